@@ -0,0 +1,264 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// SignedBundleVersion is the current version of the signed bundle format.
+const SignedBundleVersion = 1
+
+// SignedBundle is a set of signed operations generated offline.
+type SignedBundle struct {
+	Version               uint64
+	VoluntaryExits        []*phase0.SignedVoluntaryExit
+	BLSToExecutionChanges []*capella.SignedBLSToExecutionChange
+	Deposits              []*util.DepositInfo
+}
+
+type signedBundleJSON struct {
+	Version               string                                `json:"version"`
+	VoluntaryExits        []*phase0.SignedVoluntaryExit         `json:"voluntary_exits"`
+	BLSToExecutionChanges []*capella.SignedBLSToExecutionChange `json:"bls_to_execution_changes"`
+	Deposits              []*depositJSON                        `json:"deposits"`
+}
+
+// depositJSON is a deposit in the format generated by "validator depositdata".
+type depositJSON struct {
+	Name                  string `json:"name,omitempty"`
+	Account               string `json:"account,omitempty"`
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Signature             string `json:"signature"`
+	Amount                uint64 `json:"amount"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	ForkVersion           string `json:"fork_version"`
+	Version               uint64 `json:"version"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (b *SignedBundle) MarshalJSON() ([]byte, error) {
+	data := &signedBundleJSON{
+		Version:               strconv.FormatUint(b.Version, 10),
+		VoluntaryExits:        b.VoluntaryExits,
+		BLSToExecutionChanges: b.BLSToExecutionChanges,
+		Deposits:              make([]*depositJSON, 0, len(b.Deposits)),
+	}
+	if data.VoluntaryExits == nil {
+		data.VoluntaryExits = make([]*phase0.SignedVoluntaryExit, 0)
+	}
+	if data.BLSToExecutionChanges == nil {
+		data.BLSToExecutionChanges = make([]*capella.SignedBLSToExecutionChange, 0)
+	}
+	for _, deposit := range b.Deposits {
+		data.Deposits = append(data.Deposits, &depositJSON{
+			Name:                  deposit.Name,
+			Account:               deposit.Account,
+			PublicKey:             fmt.Sprintf("%#x", deposit.PublicKey),
+			WithdrawalCredentials: fmt.Sprintf("%#x", deposit.WithdrawalCredentials),
+			Signature:             fmt.Sprintf("%#x", deposit.Signature),
+			Amount:                deposit.Amount,
+			DepositDataRoot:       fmt.Sprintf("%#x", deposit.DepositDataRoot),
+			DepositMessageRoot:    fmt.Sprintf("%#x", deposit.DepositMessageRoot),
+			ForkVersion:           fmt.Sprintf("%#x", deposit.ForkVersion),
+			Version:               3,
+		})
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *SignedBundle) UnmarshalJSON(input []byte) error {
+	var data signedBundleJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+	if data.Version == "" {
+		return errors.New("version missing")
+	}
+	version, err := strconv.ParseUint(data.Version, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "version invalid")
+	}
+	if version != SignedBundleVersion {
+		return fmt.Errorf("unsupported version %d", version)
+	}
+	b.Version = version
+	b.VoluntaryExits = data.VoluntaryExits
+	b.BLSToExecutionChanges = data.BLSToExecutionChanges
+	b.Deposits = nil
+	if len(data.Deposits) > 0 {
+		// Decode through the common deposit data parser, to ensure that the deposits are complete.
+		deposits, err := json.Marshal(data.Deposits)
+		if err != nil {
+			return errors.Wrap(err, "invalid deposits")
+		}
+		b.Deposits, err = util.DepositInfoFromJSON(deposits)
+		if err != nil {
+			return errors.Wrap(err, "invalid deposits")
+		}
+	}
+
+	return nil
+}
+
+// Operations returns the number of operations in the bundle.
+func (b *SignedBundle) Operations() int {
+	return len(b.VoluntaryExits) + len(b.BLSToExecutionChanges) + len(b.Deposits)
+}
+
+// Verify verifies the signatures of all operations in the bundle against the chain information.
+func (b *SignedBundle) Verify(ctx context.Context, chainInfo *ChainInfo) error {
+	if len(b.VoluntaryExits) > 0 {
+		domain, err := chainInfo.VoluntaryExitDomain()
+		if err != nil {
+			return err
+		}
+		for _, exit := range b.VoluntaryExits {
+			validatorInfo, err := chainInfo.FetchValidatorInfo(ctx, fmt.Sprintf("%d", exit.Message.ValidatorIndex))
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to obtain validator %d for exit", exit.Message.ValidatorIndex))
+			}
+			root, err := exit.Message.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "failed to generate exit root")
+			}
+			if err := verifySignature(root, domain, validatorInfo.Pubkey[:], exit.Signature); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("exit for validator %d", exit.Message.ValidatorIndex))
+			}
+		}
+	}
+
+	if len(b.BLSToExecutionChanges) > 0 {
+		domain, err := chainInfo.BLSToExecutionChangeDomain()
+		if err != nil {
+			return err
+		}
+		for _, change := range b.BLSToExecutionChanges {
+			validatorInfo, err := chainInfo.FetchValidatorInfo(ctx, fmt.Sprintf("%d", change.Message.ValidatorIndex))
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("failed to obtain validator %d for credentials change", change.Message.ValidatorIndex))
+			}
+			withdrawalCredentials := ethutil.SHA256(change.Message.FromBLSPubkey[:])
+			if !bytes.Equal(withdrawalCredentials[1:], validatorInfo.WithdrawalCredentials[1:]) {
+				return fmt.Errorf("credentials change for validator %d is not from its withdrawal key", change.Message.ValidatorIndex)
+			}
+			root, err := change.Message.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "failed to generate credentials change root")
+			}
+			if err := verifySignature(root, domain, change.Message.FromBLSPubkey[:], change.Signature); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("credentials change for validator %d", change.Message.ValidatorIndex))
+			}
+		}
+	}
+
+	if len(b.Deposits) > 0 {
+		domain := chainInfo.DepositDomain()
+		for _, deposit := range b.Deposits {
+			depositData, err := DepositData(deposit)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("deposit for %#x", deposit.PublicKey))
+			}
+			root, err := (&phase0.DepositMessage{
+				PublicKey:             depositData.PublicKey,
+				WithdrawalCredentials: depositData.WithdrawalCredentials,
+				Amount:                depositData.Amount,
+			}).HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "failed to generate deposit message root")
+			}
+			if !bytes.Equal(root[:], deposit.DepositMessageRoot) {
+				return fmt.Errorf("deposit for %#x has incorrect deposit message root", deposit.PublicKey)
+			}
+			dataRoot, err := depositData.HashTreeRoot()
+			if err != nil {
+				return errors.Wrap(err, "failed to generate deposit data root")
+			}
+			if !bytes.Equal(dataRoot[:], deposit.DepositDataRoot) {
+				return fmt.Errorf("deposit for %#x has incorrect deposit data root", deposit.PublicKey)
+			}
+			if err := verifySignature(root, domain, depositData.PublicKey[:], depositData.Signature); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("deposit for %#x", deposit.PublicKey))
+			}
+		}
+	}
+
+	return nil
+}
+
+// DepositData returns the deposit data for a deposit.
+func DepositData(deposit *util.DepositInfo) (*phase0.DepositData, error) {
+	if len(deposit.PublicKey) != phase0.PublicKeyLength {
+		return nil, errors.New("invalid public key length")
+	}
+	if len(deposit.WithdrawalCredentials) != 32 {
+		return nil, errors.New("invalid withdrawal credentials length")
+	}
+	if len(deposit.Signature) != phase0.SignatureLength {
+		return nil, errors.New("invalid signature length")
+	}
+
+	depositData := &phase0.DepositData{
+		WithdrawalCredentials: deposit.WithdrawalCredentials,
+		Amount:                phase0.Gwei(deposit.Amount),
+	}
+	copy(depositData.PublicKey[:], deposit.PublicKey)
+	copy(depositData.Signature[:], deposit.Signature)
+
+	return depositData, nil
+}
+
+func verifySignature(root phase0.Root,
+	domain phase0.Domain,
+	pubkeyBytes []byte,
+	signature phase0.BLSSignature,
+) error {
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate signing root")
+	}
+
+	// Copy the key, as the BLS library cannot accept memory that is part of a larger structure.
+	pubkey, err := e2types.BLSPublicKeyFromBytes(append([]byte{}, pubkeyBytes...))
+	if err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	sig, err := e2types.BLSSignatureFromBytes(signature[:])
+	if err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !sig.Verify(signingRoot[:], pubkey) {
+		return errors.New("signature does not verify")
+	}
+
+	return nil
+}
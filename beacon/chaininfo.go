@@ -40,6 +40,8 @@ type ChainInfo struct {
 	CurrentForkVersion             phase0.Version
 	BLSToExecutionChangeDomainType phase0.DomainType
 	VoluntaryExitDomainType        phase0.DomainType
+	SlotsPerEpoch                  uint64
+	ForkSchedule                   []*phase0.Fork
 }
 
 type chainInfoJSON struct {
@@ -52,6 +54,8 @@ type chainInfoJSON struct {
 	CurrentForkVersion             string           `json:"current_fork_version"`
 	BLSToExecutionChangeDomainType string           `json:"bls_to_execution_change_domain_type"`
 	VoluntaryExitDomainType        string           `json:"voluntary_exit_domain_type"`
+	SlotsPerEpoch                  string           `json:"slots_per_epoch,omitempty"`
+	ForkSchedule                   []*phase0.Fork   `json:"fork_schedule,omitempty"`
 }
 
 type chainInfoVersionJSON struct {
@@ -60,6 +64,11 @@ type chainInfoVersionJSON struct {
 
 // MarshalJSON implements json.Marshaler.
 func (c *ChainInfo) MarshalJSON() ([]byte, error) {
+	slotsPerEpoch := ""
+	if c.SlotsPerEpoch != 0 {
+		slotsPerEpoch = strconv.FormatUint(c.SlotsPerEpoch, 10)
+	}
+
 	return json.Marshal(&chainInfoJSON{
 		Version:                        strconv.FormatUint(c.Version, 10),
 		Validators:                     c.Validators,
@@ -70,6 +79,8 @@ func (c *ChainInfo) MarshalJSON() ([]byte, error) {
 		CurrentForkVersion:             fmt.Sprintf("%#x", c.CurrentForkVersion),
 		BLSToExecutionChangeDomainType: fmt.Sprintf("%#x", c.BLSToExecutionChangeDomainType),
 		VoluntaryExitDomainType:        fmt.Sprintf("%#x", c.VoluntaryExitDomainType),
+		SlotsPerEpoch:                  slotsPerEpoch,
+		ForkSchedule:                   c.ForkSchedule,
 	})
 }
 
@@ -183,6 +194,15 @@ func (c *ChainInfo) UnmarshalJSON(input []byte) error {
 	}
 	copy(c.VoluntaryExitDomainType[:], voluntaryExitDomainType)
 
+	// Slots per epoch and the fork schedule are not present in older chain information.
+	if data.SlotsPerEpoch != "" {
+		c.SlotsPerEpoch, err = strconv.ParseUint(data.SlotsPerEpoch, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "slots per epoch invalid")
+		}
+	}
+	c.ForkSchedule = data.ForkSchedule
+
 	return nil
 }
 
@@ -251,10 +271,9 @@ func ObtainChainInfoFromNode(ctx context.Context,
 	*ChainInfo,
 	error,
 ) {
-	res := &ChainInfo{
-		Version:    3,
-		Validators: make([]*ValidatorInfo, 0),
-		Epoch:      chainTime.CurrentEpoch(),
+	res, err := ObtainSigningInfoFromNode(ctx, consensusClient, chainTime)
+	if err != nil {
+		return nil, err
 	}
 
 	// Obtain validators.
@@ -276,6 +295,24 @@ func ObtainChainInfoFromNode(ctx context.Context,
 		return res.Validators[i].Index < res.Validators[j].Index
 	})
 
+	return res, nil
+}
+
+// ObtainSigningInfoFromNode obtains the chain information required to calculate signature
+// domains from a node.  It does not obtain validator information.
+func ObtainSigningInfoFromNode(ctx context.Context,
+	consensusClient consensusclient.Service,
+	chainTime chaintime.Service,
+) (
+	*ChainInfo,
+	error,
+) {
+	res := &ChainInfo{
+		Version:    3,
+		Validators: make([]*ValidatorInfo, 0),
+		Epoch:      chainTime.CurrentEpoch(),
+	}
+
 	// Genesis validators root obtained from beacon node.
 	genesisResponse, err := consensusClient.(consensusclient.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
 	if err != nil {
@@ -318,6 +355,8 @@ func ObtainChainInfoFromNode(ctx context.Context,
 			res.CurrentForkVersion = forkScheduleResponse.Data[i].CurrentVersion
 		}
 	}
+	res.ForkSchedule = forkScheduleResponse.Data
+	res.SlotsPerEpoch = chainTime.SlotsPerEpoch()
 
 	blsToExecutionChangeDomainType, exists := specResponse.Data["DOMAIN_BLS_TO_EXECUTION_CHANGE"].(phase0.DomainType)
 	if !exists {
@@ -0,0 +1,106 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package beacon
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// VoluntaryExitDomain returns the signature domain for voluntary exits.
+func (c *ChainInfo) VoluntaryExitDomain() (phase0.Domain, error) {
+	// Exits are signed with the Capella fork version as per the spec.
+	return computeDomain(c.VoluntaryExitDomainType, c.ExitForkVersion, c.GenesisValidatorsRoot)
+}
+
+// BLSToExecutionChangeDomain returns the signature domain for BLS to execution changes.
+func (c *ChainInfo) BLSToExecutionChangeDomain() (phase0.Domain, error) {
+	return computeDomain(c.BLSToExecutionChangeDomainType, c.GenesisForkVersion, c.GenesisValidatorsRoot)
+}
+
+// CurrentDomain returns the signature domain for the given domain type at the current fork.
+func (c *ChainInfo) CurrentDomain(domainType phase0.DomainType) (phase0.Domain, error) {
+	return computeDomain(domainType, c.CurrentForkVersion, c.GenesisValidatorsRoot)
+}
+
+// DomainAtEpoch returns the signature domain for the given domain type at the fork active at the given epoch.
+func (c *ChainInfo) DomainAtEpoch(domainType phase0.DomainType, epoch phase0.Epoch) (phase0.Domain, error) {
+	forkVersion, err := c.ForkVersionAtEpoch(epoch)
+	if err != nil {
+		return phase0.Domain{}, err
+	}
+
+	return computeDomain(domainType, forkVersion, c.GenesisValidatorsRoot)
+}
+
+// DomainAtSlot returns the signature domain for the given domain type at the fork active at the given slot.
+func (c *ChainInfo) DomainAtSlot(domainType phase0.DomainType, slot phase0.Slot) (phase0.Domain, error) {
+	if c.SlotsPerEpoch == 0 {
+		return phase0.Domain{}, errors.New("slots per epoch not known; please regenerate chain information")
+	}
+
+	return c.DomainAtEpoch(domainType, phase0.Epoch(uint64(slot)/c.SlotsPerEpoch))
+}
+
+// ForkVersionAtEpoch returns the fork version active at the given epoch.
+func (c *ChainInfo) ForkVersionAtEpoch(epoch phase0.Epoch) (phase0.Version, error) {
+	if len(c.ForkSchedule) == 0 {
+		return phase0.Version{}, errors.New("fork schedule not known; please regenerate chain information")
+	}
+
+	var activeFork *phase0.Fork
+	for _, fork := range c.ForkSchedule {
+		if fork.Epoch <= epoch && (activeFork == nil || fork.Epoch >= activeFork.Epoch) {
+			activeFork = fork
+		}
+	}
+	if activeFork == nil {
+		return phase0.Version{}, fmt.Errorf("no fork active at epoch %d", epoch)
+	}
+
+	return activeFork.CurrentVersion, nil
+}
+
+// DepositDomain returns the signature domain for deposits.
+func (c *ChainInfo) DepositDomain() phase0.Domain {
+	domain := phase0.Domain{}
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, c.GenesisForkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+
+	return domain
+}
+
+func computeDomain(domainType phase0.DomainType,
+	forkVersion phase0.Version,
+	genesisValidatorsRoot phase0.Root,
+) (
+	phase0.Domain,
+	error,
+) {
+	root, err := (&phase0.ForkData{
+		CurrentVersion:        forkVersion,
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Domain{}, errors.Wrap(err, "failed to calculate signature domain")
+	}
+
+	domain := phase0.Domain{}
+	copy(domain[:], domainType[:])
+	copy(domain[4:], root[:])
+
+	return domain, nil
+}
@@ -0,0 +1,327 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountderive
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// batchKey is a key derived as part of a batch.
+type batchKey struct {
+	index       uint64
+	path        string
+	key         *e2types.BLSPrivateKey
+	keystore    []byte
+	depositData *depositInfo
+}
+
+// depositInfo is an ethdo V3 deposit structure.
+type depositInfo struct {
+	Name                  string `json:"name"`
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Signature             string `json:"signature"`
+	Amount                uint64 `json:"amount"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	ForkVersion           string `json:"fork_version"`
+	Version               uint64 `json:"version"`
+}
+
+func processBatch(_ context.Context, data *dataIn) (*dataOut, error) {
+	seed, err := util.SeedFromMnemonic(data.mnemonic)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive seed")
+	}
+
+	var withdrawalCredentials []byte
+	if data.withdrawalAddress != "" {
+		withdrawalCredentials, err = addressWithdrawalCredentials(data.withdrawalAddress, data.compounding)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	domain := spec.Domain{}
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, data.forkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+
+	keys := make([]*batchKey, data.count)
+	for i := range keys {
+		index := data.fromIndex + uint64(i)
+		keys[i] = &batchKey{
+			index: index,
+			path:  fmt.Sprintf("m/12381/3600/%d/0/0", index),
+		}
+	}
+
+	// Key encryption is expensive, so spread the work over the available CPUs.
+	workers := runtime.NumCPU()
+	if workers > len(keys) {
+		workers = len(keys)
+	}
+	jobs := make(chan *batchKey)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			encryptor := keystorev4.New(keystorev4.WithCipher(data.kdf))
+			for key := range jobs {
+				if errs[worker] != nil {
+					// Drain remaining jobs.
+					continue
+				}
+				errs[worker] = processBatchKey(seed, key, encryptor, data, withdrawalCredentials, domain)
+			}
+		}(i)
+	}
+	for _, key := range keys {
+		jobs <- key
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeBatch(data.outputDir, keys); err != nil {
+		return nil, err
+	}
+
+	return &dataOut{
+		batch:     keys,
+		outputDir: data.outputDir,
+	}, nil
+}
+
+// writeBatch writes the keystores and deposit data for a batch of keys, using the same
+// filenames as the staking deposit CLI.
+func writeBatch(outputDir string, keys []*batchKey) error {
+	timestamp := time.Now().Unix()
+
+	if err := os.MkdirAll(outputDir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create output directory")
+	}
+
+	depositData := make([]*depositInfo, 0, len(keys))
+	for _, key := range keys {
+		keystoreFilename := filepath.Join(outputDir, fmt.Sprintf("keystore-%s-%d.json", strings.ReplaceAll(key.path, "/", "_"), timestamp))
+		if err := os.WriteFile(keystoreFilename, key.keystore, 0o600); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to write %s", keystoreFilename))
+		}
+		depositData = append(depositData, key.depositData)
+	}
+
+	out, err := json.Marshal(depositData)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal deposit data JSON")
+	}
+	depositDataFilename := filepath.Join(outputDir, fmt.Sprintf("deposit_data-%d.json", timestamp))
+	if err := os.WriteFile(depositDataFilename, out, 0o600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", depositDataFilename))
+	}
+
+	return nil
+}
+
+// processBatchKey derives the key, generates its keystore and signs its deposit data.
+func processBatchKey(seed []byte,
+	batchKey *batchKey,
+	encryptor *keystorev4.Encryptor,
+	data *dataIn,
+	withdrawalCredentials []byte,
+	domain spec.Domain,
+) error {
+	var err error
+	batchKey.key, err = ethutil.PrivateKeyFromSeedAndPath(seed, batchKey.path)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to generate key for %s", batchKey.path))
+	}
+
+	batchKey.keystore, err = generateKeystore(batchKey.key, batchKey.path, data.passphrase, encryptor)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to generate keystore for %s", batchKey.path))
+	}
+
+	if withdrawalCredentials == nil {
+		// Use the BLS withdrawal key from the EIP-2334 path.
+		withdrawalPath := fmt.Sprintf("m/12381/3600/%d/0", batchKey.index)
+		withdrawalKey, err := ethutil.PrivateKeyFromSeedAndPath(seed, withdrawalPath)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to generate key for %s", withdrawalPath))
+		}
+		withdrawalCredentials = ethutil.SHA256(withdrawalKey.PublicKey().Marshal())
+		withdrawalCredentials[0] = byte(0) // BLS_WITHDRAWAL_PREFIX
+	}
+
+	batchKey.depositData, err = generateDepositData(batchKey, withdrawalCredentials, data.amount, data.forkVersion, domain)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to generate deposit data for %s", batchKey.path))
+	}
+
+	return nil
+}
+
+func generateDepositData(batchKey *batchKey,
+	withdrawalCredentials []byte,
+	amount spec.Gwei,
+	forkVersion spec.Version,
+	domain spec.Domain,
+) (
+	*depositInfo,
+	error,
+) {
+	var pubKey spec.BLSPubKey
+	copy(pubKey[:], batchKey.key.PublicKey().Marshal())
+	depositMessage := &spec.DepositMessage{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+	}
+	depositMessageRoot, err := depositMessage.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit message root")
+	}
+
+	signingRoot, err := (&spec.SigningData{
+		ObjectRoot: depositMessageRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate signing root")
+	}
+	var sig spec.BLSSignature
+	copy(sig[:], batchKey.key.Sign(signingRoot[:]).Marshal())
+
+	depositDataRoot, err := (&spec.DepositData{
+		PublicKey:             pubKey,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+		Signature:             sig,
+	}).HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate deposit data root")
+	}
+
+	return &depositInfo{
+		Name:                  fmt.Sprintf("Deposit for %s", batchKey.path),
+		PublicKey:             fmt.Sprintf("%#x", pubKey),
+		WithdrawalCredentials: fmt.Sprintf("%#x", withdrawalCredentials),
+		Signature:             fmt.Sprintf("%#x", sig),
+		Amount:                uint64(amount),
+		DepositDataRoot:       fmt.Sprintf("%#x", depositDataRoot),
+		DepositMessageRoot:    fmt.Sprintf("%#x", depositMessageRoot),
+		ForkVersion:           fmt.Sprintf("%#x", forkVersion),
+		Version:               3,
+	}, nil
+}
+
+// generateKeystore generates an EIP-2335 keystore for the key.
+func generateKeystore(key *e2types.BLSPrivateKey,
+	path string,
+	passphrase string,
+	encryptor *keystorev4.Encryptor,
+) (
+	[]byte,
+	error,
+) {
+	crypto, err := encryptor.Encrypt(key.Marshal(), passphrase)
+	if err != nil {
+		return nil, errors.New("failed to encrypt private key")
+	}
+
+	uuid, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.New("failed to generate UUID")
+	}
+	ks := make(map[string]interface{})
+	ks["uuid"] = uuid.String()
+	ks["pubkey"] = hex.EncodeToString(key.PublicKey().Marshal())
+	ks["version"] = 4
+	ks["path"] = path
+	ks["crypto"] = crypto
+	out, err := json.Marshal(ks)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal keystore JSON")
+	}
+
+	return out, nil
+}
+
+// addressWithdrawalCredentials creates withdrawal credentials for an Ethereum execution address.
+func addressWithdrawalCredentials(input string, compounding bool) ([]byte, error) {
+	address, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode withdrawal address")
+	}
+	if len(address) != 20 {
+		return nil, errors.New("withdrawal address must be exactly 20 bytes in length")
+	}
+	// Ensure the address is properly checksummed.
+	checksummedAddress := addressBytesToEIP55(address)
+	if checksummedAddress != input {
+		return nil, fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+	}
+
+	withdrawalCredentials := make([]byte, 32)
+	copy(withdrawalCredentials[12:32], address)
+	// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
+	if compounding {
+		withdrawalCredentials[0] = byte(2) // COMPOUNDING_WITHDRAWAL_PREFIX
+	} else {
+		withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+	}
+
+	return withdrawalCredentials, nil
+}
+
+// addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
+func addressBytesToEIP55(address []byte) string {
+	bytes := []byte(hex.EncodeToString(address))
+	hash := ethutil.Keccak256(bytes)
+	for i := 0; i < len(bytes); i++ {
+		hashByte := hash[i/2]
+		if i%2 == 0 {
+			hashByte >>= 4
+		} else {
+			hashByte &= 0xf
+		}
+		if bytes[i] > '9' && hashByte > 7 {
+			bytes[i] -= 32
+		}
+	}
+
+	return fmt.Sprintf("0x%s", string(bytes))
+}
@@ -0,0 +1,137 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountderive
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+func TestProcessBatch(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	tests := []struct {
+		name                  string
+		dataIn                *dataIn
+		pubKeys               [][]byte
+		withdrawalCredentials [][]byte
+		err                   string
+	}{
+		{
+			name: "MnemonicInvalid",
+			dataIn: &dataIn{
+				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+				count:    1,
+			},
+			err: "failed to derive seed: mnemonic is invalid",
+		},
+		{
+			name: "WithdrawalAddressChecksum",
+			dataIn: &dataIn{
+				mnemonic:          "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				count:             1,
+				withdrawalAddress: "0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+			},
+			err: "withdrawal address checksum does not match (expected 0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15)",
+		},
+		{
+			name: "BLSWithdrawalCredentials",
+			dataIn: &dataIn{
+				mnemonic:   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				count:      2,
+				passphrase: "secret",
+				kdf:        "pbkdf2",
+				amount:     32000000000,
+			},
+			pubKeys: [][]byte{
+				testutil.HexToBytes("0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87"),
+				testutil.HexToBytes("0xb3d89e2f29c712c6a9f8e5a269b97617c4a94dd6f6662ab3b07ce9e5434573f15b5c988cd14bbd5804f77156a8af1cfa"),
+			},
+			withdrawalCredentials: [][]byte{
+				testutil.HexToBytes("0x008ba1cc4b091b91c1202bba3f508075d6ff565c77e559f0803c0792e0302bf1"),
+				testutil.HexToBytes("0x00786cb02ed28e5fbb1f7f9e931a2b72692906e6b12ce4643975e32b517691f2"),
+			},
+		},
+		{
+			name: "AddressWithdrawalCredentials",
+			dataIn: &dataIn{
+				mnemonic:          "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				fromIndex:         1,
+				count:             1,
+				passphrase:        "secret",
+				kdf:               "pbkdf2",
+				withdrawalAddress: "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15",
+				compounding:       true,
+				amount:            32000000000,
+			},
+			pubKeys: [][]byte{
+				testutil.HexToBytes("0xb3d89e2f29c712c6a9f8e5a269b97617c4a94dd6f6662ab3b07ce9e5434573f15b5c988cd14bbd5804f77156a8af1cfa"),
+			},
+			withdrawalCredentials: [][]byte{
+				testutil.HexToBytes("0x0200000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15"),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.dataIn.outputDir = t.TempDir()
+			res, err := process(context.Background(), test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.batch, len(test.pubKeys))
+
+			// Check the deposit data.
+			depositDataFiles, err := filepath.Glob(filepath.Join(test.dataIn.outputDir, "deposit_data-*.json"))
+			require.NoError(t, err)
+			require.Len(t, depositDataFiles, 1)
+			data, err := os.ReadFile(depositDataFiles[0])
+			require.NoError(t, err)
+			depositInfos, err := util.DepositInfoFromJSON(data)
+			require.NoError(t, err)
+			require.Len(t, depositInfos, len(test.pubKeys))
+			for i := range depositInfos {
+				require.Equal(t, test.pubKeys[i], depositInfos[i].PublicKey)
+				require.Equal(t, test.withdrawalCredentials[i], depositInfos[i].WithdrawalCredentials)
+			}
+
+			// Check the keystores.
+			keystoreFiles, err := filepath.Glob(filepath.Join(test.dataIn.outputDir, "keystore-*.json"))
+			require.NoError(t, err)
+			require.Len(t, keystoreFiles, len(test.pubKeys))
+			for i, key := range res.batch {
+				data, err := os.ReadFile(keystoreFiles[i])
+				require.NoError(t, err)
+				keystore := make(map[string]interface{})
+				require.NoError(t, json.Unmarshal(data, &keystore))
+				require.Equal(t, key.path, keystore["path"])
+				secret, err := keystorev4.New().Decrypt(keystore["crypto"].(map[string]interface{}), test.dataIn.passphrase)
+				require.NoError(t, err)
+				require.Equal(t, key.key.Marshal(), secret)
+			}
+		})
+	}
+}
@@ -15,9 +15,14 @@ package accountderive
 
 import (
 	"context"
+	"encoding/hex"
+	"strings"
 
+	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	string2eth "github.com/wealdtech/go-string2eth"
 )
 
 type dataIn struct {
@@ -30,9 +35,19 @@ type dataIn struct {
 	showPrivateKey            bool
 	showWithdrawalCredentials bool
 	generateKeystore          bool
+	// Batch information.
+	fromIndex         uint64
+	count             uint64
+	outputDir         string
+	passphrase        string
+	kdf               string
+	withdrawalAddress string
+	compounding       bool
+	amount            spec.Gwei
+	forkVersion       spec.Version
 }
 
-func input(_ context.Context) (*dataIn, error) {
+func input(ctx context.Context) (*dataIn, error) {
 	data := &dataIn{}
 
 	// Quiet.
@@ -47,6 +62,20 @@ func input(_ context.Context) (*dataIn, error) {
 	}
 	data.mnemonic = viper.GetString("mnemonic")
 
+	// Batch.
+	data.fromIndex = viper.GetUint64("from-index")
+	data.count = viper.GetUint64("count")
+	if data.count > 0 {
+		if viper.GetString("path") != "" {
+			return nil, errors.New("path cannot be supplied with count")
+		}
+		if err := inputBatch(ctx, data); err != nil {
+			return nil, err
+		}
+
+		return data, nil
+	}
+
 	// Path.
 	if viper.GetString("path") == "" {
 		return nil, errors.New("path is required")
@@ -64,3 +93,61 @@ func input(_ context.Context) (*dataIn, error) {
 
 	return data, nil
 }
+
+// inputBatch obtains the input for batch derivation.
+func inputBatch(_ context.Context, data *dataIn) error {
+	data.outputDir = viper.GetString("output-dir")
+	if data.outputDir == "" {
+		data.outputDir = "."
+	}
+
+	var err error
+	data.passphrase, err = util.GetPassphrase()
+	if err != nil {
+		return errors.New("a single passphrase is required to generate keystores")
+	}
+
+	data.kdf = viper.GetString("kdf")
+	switch data.kdf {
+	case "":
+		data.kdf = "scrypt"
+	case "scrypt", "pbkdf2":
+		// Good.
+	default:
+		return errors.New("kdf must be one of scrypt or pbkdf2")
+	}
+
+	data.withdrawalAddress = viper.GetString("withdrawaladdress")
+	data.compounding = viper.GetBool("compounding")
+	if data.compounding && data.withdrawalAddress == "" {
+		return errors.New("compounding withdrawal credentials require a withdrawal address")
+	}
+
+	depositValue := viper.GetString("depositvalue")
+	if depositValue == "" {
+		depositValue = "32 Ether"
+	}
+	amount, err := string2eth.StringToGWei(depositValue)
+	if err != nil {
+		return errors.Wrap(err, "deposit value is invalid")
+	}
+	data.amount = spec.Gwei(amount)
+	// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
+	if data.amount < 1000000000 { // MIN_DEPOSIT_AMOUNT
+		return errors.New("deposit value must be at least 1 Ether")
+	}
+
+	// Default to mainnet.
+	if viper.GetString("forkversion") != "" {
+		forkVersion, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("forkversion"), "0x"))
+		if err != nil {
+			return errors.Wrap(err, "failed to decode fork version")
+		}
+		if len(forkVersion) != 4 {
+			return errors.New("fork version must be exactly 4 bytes in length")
+		}
+		copy(data.forkVersion[:], forkVersion)
+	}
+
+	return nil
+}
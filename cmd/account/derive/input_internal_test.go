@@ -45,6 +45,65 @@ func TestInput(t *testing.T) {
 			},
 			err: "path is required",
 		},
+		{
+			name: "PathWithCount",
+			vars: map[string]interface{}{
+				"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"path":     "m/12381/3600/0/0",
+				"count":    2,
+			},
+			err: "path cannot be supplied with count",
+		},
+		{
+			name: "CountPassphraseMissing",
+			vars: map[string]interface{}{
+				"mnemonic": "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"count":    2,
+			},
+			err: "a single passphrase is required to generate keystores",
+		},
+		{
+			name: "CountKDFInvalid",
+			vars: map[string]interface{}{
+				"mnemonic":   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"count":      2,
+				"passphrase": "secret",
+				"kdf":        "bad",
+			},
+			err: "kdf must be one of scrypt or pbkdf2",
+		},
+		{
+			name: "CountDepositValueLow",
+			vars: map[string]interface{}{
+				"mnemonic":     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"count":        2,
+				"passphrase":   "secret",
+				"depositvalue": "0.5 Ether",
+			},
+			err: "deposit value must be at least 1 Ether",
+		},
+		{
+			name: "CountCompoundingWithoutAddress",
+			vars: map[string]interface{}{
+				"mnemonic":    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"count":       2,
+				"passphrase":  "secret",
+				"compounding": true,
+			},
+			err: "compounding withdrawal credentials require a withdrawal address",
+		},
+		{
+			name: "CountGood",
+			vars: map[string]interface{}{
+				"mnemonic":   "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+				"from-index": 5,
+				"count":      2,
+				"passphrase": "secret",
+			},
+			res: &dataIn{
+				mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			},
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
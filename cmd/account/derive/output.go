@@ -15,14 +15,11 @@ package accountderive
 
 import (
 	"context"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
@@ -37,12 +34,18 @@ type dataOut struct {
 	generateKeystore          bool
 	key                       *e2types.BLSPrivateKey
 	path                      string
+	// Batch output.
+	batch     []*batchKey
+	outputDir string
 }
 
 func output(ctx context.Context, data *dataOut) (string, error) {
 	if data == nil {
 		return "", errors.New("no data")
 	}
+	if len(data.batch) > 0 {
+		return outputBatch(ctx, data)
+	}
 	if data.key == nil {
 		return "", errors.New("no key")
 	}
@@ -74,25 +77,9 @@ func outputKeystore(_ context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no passphrase supplied")
 	}
 
-	encryptor := keystorev4.New()
-	crypto, err := encryptor.Encrypt(data.key.Marshal(), passphrase)
-	if err != nil {
-		return "", errors.New("failed to encrypt private key")
-	}
-
-	uuid, err := uuid.NewRandom()
-	if err != nil {
-		return "", errors.New("failed to generate UUID")
-	}
-	ks := make(map[string]interface{})
-	ks["uuid"] = uuid.String()
-	ks["pubkey"] = hex.EncodeToString(data.key.PublicKey().Marshal())
-	ks["version"] = 4
-	ks["path"] = data.path
-	ks["crypto"] = crypto
-	out, err := json.Marshal(ks)
+	out, err := generateKeystore(data.key, data.path, passphrase, keystorev4.New())
 	if err != nil {
-		return "", errors.Wrap(err, "failed to marshal keystore JSON")
+		return "", err
 	}
 
 	if data.json {
@@ -106,3 +93,14 @@ func outputKeystore(_ context.Context, data *dataOut) (string, error) {
 	}
 	return "", nil
 }
+
+// outputBatch outputs a summary of a batch of keys.
+func outputBatch(_ context.Context, data *dataOut) (string, error) {
+	builder := strings.Builder{}
+	for _, key := range data.batch {
+		builder.WriteString(fmt.Sprintf("%s: %#x\n", key.path, key.key.PublicKey().Marshal()))
+	}
+	builder.WriteString(fmt.Sprintf("Keystores and deposit data for %d validator(s) written to %s\n", len(data.batch), data.outputDir))
+
+	return builder.String(), nil
+}
@@ -27,6 +27,10 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
+	if data.count > 0 {
+		return processBatch(ctx, data)
+	}
+
 	account, err := util.ParseAccount(ctx, data.mnemonic, []string{data.path}, true)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to derive account")
@@ -0,0 +1,37 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkgcontribute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Public contribution written to %s; send it to all participants\n", c.contributionFile))
+	for i, shareFile := range c.shareFiles {
+		participant := uint64(i + 1)
+		if participant == c.participant {
+			builder.WriteString(fmt.Sprintf("Secret share for this participant written to %s; keep it for finalization\n", shareFile))
+		} else {
+			builder.WriteString(fmt.Sprintf("Secret share for participant %d written to %s; send it privately to that participant only\n", participant, shareFile))
+		}
+	}
+	builder.WriteString("Secret share files must be deleted once the ceremony has been finalized")
+
+	return builder.String(), nil
+}
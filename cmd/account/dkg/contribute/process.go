@@ -0,0 +1,67 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkgcontribute
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/dkg"
+)
+
+func (c *command) process(_ context.Context) error {
+	c.ceremony = &dkg.Ceremony{}
+	if err := dkg.ReadFile(c.ceremonyFile, c.ceremony); err != nil {
+		return err
+	}
+
+	encryptionKeys := make(map[uint64]*dkg.EncryptionKey)
+	for i := uint64(1); i <= c.ceremony.Participants; i++ {
+		encryptionKey := &dkg.EncryptionKey{}
+		if err := dkg.ReadFile(filepath.Join(c.inputDir, dkg.EncryptionKeyFilename(i)), encryptionKey); err != nil {
+			return err
+		}
+		encryptionKeys[i] = encryptionKey
+	}
+
+	contribution, shares, err := dkg.Contribute(c.ceremony, c.participant)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.outputDir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create output directory")
+	}
+
+	c.contributionFile = filepath.Join(c.outputDir, dkg.ContributionFilename(c.participant))
+	if err := dkg.WriteFile(c.contributionFile, contribution); err != nil {
+		return err
+	}
+
+	c.shareFiles = make([]string, len(shares))
+	for i, share := range shares {
+		encryptedShare, err := share.Encrypt(encryptionKeys[share.To])
+		if err != nil {
+			return err
+		}
+		c.shareFiles[i] = filepath.Join(c.outputDir, dkg.ShareFilename(share.From, share.To))
+		if err := dkg.WriteFile(c.shareFiles[i], encryptedShare); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkgfinalize
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/dkg"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	ceremonyFile string
+	participant  uint64
+	inputDir     string
+	outputDir    string
+	passphrase   string
+
+	// Data.
+	ceremony *dkg.Ceremony
+	result   *dkg.Result
+
+	// Output.
+	keystoreFile         string
+	publicFile           string
+	depositSignatureFile string
+	depositSignatures    int
+	depositDataFile      string
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:        viper.GetBool("quiet"),
+		verbose:      viper.GetBool("verbose"),
+		debug:        viper.GetBool("debug"),
+		ceremonyFile: viper.GetString("ceremony"),
+		participant:  viper.GetUint64("participant"),
+		inputDir:     viper.GetString("input-dir"),
+		outputDir:    viper.GetString("output-dir"),
+	}
+
+	if c.ceremonyFile == "" {
+		return nil, errors.New("ceremony file is required")
+	}
+	if c.participant == 0 {
+		return nil, errors.New("participant is required")
+	}
+	if c.inputDir == "" {
+		c.inputDir = "."
+	}
+	if c.outputDir == "" {
+		c.outputDir = "."
+	}
+
+	var err error
+	c.passphrase, err = util.GetPassphrase()
+	if err != nil {
+		return nil, errors.New("a single passphrase is required to decrypt the encryption key and encrypt the keystore")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,40 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkgfinalize
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Composite public key: %#x\n", c.result.PublicKey))
+	participantKey := c.result.ParticipantPublicKeys[c.participant]
+	builder.WriteString(fmt.Sprintf("Participant public key: %#x\n", participantKey))
+	builder.WriteString(fmt.Sprintf("Keystore written to %s\n", c.keystoreFile))
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Public information written to %s\n", c.publicFile))
+	}
+	builder.WriteString(fmt.Sprintf("Deposit signature written to %s; send it to the other participants\n", c.depositSignatureFile))
+	if c.depositDataFile != "" {
+		builder.WriteString(fmt.Sprintf("Deposit data written to %s", c.depositDataFile))
+	} else {
+		builder.WriteString(fmt.Sprintf("%d of %d deposit signatures required for deposit data available", c.depositSignatures, c.ceremony.Threshold))
+	}
+
+	return builder.String(), nil
+}
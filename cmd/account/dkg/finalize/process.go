@@ -0,0 +1,233 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkgfinalize
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/dkg"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// publicInfo is the public outcome of the ceremony.
+type publicInfo struct {
+	CeremonyID   string            `json:"ceremony_id"`
+	Threshold    uint64            `json:"signing_threshold"`
+	PublicKey    string            `json:"pubkey"`
+	Participants map[string]string `json:"participants"`
+}
+
+// depositInfo is an ethdo V3 deposit structure.
+type depositInfo struct {
+	Name                  string `json:"name"`
+	PublicKey             string `json:"pubkey"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Signature             string `json:"signature"`
+	Amount                uint64 `json:"amount"`
+	DepositDataRoot       string `json:"deposit_data_root"`
+	DepositMessageRoot    string `json:"deposit_message_root"`
+	ForkVersion           string `json:"fork_version"`
+	Version               uint64 `json:"version"`
+}
+
+func (c *command) process(_ context.Context) error {
+	c.ceremony = &dkg.Ceremony{}
+	if err := dkg.ReadFile(c.ceremonyFile, c.ceremony); err != nil {
+		return err
+	}
+
+	encryptionSecret := &dkg.EncryptionSecret{}
+	if err := dkg.ReadFile(filepath.Join(c.inputDir, dkg.EncryptionSecretFilename(c.participant)), encryptionSecret); err != nil {
+		return err
+	}
+	if encryptionSecret.CeremonyID != c.ceremony.ID || encryptionSecret.Participant != c.participant {
+		return fmt.Errorf("encryption key is not for participant %d in this ceremony", c.participant)
+	}
+	encryptionKey, err := encryptionSecret.Decrypt(c.passphrase)
+	if err != nil {
+		return err
+	}
+
+	contributions := make([]*dkg.Contribution, 0, c.ceremony.Participants)
+	shares := make([]*dkg.Share, 0, c.ceremony.Participants)
+	for i := uint64(1); i <= c.ceremony.Participants; i++ {
+		contribution := &dkg.Contribution{}
+		if err := dkg.ReadFile(filepath.Join(c.inputDir, dkg.ContributionFilename(i)), contribution); err != nil {
+			return err
+		}
+		contributions = append(contributions, contribution)
+
+		encryptedShare := &dkg.EncryptedShare{}
+		if err := dkg.ReadFile(filepath.Join(c.inputDir, dkg.ShareFilename(i, c.participant)), encryptedShare); err != nil {
+			return err
+		}
+		share, err := encryptedShare.Decrypt(encryptionKey)
+		if err != nil {
+			return err
+		}
+		shares = append(shares, share)
+	}
+
+	c.result, err = dkg.Finalize(c.ceremony, c.participant, contributions, shares)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.outputDir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create output directory")
+	}
+
+	if err := c.writeKeystore(); err != nil {
+		return err
+	}
+
+	if err := c.writePublicInfo(); err != nil {
+		return err
+	}
+
+	return c.writeDeposit()
+}
+
+// writeKeystore writes the participant's share of the composite key as an EIP-2335 keystore.
+func (c *command) writeKeystore() error {
+	crypto, err := keystorev4.New().Encrypt(c.result.Key.Marshal(), c.passphrase)
+	if err != nil {
+		return errors.New("failed to encrypt private key")
+	}
+
+	uuid, err := uuid.NewRandom()
+	if err != nil {
+		return errors.New("failed to generate UUID")
+	}
+	ks := make(map[string]interface{})
+	ks["uuid"] = uuid.String()
+	ks["pubkey"] = hex.EncodeToString(c.result.Key.PublicKey().Marshal())
+	ks["version"] = 4
+	ks["path"] = ""
+	ks["crypto"] = crypto
+	data, err := json.Marshal(ks)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal keystore JSON")
+	}
+
+	c.keystoreFile = filepath.Join(c.outputDir, fmt.Sprintf("dkg-keystore-%d.json", c.participant))
+	if err := os.WriteFile(c.keystoreFile, data, 0o600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", c.keystoreFile))
+	}
+
+	return nil
+}
+
+// writePublicInfo writes the composite public key and the public keys of all participants.
+func (c *command) writePublicInfo() error {
+	info := &publicInfo{
+		CeremonyID:   c.ceremony.ID.String(),
+		Threshold:    c.ceremony.Threshold,
+		PublicKey:    fmt.Sprintf("%#x", c.result.PublicKey),
+		Participants: make(map[string]string),
+	}
+	for participant, publicKey := range c.result.ParticipantPublicKeys {
+		info.Participants[strconv.FormatUint(participant, 10)] = fmt.Sprintf("%#x", publicKey)
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal public information JSON")
+	}
+
+	c.publicFile = filepath.Join(c.outputDir, "dkg-public.json")
+	if err := os.WriteFile(c.publicFile, data, 0o600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", c.publicFile))
+	}
+
+	return nil
+}
+
+// writeDeposit writes the participant's deposit signature and, if sufficient signatures from
+// other participants are available, the deposit data for the composite key.
+func (c *command) writeDeposit() error {
+	signature, err := c.result.SignDeposit(c.ceremony)
+	if err != nil {
+		return err
+	}
+	c.depositSignatureFile = filepath.Join(c.outputDir, dkg.DepositSignatureFilename(c.participant))
+	if err := dkg.WriteFile(c.depositSignatureFile, signature); err != nil {
+		return err
+	}
+
+	signatures := []*dkg.DepositSignature{signature}
+	for i := uint64(1); i <= c.ceremony.Participants; i++ {
+		if i == c.participant {
+			continue
+		}
+		path := filepath.Join(c.inputDir, dkg.DepositSignatureFilename(i))
+		if _, err := os.Stat(path); err != nil {
+			// Not yet finalized by this participant.
+			continue
+		}
+		signature := &dkg.DepositSignature{}
+		if err := dkg.ReadFile(path, signature); err != nil {
+			return err
+		}
+		signatures = append(signatures, signature)
+	}
+	c.depositSignatures = len(signatures)
+	if uint64(len(signatures)) < c.ceremony.Threshold {
+		return nil
+	}
+
+	deposit, err := c.result.CombineDepositSignatures(c.ceremony, signatures)
+	if err != nil {
+		return err
+	}
+	depositMessageRoot, err := c.result.DepositMessageRoot(c.ceremony)
+	if err != nil {
+		return err
+	}
+	depositDataRoot, err := deposit.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate deposit data root")
+	}
+
+	data, err := json.Marshal([]*depositInfo{
+		{
+			Name:                  fmt.Sprintf("Deposit for DKG ceremony %s", c.ceremony.ID),
+			PublicKey:             fmt.Sprintf("%#x", deposit.PublicKey),
+			WithdrawalCredentials: fmt.Sprintf("%#x", deposit.WithdrawalCredentials),
+			Signature:             fmt.Sprintf("%#x", deposit.Signature),
+			Amount:                uint64(deposit.Amount),
+			DepositDataRoot:       fmt.Sprintf("%#x", depositDataRoot),
+			DepositMessageRoot:    fmt.Sprintf("%#x", depositMessageRoot),
+			ForkVersion:           fmt.Sprintf("%#x", c.ceremony.ForkVersion),
+			Version:               3,
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal deposit data JSON")
+	}
+	c.depositDataFile = filepath.Join(c.outputDir, fmt.Sprintf("deposit_data-%d.json", time.Now().Unix()))
+	if err := os.WriteFile(c.depositDataFile, data, 0o600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", c.depositDataFile))
+	}
+
+	return nil
+}
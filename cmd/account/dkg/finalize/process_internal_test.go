@@ -0,0 +1,116 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkgfinalize
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/dkg"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	dir := t.TempDir()
+	withdrawalCredentials := testutil.HexToBytes("0x0100000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15")
+	ceremony, err := dkg.NewCeremony(3, 2, withdrawalCredentials, 32000000000, phase0.Version{})
+	require.NoError(t, err)
+	ceremonyFile := filepath.Join(dir, "dkg-ceremony.json")
+	require.NoError(t, dkg.WriteFile(ceremonyFile, ceremony))
+
+	newTestCommand := func(participant uint64) *command {
+		return &command{
+			ceremonyFile: ceremonyFile,
+			participant:  participant,
+			inputDir:     dir,
+			outputDir:    dir,
+			passphrase:   fmt.Sprintf("secret %d", participant),
+		}
+	}
+
+	// Finalizing before registration fails.
+	require.ErrorContains(t, newTestCommand(1).process(ctx), "dkg-encryption-secret-1.json")
+
+	encryptionKeys := make(map[uint64]*dkg.EncryptionKey)
+	for i := uint64(1); i <= ceremony.Participants; i++ {
+		encryptionKey, encryptionSecret, err := dkg.GenerateEncryptionKey(ceremony, i, fmt.Sprintf("secret %d", i))
+		require.NoError(t, err)
+		require.NoError(t, dkg.WriteFile(filepath.Join(dir, dkg.EncryptionSecretFilename(i)), encryptionSecret))
+		encryptionKeys[i] = encryptionKey
+	}
+
+	// Finalizing before contributions are available fails.
+	require.ErrorContains(t, newTestCommand(1).process(ctx), "dkg-contribution-1.json")
+
+	for i := uint64(1); i <= ceremony.Participants; i++ {
+		contribution, shares, err := dkg.Contribute(ceremony, i)
+		require.NoError(t, err)
+		require.NoError(t, dkg.WriteFile(filepath.Join(dir, dkg.ContributionFilename(i)), contribution))
+		for _, share := range shares {
+			encryptedShare, err := share.Encrypt(encryptionKeys[share.To])
+			require.NoError(t, err)
+			require.NoError(t, dkg.WriteFile(filepath.Join(dir, dkg.ShareFilename(share.From, share.To)), encryptedShare))
+		}
+	}
+
+	// The encryption key cannot be decrypted with another participant's passphrase.
+	wrongPassphrase := newTestCommand(1)
+	wrongPassphrase.passphrase = "secret 2"
+	require.EqualError(t, wrongPassphrase.process(ctx), "failed to decrypt encryption key: invalid checksum")
+
+	// The first participant to finalize cannot generate deposit data.
+	c1 := newTestCommand(1)
+	require.NoError(t, c1.process(ctx))
+	require.Equal(t, 1, c1.depositSignatures)
+	require.Empty(t, c1.depositDataFile)
+
+	// The keystore must contain the participant's key.
+	data, err := os.ReadFile(c1.keystoreFile)
+	require.NoError(t, err)
+	account, err := util.ParseAccount(ctx, string(data), []string{"secret 1"}, true)
+	require.NoError(t, err)
+	participantKey := c1.result.ParticipantPublicKeys[1]
+	require.Equal(t, participantKey[:], account.PublicKey().Marshal())
+
+	// The second participant to finalize reaches the threshold.
+	c2 := newTestCommand(2)
+	require.NoError(t, c2.process(ctx))
+	require.Equal(t, 2, c2.depositSignatures)
+	require.NotEmpty(t, c2.depositDataFile)
+
+	data, err = os.ReadFile(c2.depositDataFile)
+	require.NoError(t, err)
+	deposits, err := util.DepositInfoFromJSON(data)
+	require.NoError(t, err)
+	require.Len(t, deposits, 1)
+	require.Equal(t, c1.result.PublicKey[:], deposits[0].PublicKey)
+	require.Equal(t, withdrawalCredentials, deposits[0].WithdrawalCredentials)
+
+	data, err = os.ReadFile(c2.publicFile)
+	require.NoError(t, err)
+	info := &publicInfo{}
+	require.NoError(t, json.Unmarshal(data, info))
+	require.Len(t, info.Participants, 3)
+}
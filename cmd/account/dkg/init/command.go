@@ -0,0 +1,98 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkginit
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/dkg"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	ceremonyFile      string
+	participants      uint64
+	threshold         uint64
+	withdrawalAddress string
+	compounding       bool
+	amount            phase0.Gwei
+	forkVersion       phase0.Version
+
+	// Output.
+	ceremony *dkg.Ceremony
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:             viper.GetBool("quiet"),
+		verbose:           viper.GetBool("verbose"),
+		debug:             viper.GetBool("debug"),
+		ceremonyFile:      viper.GetString("ceremony"),
+		participants:      viper.GetUint64("participants"),
+		threshold:         viper.GetUint64("signing-threshold"),
+		withdrawalAddress: viper.GetString("withdrawaladdress"),
+		compounding:       viper.GetBool("compounding"),
+	}
+
+	if c.ceremonyFile == "" {
+		return nil, errors.New("ceremony file is required")
+	}
+	if c.participants == 0 {
+		return nil, errors.New("participants is required")
+	}
+	if c.threshold == 0 {
+		return nil, errors.New("signing threshold is required")
+	}
+	if c.withdrawalAddress == "" {
+		return nil, errors.New("withdrawal address is required")
+	}
+
+	depositValue := viper.GetString("depositvalue")
+	if depositValue == "" {
+		depositValue = "32 Ether"
+	}
+	amount, err := string2eth.StringToGWei(depositValue)
+	if err != nil {
+		return nil, errors.Wrap(err, "deposit value is invalid")
+	}
+	c.amount = phase0.Gwei(amount)
+	// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
+	if c.amount < 1000000000 { // MIN_DEPOSIT_AMOUNT
+		return nil, errors.New("deposit value must be at least 1 Ether")
+	}
+
+	// Default to mainnet.
+	if viper.GetString("forkversion") != "" {
+		forkVersion, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("forkversion"), "0x"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode fork version")
+		}
+		if len(forkVersion) != phase0.ForkVersionLength {
+			return nil, errors.New("fork version must be exactly 4 bytes in length")
+		}
+		copy(c.forkVersion[:], forkVersion)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,23 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkginit
+
+import (
+	"context"
+	"fmt"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	return fmt.Sprintf("Ceremony %s for %d participants with signing threshold %d written to %s", c.ceremony.ID, c.ceremony.Participants, c.ceremony.Threshold, c.ceremonyFile), nil
+}
@@ -0,0 +1,85 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkginit
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/dkg"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+func (c *command) process(_ context.Context) error {
+	withdrawalCredentials, err := addressWithdrawalCredentials(c.withdrawalAddress, c.compounding)
+	if err != nil {
+		return err
+	}
+
+	c.ceremony, err = dkg.NewCeremony(c.participants, c.threshold, withdrawalCredentials, c.amount, c.forkVersion)
+	if err != nil {
+		return err
+	}
+
+	return dkg.WriteFile(c.ceremonyFile, c.ceremony)
+}
+
+// addressWithdrawalCredentials creates withdrawal credentials for an Ethereum execution address.
+func addressWithdrawalCredentials(input string, compounding bool) ([]byte, error) {
+	address, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode withdrawal address")
+	}
+	if len(address) != 20 {
+		return nil, errors.New("withdrawal address must be exactly 20 bytes in length")
+	}
+	// Ensure the address is properly checksummed.
+	checksummedAddress := addressBytesToEIP55(address)
+	if checksummedAddress != input {
+		return nil, fmt.Errorf("withdrawal address checksum does not match (expected %s)", checksummedAddress)
+	}
+
+	withdrawalCredentials := make([]byte, 32)
+	copy(withdrawalCredentials[12:32], address)
+	// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
+	if compounding {
+		withdrawalCredentials[0] = byte(2) // COMPOUNDING_WITHDRAWAL_PREFIX
+	} else {
+		withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+	}
+
+	return withdrawalCredentials, nil
+}
+
+// addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
+func addressBytesToEIP55(address []byte) string {
+	bytes := []byte(hex.EncodeToString(address))
+	hash := ethutil.Keccak256(bytes)
+	for i := 0; i < len(bytes); i++ {
+		hashByte := hash[i/2]
+		if i%2 == 0 {
+			hashByte >>= 4
+		} else {
+			hashByte &= 0xf
+		}
+		if bytes[i] > '9' && hashByte > 7 {
+			bytes[i] -= 32
+		}
+	}
+
+	return fmt.Sprintf("0x%s", string(bytes))
+}
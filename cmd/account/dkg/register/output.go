@@ -0,0 +1,29 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkgregister
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Encryption key written to %s; send it to all participants\n", c.encryptionKeyFile))
+	builder.WriteString(fmt.Sprintf("Encrypted secret key written to %s; keep it for finalization and do not send it to anyone", c.encryptionSecretFile))
+
+	return builder.String(), nil
+}
@@ -0,0 +1,48 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountdkgregister
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/dkg"
+)
+
+func (c *command) process(_ context.Context) error {
+	c.ceremony = &dkg.Ceremony{}
+	if err := dkg.ReadFile(c.ceremonyFile, c.ceremony); err != nil {
+		return err
+	}
+
+	encryptionKey, encryptionSecret, err := dkg.GenerateEncryptionKey(c.ceremony, c.participant, c.passphrase)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.outputDir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create output directory")
+	}
+
+	c.encryptionKeyFile = filepath.Join(c.outputDir, dkg.EncryptionKeyFilename(c.participant))
+	if err := dkg.WriteFile(c.encryptionKeyFile, encryptionKey); err != nil {
+		return err
+	}
+
+	c.encryptionSecretFile = filepath.Join(c.outputDir, dkg.EncryptionSecretFilename(c.participant))
+
+	return dkg.WriteFile(c.encryptionSecretFile, encryptionSecret)
+}
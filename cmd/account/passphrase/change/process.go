@@ -0,0 +1,38 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountpassphrasechange
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	opCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	wallet, account, err := util.WalletAndAccountFromPath(opCtx, c.account)
+	cancel()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain account")
+	}
+
+	c.rekeyed, err = util.RekeyAccounts(ctx, wallet, []uuid.UUID{account.ID()}, c.passphrases, c.newPassphrase, c.encryptor, c.backupDir)
+	if err != nil {
+		return errors.Wrap(err, "failed to change passphrase")
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountscan
+
+import (
+	"context"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Input.
+	mnemonic      string
+	chainInfoFile string
+	maxDistance   uint64
+	jsonOutput    bool
+
+	// Processing.
+	consensusClient consensusclient.Service
+	chainTime       chaintime.Service
+	chainInfo       *beacon.ChainInfo
+
+	// Results.
+	results []*scanResult
+}
+
+// scanResult is a validator found by the scan.
+type scanResult struct {
+	Path                      string
+	Index                     phase0.ValidatorIndex
+	Pubkey                    phase0.BLSPubKey
+	State                     string
+	WithdrawalCredentials     []byte
+	WithdrawalCredentialsType string
+	// WithdrawalKeyPath and WithdrawalKeyMatches are only present for BLS withdrawal credentials.
+	WithdrawalKeyPath    string
+	WithdrawalKeyMatches *bool
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		mnemonic:                 viper.GetString("mnemonic"),
+		chainInfoFile:            viper.GetString("chain-info"),
+		maxDistance:              viper.GetUint64("max-distance"),
+		jsonOutput:               viper.GetBool("json"),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.mnemonic == "" {
+		return nil, errors.New("mnemonic is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,96 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type scanResultJSON struct {
+	Path                      string `json:"path"`
+	Index                     string `json:"index"`
+	Pubkey                    string `json:"pubkey"`
+	State                     string `json:"state"`
+	WithdrawalCredentials     string `json:"withdrawal_credentials"`
+	WithdrawalCredentialsType string `json:"withdrawal_credentials_type"`
+	WithdrawalKeyPath         string `json:"withdrawal_key_path,omitempty"`
+	WithdrawalKeyMatches      *bool  `json:"withdrawal_key_matches,omitempty"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputTxt(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	results := make([]*scanResultJSON, 0, len(c.results))
+	for _, result := range c.results {
+		results = append(results, &scanResultJSON{
+			Path:                      result.Path,
+			Index:                     fmt.Sprintf("%d", result.Index),
+			Pubkey:                    fmt.Sprintf("%#x", result.Pubkey),
+			State:                     result.State,
+			WithdrawalCredentials:     fmt.Sprintf("%#x", result.WithdrawalCredentials),
+			WithdrawalCredentialsType: result.WithdrawalCredentialsType,
+			WithdrawalKeyPath:         result.WithdrawalKeyPath,
+			WithdrawalKeyMatches:      result.WithdrawalKeyMatches,
+		})
+	}
+
+	data, err := json.Marshal(results)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal JSON")
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	if len(c.results) == 0 {
+		return "No validators found", nil
+	}
+
+	builder := strings.Builder{}
+	for i, result := range c.results {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("%s\n", result.Path))
+		builder.WriteString(fmt.Sprintf("  Index: %d\n", result.Index))
+		builder.WriteString(fmt.Sprintf("  Public key: %#x\n", result.Pubkey))
+		builder.WriteString(fmt.Sprintf("  State: %s\n", result.State))
+		builder.WriteString(fmt.Sprintf("  Withdrawal credentials: %#x (%s)\n", result.WithdrawalCredentials, result.WithdrawalCredentialsType))
+		if result.WithdrawalKeyMatches != nil {
+			if *result.WithdrawalKeyMatches {
+				builder.WriteString(fmt.Sprintf("  Withdrawal key %s matches withdrawal credentials\n", result.WithdrawalKeyPath))
+			} else {
+				builder.WriteString(fmt.Sprintf("  Withdrawal key %s does not match withdrawal credentials\n", result.WithdrawalKeyPath))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,184 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountscan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+)
+
+// minTimeout is the minimum timeout for this command.
+// It needs to be set here as we want timeouts to be low in general, but this can be pulling
+// a lot of data for an unsophisticated audience so it's easier to set a higher timeout..
+var minTimeout = 5 * time.Minute
+
+// defaultMaxDistance is the number of indices to scan past the last validator found.
+const defaultMaxDistance = 1024
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.obtainChainInfo(ctx); err != nil {
+		return err
+	}
+
+	return c.scan(ctx)
+}
+
+func (c *command) obtainChainInfo(ctx context.Context) error {
+	if c.chainInfoFile != "" {
+		data, err := os.ReadFile(c.chainInfoFile)
+		if err != nil {
+			return errors.Wrap(err, "failed to read chain info file")
+		}
+		c.chainInfo = &beacon.ChainInfo{}
+		if err := json.Unmarshal(data, c.chainInfo); err != nil {
+			return errors.Wrap(err, "chain info file invalid")
+		}
+
+		return nil
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Populating chain info from beacon node\n")
+	}
+	var err error
+	c.chainInfo, err = beacon.ObtainChainInfoFromNode(ctx, c.consensusClient, c.chainTime)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// scan scans the validator keys of the mnemonic, stopping when no validators have been
+// found in the last maxDistance indices.
+func (c *command) scan(_ context.Context) error {
+	seed, err := util.SeedFromMnemonic(c.mnemonic)
+	if err != nil {
+		return err
+	}
+
+	// Turn the validators in to a map for easy lookup.
+	validators := make(map[string]*beacon.ValidatorInfo, len(c.chainInfo.Validators))
+	for _, validator := range c.chainInfo.Validators {
+		validators[fmt.Sprintf("%#x", validator.Pubkey)] = validator
+	}
+
+	maxDistance := defaultMaxDistance
+	if c.maxDistance > 0 {
+		maxDistance = int(c.maxDistance)
+	}
+
+	c.results = make([]*scanResult, 0)
+	lastFoundIndex := -1
+	for i := 0; i-lastFoundIndex <= maxDistance; i++ {
+		validatorKeyPath := fmt.Sprintf("m/12381/3600/%d/0/0", i)
+		validatorPrivkey, err := ethutil.PrivateKeyFromSeedAndPath(seed, validatorKeyPath)
+		if err != nil {
+			return errors.Wrap(err, "failed to generate validator private key")
+		}
+		validator, exists := validators[fmt.Sprintf("%#x", validatorPrivkey.PublicKey().Marshal())]
+		if !exists {
+			continue
+		}
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Found validator %d at %s\n", validator.Index, validatorKeyPath)
+		}
+		lastFoundIndex = i
+
+		result := &scanResult{
+			Path:                      validatorKeyPath,
+			Index:                     validator.Index,
+			Pubkey:                    validator.Pubkey,
+			State:                     validator.State.String(),
+			WithdrawalCredentials:     validator.WithdrawalCredentials,
+			WithdrawalCredentialsType: withdrawalCredentialsType(validator.WithdrawalCredentials),
+		}
+		if validator.WithdrawalCredentials[0] == 0x00 {
+			withdrawalKeyPath := strings.TrimSuffix(validatorKeyPath, "/0")
+			withdrawalPrivkey, err := ethutil.PrivateKeyFromSeedAndPath(seed, withdrawalKeyPath)
+			if err != nil {
+				return errors.Wrap(err, "failed to generate withdrawal private key")
+			}
+			withdrawalCredentials := ethutil.SHA256(withdrawalPrivkey.PublicKey().Marshal())
+			matches := bytes.Equal(withdrawalCredentials[1:], validator.WithdrawalCredentials[1:])
+			result.WithdrawalKeyPath = withdrawalKeyPath
+			result.WithdrawalKeyMatches = &matches
+		}
+		c.results = append(c.results, result)
+	}
+
+	return nil
+}
+
+func withdrawalCredentialsType(withdrawalCredentials []byte) string {
+	switch withdrawalCredentials[0] {
+	case 0x00:
+		return "bls"
+	case 0x01:
+		return "execution"
+	case 0x02:
+		return "compounding"
+	default:
+		return "unknown"
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	// Ensure timeout is at least the minimum.
+	if c.timeout < minTimeout {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Increasing timeout to %v\n", minTimeout)
+		}
+		c.timeout = minTimeout
+	}
+
+	// Connect to the consensus node.
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
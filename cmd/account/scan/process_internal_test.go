@@ -0,0 +1,118 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package accountscan
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestScan(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+
+	// Validators at paths m/12381/3600/0/0/0, m/12381/3600/1/0/0 and m/12381/3600/3/0/0.
+	validator0 := &beacon.ValidatorInfo{
+		Index:                 100,
+		Pubkey:                phase0.BLSPubKey(testutil.HexToBytes("0xb384f767d964e100c8a9b21018d08c25ffebae268b3ab6d610353897541971726dbfc3c7463884c68a531515aab94c87")),
+		State:                 apiv1.ValidatorStateActiveOngoing,
+		WithdrawalCredentials: testutil.HexToBytes("0x008ba1cc4b091b91c1202bba3f508075d6ff565c77e559f0803c0792e0302bf1"),
+	}
+	validator1 := &beacon.ValidatorInfo{
+		Index:                 101,
+		Pubkey:                phase0.BLSPubKey(testutil.HexToBytes("0xb3d89e2f29c712c6a9f8e5a269b97617c4a94dd6f6662ab3b07ce9e5434573f15b5c988cd14bbd5804f77156a8af1cfa")),
+		State:                 apiv1.ValidatorStateExitedUnslashed,
+		WithdrawalCredentials: testutil.HexToBytes("0x0100000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15"),
+	}
+	validator3 := &beacon.ValidatorInfo{
+		Index:  102,
+		Pubkey: phase0.BLSPubKey(testutil.HexToBytes("0x86d330af51fa593fa9f93edb9d16640186be2e93ea94d259781e1eb34deb844c3968d75ea91d19f159dbd0523c6c5ba5")),
+		State:  apiv1.ValidatorStatePendingQueued,
+		// Credentials that do not match the withdrawal key.
+		WithdrawalCredentials: testutil.HexToBytes("0x0000000000000000000000000000000000000000000000000000000000000000"),
+	}
+
+	tests := []struct {
+		name        string
+		validators  []*beacon.ValidatorInfo
+		maxDistance uint64
+		paths       []string
+		matches     []*bool
+	}{
+		{
+			name:        "None",
+			validators:  []*beacon.ValidatorInfo{},
+			maxDistance: 4,
+			paths:       []string{},
+		},
+		{
+			name:        "Single",
+			validators:  []*beacon.ValidatorInfo{validator0},
+			maxDistance: 4,
+			paths:       []string{"m/12381/3600/0/0/0"},
+			matches:     []*bool{boolPtr(true)},
+		},
+		{
+			name:        "Multiple",
+			validators:  []*beacon.ValidatorInfo{validator0, validator1},
+			maxDistance: 4,
+			paths:       []string{"m/12381/3600/0/0/0", "m/12381/3600/1/0/0"},
+			matches:     []*bool{boolPtr(true), nil},
+		},
+		{
+			name:        "Gap",
+			validators:  []*beacon.ValidatorInfo{validator0, validator3},
+			maxDistance: 4,
+			paths:       []string{"m/12381/3600/0/0/0", "m/12381/3600/3/0/0"},
+			matches:     []*bool{boolPtr(true), boolPtr(false)},
+		},
+		{
+			name:        "GapTooLarge",
+			validators:  []*beacon.ValidatorInfo{validator0, validator3},
+			maxDistance: 2,
+			paths:       []string{"m/12381/3600/0/0/0"},
+			matches:     []*bool{boolPtr(true)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				mnemonic:    mnemonic,
+				maxDistance: test.maxDistance,
+				chainInfo: &beacon.ChainInfo{
+					Validators: test.validators,
+				},
+			}
+			require.NoError(t, c.scan(context.Background()))
+			require.Len(t, c.results, len(test.paths))
+			for i := range c.results {
+				require.Equal(t, test.paths[i], c.results[i].Path)
+				require.Equal(t, test.matches[i], c.results[i].WithdrawalKeyMatches)
+			}
+		})
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
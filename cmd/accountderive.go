@@ -28,6 +28,10 @@ var accountDeriveCmd = &cobra.Command{
 
     ethdo account derive --mnemonic="..." --path="m/12381/3600/0/0"
 
+A range of validator keys can be derived with --count, in which case keystores and deposit data for the keys are written to --output-dir.  For example:
+
+    ethdo account derive --mnemonic="..." --from-index=0 --count=10 --passphrase="secret" --withdrawaladdress=0x...
+
 In quiet mode this will return 0 if the inputs can derive an account account, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := accountderive.Run(cmd)
@@ -51,6 +55,14 @@ func init() {
 	accountDeriveCmd.Flags().Bool("show-withdrawal-credentials", false, "show withdrawal credentials for derived account")
 	accountDeriveCmd.Flags().Bool("generate-keystore", false, "generate a keystore for the derived account")
 	accountDeriveCmd.Flags().Bool("json", false, "display the JSON keystore for the derived account on stdout")
+	accountDeriveCmd.Flags().Uint64("from-index", 0, "the first validator index to derive when deriving a range of keys")
+	accountDeriveCmd.Flags().Uint64("count", 0, "the number of validator keys to derive")
+	accountDeriveCmd.Flags().String("output-dir", ".", "the directory to which to write keystores and deposit data when deriving a range of keys")
+	accountDeriveCmd.Flags().String("kdf", "scrypt", "the key derivation function for generated keystores (scrypt or pbkdf2)")
+	accountDeriveCmd.Flags().String("withdrawaladdress", "", "Ethereum 1 address to which validator funds will be withdrawn (default is to use the BLS withdrawal key)")
+	accountDeriveCmd.Flags().Bool("compounding", false, "Generate compounding (0x02) withdrawal credentials for the withdrawal address")
+	accountDeriveCmd.Flags().String("depositvalue", "32 Ether", "Value of the amount to be deposited")
+	accountDeriveCmd.Flags().String("forkversion", "", "Use a hard-coded fork version for deposit data (default is to use mainnet value)")
 }
 
 func accountDeriveBindings(cmd *cobra.Command) {
@@ -66,4 +78,28 @@ func accountDeriveBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("json", cmd.Flags().Lookup("json")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("from-index", cmd.Flags().Lookup("from-index")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("count", cmd.Flags().Lookup("count")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("output-dir", cmd.Flags().Lookup("output-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kdf", cmd.Flags().Lookup("kdf")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawaladdress", cmd.Flags().Lookup("withdrawaladdress")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("compounding", cmd.Flags().Lookup("compounding")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("depositvalue", cmd.Flags().Lookup("depositvalue")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("forkversion", cmd.Flags().Lookup("forkversion")); err != nil {
+		panic(err)
+	}
 }
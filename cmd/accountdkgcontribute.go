@@ -0,0 +1,69 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountdkgcontribute "github.com/wealdtech/ethdo/cmd/account/dkg/contribute"
+)
+
+var accountDKGContributeCmd = &cobra.Command{
+	Use:   "contribute",
+	Short: "Contribute to a distributed key generation ceremony",
+	Long: `Contribute to a distributed key generation ceremony.  For example:
+
+    ethdo account dkg contribute --ceremony=dkg-ceremony.json --participant=1
+
+This generates a public contribution, which must be sent to all participants, and a secret share for each participant, which must be sent privately to that participant only.  The input directory must contain the encryption keys of all participants, generated with "account dkg register"; each share is encrypted to the encryption key of its recipient.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := accountdkgcontribute.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountDKGCmd.AddCommand(accountDKGContributeCmd)
+	accountDKGFlags(accountDKGContributeCmd)
+	accountDKGContributeCmd.Flags().String("ceremony", "dkg-ceremony.json", "the file from which to read the ceremony definition")
+	accountDKGContributeCmd.Flags().Uint64("participant", 0, "the participant number (from 1 to the number of participants)")
+	accountDKGContributeCmd.Flags().String("input-dir", ".", "the directory from which to read the encryption keys of the participants")
+	accountDKGContributeCmd.Flags().String("output-dir", ".", "the directory to which to write the contribution and shares")
+}
+
+func accountDKGContributeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("ceremony", cmd.Flags().Lookup("ceremony")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("participant", cmd.Flags().Lookup("participant")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("input-dir", cmd.Flags().Lookup("input-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("output-dir", cmd.Flags().Lookup("output-dir")); err != nil {
+		panic(err)
+	}
+}
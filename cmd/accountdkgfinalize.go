@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountdkgfinalize "github.com/wealdtech/ethdo/cmd/account/dkg/finalize"
+)
+
+var accountDKGFinalizeCmd = &cobra.Command{
+	Use:   "finalize",
+	Short: "Finalize a distributed key generation ceremony",
+	Long: `Finalize a distributed key generation ceremony for a participant.  For example:
+
+    ethdo account dkg finalize --ceremony=dkg-ceremony.json --participant=1 --passphrase=secret
+
+The input directory must contain the contributions of all participants, the shares sent to this participant, and the encrypted secret key written by "account dkg register" for this participant.  The passphrase decrypts that key, with which the shares are decrypted, and also encrypts the participant's keystore.  All shares are verified against the contributions before the participant's keystore is written.
+
+Each participant also signs the deposit for the composite key.  When deposit signatures from at least the signing threshold of participants are present in the input directory, the deposit data is written as well.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := accountdkgfinalize.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountDKGCmd.AddCommand(accountDKGFinalizeCmd)
+	accountDKGFlags(accountDKGFinalizeCmd)
+	accountDKGFinalizeCmd.Flags().String("ceremony", "dkg-ceremony.json", "the file from which to read the ceremony definition")
+	accountDKGFinalizeCmd.Flags().Uint64("participant", 0, "the participant number (from 1 to the number of participants)")
+	accountDKGFinalizeCmd.Flags().String("input-dir", ".", "the directory from which to read the encryption key, contributions, shares and deposit signatures")
+	accountDKGFinalizeCmd.Flags().String("output-dir", ".", "the directory to which to write the keystore, public information and deposit data")
+}
+
+func accountDKGFinalizeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("ceremony", cmd.Flags().Lookup("ceremony")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("participant", cmd.Flags().Lookup("participant")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("input-dir", cmd.Flags().Lookup("input-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("output-dir", cmd.Flags().Lookup("output-dir")); err != nil {
+		panic(err)
+	}
+}
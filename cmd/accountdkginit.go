@@ -0,0 +1,81 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountdkginit "github.com/wealdtech/ethdo/cmd/account/dkg/init"
+)
+
+var accountDKGInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Define a distributed key generation ceremony",
+	Long: `Define a distributed key generation ceremony.  For example:
+
+    ethdo account dkg init --participants=5 --signing-threshold=3 --withdrawaladdress=0x...
+
+The ceremony file contains the parameters of the ceremony, and must be given to all participants before they register.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := accountdkginit.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountDKGCmd.AddCommand(accountDKGInitCmd)
+	accountDKGFlags(accountDKGInitCmd)
+	accountDKGInitCmd.Flags().String("ceremony", "dkg-ceremony.json", "the file to which to write the ceremony definition")
+	accountDKGInitCmd.Flags().Uint32("participants", 0, "Number of participants in the ceremony")
+	accountDKGInitCmd.Flags().Uint32("signing-threshold", 0, "Number of participants required to sign with the composite key")
+	accountDKGInitCmd.Flags().String("withdrawaladdress", "", "Ethereum 1 address to which validator funds will be withdrawn")
+	accountDKGInitCmd.Flags().Bool("compounding", false, "Generate compounding (0x02) withdrawal credentials for the withdrawal address")
+	accountDKGInitCmd.Flags().String("depositvalue", "32 Ether", "Value of the amount to be deposited")
+	accountDKGInitCmd.Flags().String("forkversion", "", "Use a hard-coded fork version for deposit data (default is to use mainnet value)")
+}
+
+func accountDKGInitBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("ceremony", cmd.Flags().Lookup("ceremony")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("participants", cmd.Flags().Lookup("participants")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("signing-threshold", cmd.Flags().Lookup("signing-threshold")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("withdrawaladdress", cmd.Flags().Lookup("withdrawaladdress")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("compounding", cmd.Flags().Lookup("compounding")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("depositvalue", cmd.Flags().Lookup("depositvalue")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("forkversion", cmd.Flags().Lookup("forkversion")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountdkgregister "github.com/wealdtech/ethdo/cmd/account/dkg/register"
+)
+
+var accountDKGRegisterCmd = &cobra.Command{
+	Use:   "register",
+	Short: "Register for a distributed key generation ceremony",
+	Long: `Register for a distributed key generation ceremony by generating an encryption key.  For example:
+
+    ethdo account dkg register --ceremony=dkg-ceremony.json --participant=1 --passphrase=secret
+
+This generates an encryption key, to which the secret shares sent to this participant are encrypted.  The public encryption key must be sent to all participants before they contribute.  The private encryption key is encrypted with the passphrase, and must be kept by this participant for finalization.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := accountdkgregister.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountDKGCmd.AddCommand(accountDKGRegisterCmd)
+	accountDKGFlags(accountDKGRegisterCmd)
+	accountDKGRegisterCmd.Flags().String("ceremony", "dkg-ceremony.json", "the file from which to read the ceremony definition")
+	accountDKGRegisterCmd.Flags().Uint64("participant", 0, "the participant number (from 1 to the number of participants)")
+	accountDKGRegisterCmd.Flags().String("output-dir", ".", "the directory to which to write the encryption key")
+}
+
+func accountDKGRegisterBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("ceremony", cmd.Flags().Lookup("ceremony")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("participant", cmd.Flags().Lookup("participant")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("output-dir", cmd.Flags().Lookup("output-dir")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountpassphrasechange "github.com/wealdtech/ethdo/cmd/account/passphrase/change"
+)
+
+var accountPassphraseChangeCmd = &cobra.Command{
+	Use:   "change",
+	Short: "Change the passphrase of an account",
+	Long: `Change the passphrase of an account, re-encrypting its key.  For example:
+
+    ethdo account passphrase change --account="primary/operations" --passphrase="my secret" --new-passphrase="my new secret"
+
+A keystore of the account as it was prior to the change is written to a new directory inside the directory supplied with --backup-dir, or the current directory if not supplied.
+
+In quiet mode this will return 0 if the passphrase is changed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := accountpassphrasechange.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountPassphraseCmd.AddCommand(accountPassphraseChangeCmd)
+	accountPassphraseFlags(accountPassphraseChangeCmd)
+	accountPassphraseChangeCmd.Flags().String("new-passphrase", "", "The new passphrase for the account")
+	accountPassphraseChangeCmd.Flags().String("kdf", "pbkdf2", "The key derivation function with which to encrypt the account (pbkdf2 or scrypt)")
+	accountPassphraseChangeCmd.Flags().String("backup-dir", "", "The directory in which to back up the account prior to the change")
+}
+
+func accountPassphraseChangeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("new-passphrase", cmd.Flags().Lookup("new-passphrase")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kdf", cmd.Flags().Lookup("kdf")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("backup-dir", cmd.Flags().Lookup("backup-dir")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	accountscan "github.com/wealdtech/ethdo/cmd/account/scan"
+)
+
+var accountScanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Scan a mnemonic for validators",
+	Long: `Scan the validator keys of a mnemonic to find the validators that it controls.  For example:
+
+    ethdo account scan --mnemonic="..."
+
+Validator keys are scanned at the EIP-2334 paths m/12381/3600/i/0/0, stopping when no validators have been found for --max-distance indices.  Chain information is obtained from the beacon node, or from the file supplied with --chain-info.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := accountscan.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	accountCmd.AddCommand(accountScanCmd)
+	accountFlags(accountScanCmd)
+	accountScanCmd.Flags().String("chain-info", "", "the file from which to read chain information, as created by \"offline prepare\" (if not supplied, obtained from the beacon node)")
+	accountScanCmd.Flags().Uint64("max-distance", 1024, "Maximum indices to scan past the last validator found.")
+}
+
+func accountScanBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("chain-info", cmd.Flags().Lookup("chain-info")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("max-distance", cmd.Flags().Lookup("max-distance")); err != nil {
+		panic(err)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	diskchaincache "github.com/wealdtech/ethdo/services/chaincache/disk"
 	"github.com/wealdtech/ethdo/services/chaintime"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
@@ -33,10 +34,20 @@ type dataIn struct {
 	verbose bool
 	debug   bool
 	// Operation.
-	eth2Client eth2client.Service
-	chainTime  chaintime.Service
-	epoch      spec.Epoch
-	validator  string
+	eth2Client           eth2client.Service
+	blocksProvider       eth2client.SignedBeaconBlockProvider
+	blockHeadersProvider eth2client.BeaconBlockHeadersProvider
+	committeesCache      *util.BeaconCommitteesCache
+	chainTime            chaintime.Service
+	epoch                spec.Epoch
+	validator            string
+	// Report.
+	report     bool
+	validators []string
+	fromEpoch  spec.Epoch
+	toEpoch    spec.Epoch
+	jsonOutput bool
+	csvOutput  bool
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -51,9 +62,30 @@ func input(ctx context.Context) (*dataIn, error) {
 	data.debug = viper.GetBool("debug")
 
 	data.validator = viper.GetString("validator")
-	if data.validator == "" {
+	data.validators = viper.GetStringSlice("validators")
+	if data.validator == "" && len(data.validators) == 0 {
 		return nil, errors.New("validator is required")
 	}
+	if data.validator != "" && len(data.validators) > 0 {
+		return nil, errors.New("cannot specify both validator and validators")
+	}
+
+	fromEpoch := viper.GetString("from-epoch")
+	toEpoch := viper.GetString("to-epoch")
+	if (fromEpoch == "") != (toEpoch == "") {
+		return nil, errors.New("both from-epoch and to-epoch are required for a range")
+	}
+	data.report = len(data.validators) > 0 || fromEpoch != ""
+	data.jsonOutput = viper.GetBool("json")
+	data.csvOutput = viper.GetBool("csv")
+	if data.csvOutput {
+		if !data.report {
+			return nil, errors.New("CSV output is only available with validators or from-epoch and to-epoch")
+		}
+		if data.jsonOutput {
+			return nil, errors.New("cannot specify both JSON and CSV output")
+		}
+	}
 
 	// Ethereum 2 client.
 	var err error
@@ -67,6 +99,35 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, err
 	}
 
+	var isProvider bool
+	data.blocksProvider, isProvider = data.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide signed beacon blocks")
+	}
+	data.blockHeadersProvider, isProvider = data.eth2Client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide beacon block headers")
+	}
+	beaconCommitteesProvider, isProvider := data.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide beacon committees")
+	}
+
+	if cacheDir := viper.GetString("cache-dir"); cacheDir != "" {
+		// Use the on-disk cache for historical chain data.
+		cache, err := diskchaincache.New(ctx,
+			diskchaincache.WithBaseDir(cacheDir),
+			diskchaincache.WithClient(data.eth2Client),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set up chain data cache")
+		}
+		data.blocksProvider = cache
+		data.blockHeadersProvider = cache
+		beaconCommitteesProvider = cache
+	}
+	data.committeesCache = util.NewBeaconCommitteesCache(beaconCommitteesProvider)
+
 	data.chainTime, err = standardchaintime.New(ctx,
 		standardchaintime.WithSpecProvider(data.eth2Client.(eth2client.SpecProvider)),
 		standardchaintime.WithGenesisProvider(data.eth2Client.(eth2client.GenesisProvider)),
@@ -81,5 +142,22 @@ func input(ctx context.Context) (*dataIn, error) {
 		return nil, err
 	}
 
+	// Epoch range.
+	data.fromEpoch = data.epoch
+	data.toEpoch = data.epoch
+	if fromEpoch != "" {
+		data.fromEpoch, err = util.ParseEpoch(ctx, data.chainTime, fromEpoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse from epoch")
+		}
+		data.toEpoch, err = util.ParseEpoch(ctx, data.chainTime, toEpoch)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse to epoch")
+		}
+		if data.fromEpoch > data.toEpoch {
+			return nil, errors.New("from epoch cannot be after to epoch")
+		}
+	}
+
 	return data, nil
 }
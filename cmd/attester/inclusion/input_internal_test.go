@@ -68,6 +68,43 @@ func TestInput(t *testing.T) {
 			},
 			err: "validator is required",
 		},
+		{
+			name: "ValidatorAndValidators",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validator":  "1",
+				"validators": []string{"2"},
+			},
+			err: "cannot specify both validator and validators",
+		},
+		{
+			name: "FromEpochOnly",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"from-epoch": "1",
+			},
+			err: "both from-epoch and to-epoch are required for a range",
+		},
+		{
+			name: "CSVWithoutReport",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"csv":       true,
+			},
+			err: "CSV output is only available with validators or from-epoch and to-epoch",
+		},
+		{
+			name: "JSONAndCSV",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"validators": []string{"1"},
+				"json":       true,
+				"csv":        true,
+			},
+			err: "cannot specify both JSON and CSV output",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
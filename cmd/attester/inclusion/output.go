@@ -15,10 +15,13 @@ package attesterinclusion
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 )
@@ -27,7 +30,7 @@ type dataOut struct {
 	debug            bool
 	quiet            bool
 	verbose          bool
-	attestation      *phase0.Attestation
+	attestation      *spec.VersionedAttestation
 	slot             phase0.Slot
 	attestationIndex uint64
 	inclusionDelay   phase0.Slot
@@ -37,6 +40,10 @@ type dataOut struct {
 	sourceTimely     bool
 	targetCorrect    bool
 	targetTimely     bool
+	// Report.
+	jsonOutput bool
+	csvOutput  bool
+	report     *inclusionReport
 }
 
 func output(_ context.Context, data *dataOut) (string, error) {
@@ -45,6 +52,20 @@ func output(_ context.Context, data *dataOut) (string, error) {
 		return buf.String(), errors.New("no data")
 	}
 
+	if data.report != nil {
+		if data.quiet {
+			return "", nil
+		}
+		switch {
+		case data.jsonOutput:
+			return outputReportJSON(data.report)
+		case data.csvOutput:
+			return outputReportCSV(data.report)
+		default:
+			return outputReportTxt(data.report), nil
+		}
+	}
+
 	if !data.quiet {
 		if data.found {
 			buf.WriteString("Attestation included in block ")
@@ -91,3 +112,101 @@ func output(_ context.Context, data *dataOut) (string, error) {
 	}
 	return buf.String(), nil
 }
+
+func outputReportJSON(report *inclusionReport) (string, error) {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func outputReportCSV(report *inclusionReport) (string, error) {
+	builder := strings.Builder{}
+	writer := csv.NewWriter(&builder)
+
+	if err := writer.Write([]string{
+		"validator_index",
+		"epoch",
+		"slot",
+		"committee_index",
+		"included",
+		"pending",
+		"inclusion_slot",
+		"inclusion_delay",
+		"head_correct",
+		"head_timely",
+		"source_timely",
+		"target_correct",
+		"target_timely",
+	}); err != nil {
+		return "", err
+	}
+	for _, validator := range report.Validators {
+		for _, duty := range validator.Duties {
+			inclusionSlot := ""
+			inclusionDelay := ""
+			if duty.Included {
+				inclusionSlot = fmt.Sprintf("%d", duty.InclusionSlot)
+				inclusionDelay = fmt.Sprintf("%d", duty.InclusionDelay)
+			}
+			if err := writer.Write([]string{
+				fmt.Sprintf("%d", validator.Validator),
+				fmt.Sprintf("%d", duty.Epoch),
+				fmt.Sprintf("%d", duty.Slot),
+				fmt.Sprintf("%d", duty.CommitteeIndex),
+				strconv.FormatBool(duty.Included),
+				strconv.FormatBool(duty.Pending),
+				inclusionSlot,
+				inclusionDelay,
+				strconv.FormatBool(duty.HeadCorrect),
+				strconv.FormatBool(duty.HeadTimely),
+				strconv.FormatBool(duty.SourceTimely),
+				strconv.FormatBool(duty.TargetCorrect),
+				strconv.FormatBool(duty.TargetTimely),
+			}); err != nil {
+				return "", err
+			}
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func outputReportTxt(report *inclusionReport) string {
+	buf := strings.Builder{}
+
+	for _, validator := range report.Validators {
+		buf.WriteString(fmt.Sprintf("Validator %d:\n", validator.Validator))
+		for _, duty := range validator.Duties {
+			if duty.Pending {
+				buf.WriteString(fmt.Sprintf("  Epoch %d: attestation for slot %d pending inclusion\n", duty.Epoch, duty.Slot))
+				continue
+			}
+			if !duty.Included {
+				buf.WriteString(fmt.Sprintf("  Epoch %d: attestation for slot %d not found\n", duty.Epoch, duty.Slot))
+				continue
+			}
+			buf.WriteString(fmt.Sprintf("  Epoch %d: attestation for slot %d included in block %d, inclusion delay %d, head correct %s, target correct %s\n",
+				duty.Epoch, duty.Slot, duty.InclusionSlot, duty.InclusionDelay, tick(duty.HeadCorrect), tick(duty.TargetCorrect)))
+		}
+		buf.WriteString(fmt.Sprintf("  Duties missed: %d/%d", validator.DutiesMissed, validator.DutiesExpected))
+		if validator.DutiesPending > 0 {
+			buf.WriteString(fmt.Sprintf(" (%d pending)", validator.DutiesPending))
+		}
+		buf.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func tick(value bool) string {
+	if value {
+		return "✓"
+	}
+	return "✕"
+}
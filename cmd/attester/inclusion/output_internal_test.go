@@ -21,6 +21,45 @@ import (
 )
 
 func TestOutput(t *testing.T) {
+	testReport := &inclusionReport{
+		FromEpoch: 3,
+		ToEpoch:   4,
+		Validators: []*validatorInclusions{
+			{
+				Validator:      1,
+				DutiesExpected: 3,
+				DutiesMissed:   1,
+				DutiesPending:  1,
+				Duties: []*dutyInclusion{
+					{
+						Epoch:          3,
+						Slot:           100,
+						CommitteeIndex: 2,
+						Included:       true,
+						InclusionSlot:  101,
+						InclusionDelay: 1,
+						HeadCorrect:    true,
+						HeadTimely:     true,
+						SourceTimely:   true,
+						TargetCorrect:  true,
+						TargetTimely:   true,
+					},
+					{
+						Epoch:          4,
+						Slot:           130,
+						CommitteeIndex: 5,
+					},
+					{
+						Epoch:          4,
+						Slot:           150,
+						CommitteeIndex: 1,
+						Pending:        true,
+					},
+				},
+			},
+		},
+	}
+
 	tests := []struct {
 		name    string
 		dataOut *dataOut
@@ -68,6 +107,21 @@ Source timely: ✕
 Target correct: ✓
 Target timely: ✓`,
 		},
+		{
+			name: "Report",
+			dataOut: &dataOut{
+				report: testReport,
+			},
+			res: "Validator 1:\n  Epoch 3: attestation for slot 100 included in block 101, inclusion delay 1, head correct ✓, target correct ✓\n  Epoch 4: attestation for slot 130 not found\n  Epoch 4: attestation for slot 150 pending inclusion\n  Duties missed: 1/3 (1 pending)",
+		},
+		{
+			name: "ReportCSV",
+			dataOut: &dataOut{
+				csvOutput: true,
+				report:    testReport,
+			},
+			res: "validator_index,epoch,slot,committee_index,included,pending,inclusion_slot,inclusion_delay,head_correct,head_timely,source_timely,target_correct,target_timely\n1,3,100,2,true,false,101,1,true,true,true,true,true\n1,4,130,5,false,false,,,false,false,false,false,false\n1,4,150,1,false,true,,,false,false,false,false,false",
+		},
 	}
 
 	for _, test := range tests {
@@ -33,6 +33,10 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 		return nil, errors.New("no data")
 	}
 
+	if data.report {
+		return processReport(ctx, data)
+	}
+
 	validator, err := util.ParseValidator(ctx, data.eth2Client.(eth2client.ValidatorsProvider), data.validator, "head")
 	if err != nil {
 		return nil, err
@@ -63,7 +67,7 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	startSlot := duty.Slot + 1
 	endSlot := startSlot + 32
 	for slot := startSlot; slot < endSlot; slot++ {
-		blockResponse, err := data.eth2Client.(eth2client.SignedBeaconBlockProvider).SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		blockResponse, err := data.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
 			Block: fmt.Sprintf("%d", slot),
 		})
 		if err != nil {
@@ -93,17 +97,26 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 			return nil, errors.Wrap(err, "failed to obtain block attestations")
 		}
 		for i, attestation := range attestations {
-			if attestation.Data.Slot == duty.Slot &&
-				attestation.Data.Index == duty.CommitteeIndex &&
-				attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+			attestationData, err := attestation.Data()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain attestation data")
+			}
+			if attestationData.Slot != duty.Slot {
+				continue
+			}
+			committeeBits, err := util.AttestationCommitteeBits(ctx, data.committeesCache, attestation)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to obtain attestation committee bits")
+			}
+			if bits, exists := committeeBits[duty.CommitteeIndex]; exists && bits.BitAt(duty.ValidatorCommitteeIndex) {
 				headCorrect := false
 				targetCorrect := false
 				if data.verbose {
-					headCorrect, err = calcHeadCorrect(ctx, data, attestation)
+					headCorrect, err = calcHeadCorrect(ctx, data, attestationData)
 					if err != nil {
 						return nil, errors.Wrap(err, "failed to obtain head correct result")
 					}
-					targetCorrect, err = calcTargetCorrect(ctx, data, attestation)
+					targetCorrect, err = calcTargetCorrect(ctx, data, attestationData)
 					if err != nil {
 						return nil, errors.Wrap(err, "failed to obtain target correct result")
 					}
@@ -128,36 +141,29 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	return results, nil
 }
 
-func calcHeadCorrect(ctx context.Context, data *dataIn, attestation *phase0.Attestation) (bool, error) {
-	slot := attestation.Data.Slot
-	for {
-		response, err := data.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
-			Block: fmt.Sprintf("%d", slot),
-		})
-		if err != nil {
-			var apiErr *api.Error
-			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
-				// No block.
-				slot--
-				continue
-			}
-
-			return false, err
-		}
-		if !response.Data.Canonical {
-			// Not canonical.
-			slot--
-			continue
-		}
-		return bytes.Equal(response.Data.Root[:], attestation.Data.BeaconBlockRoot[:]), nil
+func calcHeadCorrect(ctx context.Context, data *dataIn, attestationData *phase0.AttestationData) (bool, error) {
+	root, err := canonicalRoot(ctx, data, attestationData.Slot)
+	if err != nil {
+		return false, err
 	}
+
+	return bytes.Equal(root[:], attestationData.BeaconBlockRoot[:]), nil
 }
 
-func calcTargetCorrect(ctx context.Context, data *dataIn, attestation *phase0.Attestation) (bool, error) {
+func calcTargetCorrect(ctx context.Context, data *dataIn, attestationData *phase0.AttestationData) (bool, error) {
 	// Start with first slot of the target epoch.
-	slot := data.chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch)
+	root, err := canonicalRoot(ctx, data, data.chainTime.FirstSlotOfEpoch(attestationData.Target.Epoch))
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(root[:], attestationData.Target.Root[:]), nil
+}
+
+// canonicalRoot returns the root of the canonical block at the given slot, or the closest canonical block before it if the slot is empty.
+func canonicalRoot(ctx context.Context, data *dataIn, slot phase0.Slot) (phase0.Root, error) {
 	for {
-		response, err := data.eth2Client.(eth2client.BeaconBlockHeadersProvider).BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+		response, err := data.blockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
 			Block: fmt.Sprintf("%d", slot),
 		})
 		if err != nil {
@@ -168,14 +174,14 @@ func calcTargetCorrect(ctx context.Context, data *dataIn, attestation *phase0.At
 				continue
 			}
 
-			return false, err
+			return phase0.Root{}, err
 		}
 		if !response.Data.Canonical {
 			// Not canonical.
 			slot--
 			continue
 		}
-		return bytes.Equal(response.Data.Root[:], attestation.Data.Target.Root[:]), nil
+		return response.Data.Root, nil
 	}
 }
 
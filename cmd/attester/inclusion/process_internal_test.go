@@ -53,9 +53,11 @@ func TestProcess(t *testing.T) {
 		{
 			name: "Client",
 			dataIn: &dataIn{
-				eth2Client: eth2Client,
-				chainTime:  chainTime,
-				validator:  "0x933ad9491b62059dd065b560d256d8957a8c402cc6e8d8ee7290ae11e8f7329267a8811c397529dac52ae1342ba58c95",
+				eth2Client:           eth2Client,
+				blocksProvider:       eth2Client.(eth2client.SignedBeaconBlockProvider),
+				blockHeadersProvider: eth2Client.(eth2client.BeaconBlockHeadersProvider),
+				chainTime:            chainTime,
+				validator:            "0x933ad9491b62059dd065b560d256d8957a8c402cc6e8d8ee7290ae11e8f7329267a8811c397529dac52ae1342ba58c95",
 			},
 		},
 	}
@@ -0,0 +1,308 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterinclusion
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// inclusionReport contains the inclusion of attestations for a number of validators over a range of epochs.
+type inclusionReport struct {
+	FromEpoch  phase0.Epoch           `json:"from_epoch"`
+	ToEpoch    phase0.Epoch           `json:"to_epoch"`
+	Validators []*validatorInclusions `json:"validators"`
+}
+
+// validatorInclusions contains the inclusion of attestations for a single validator.
+type validatorInclusions struct {
+	Validator      phase0.ValidatorIndex `json:"validator_index"`
+	DutiesExpected int                   `json:"duties_expected"`
+	DutiesMissed   int                   `json:"duties_missed"`
+	DutiesPending  int                   `json:"duties_pending"`
+	Duties         []*dutyInclusion      `json:"duties"`
+}
+
+// dutyInclusion contains the inclusion of the attestation for a single duty.
+type dutyInclusion struct {
+	Epoch          phase0.Epoch          `json:"epoch"`
+	Slot           phase0.Slot           `json:"slot"`
+	CommitteeIndex phase0.CommitteeIndex `json:"committee_index"`
+	Included       bool                  `json:"included"`
+	Pending        bool                  `json:"pending,omitempty"`
+	InclusionSlot  phase0.Slot           `json:"inclusion_slot,omitempty"`
+	InclusionDelay phase0.Slot           `json:"inclusion_delay,omitempty"`
+	HeadCorrect    bool                  `json:"head_correct"`
+	HeadTimely     bool                  `json:"head_timely"`
+	SourceTimely   bool                  `json:"source_timely"`
+	TargetCorrect  bool                  `json:"target_correct"`
+	TargetTimely   bool                  `json:"target_timely"`
+
+	validatorCommitteeIndex uint64
+}
+
+// committeeKey identifies a committee.
+type committeeKey struct {
+	slot  phase0.Slot
+	index phase0.CommitteeIndex
+}
+
+// processReport obtains the inclusion of attestations for all validators over the range of epochs.
+// Each block that could contain the attestations is fetched once, and shared between the validators.
+func processReport(ctx context.Context, data *dataIn) (*dataOut, error) {
+	validators, err := reportValidators(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	if len(validators) == 0 {
+		return nil, errors.New("no validators found")
+	}
+	indices := make([]phase0.ValidatorIndex, 0, len(validators))
+	for _, validator := range validators {
+		indices = append(indices, validator.Index)
+	}
+
+	report := &inclusionReport{
+		FromEpoch:  data.fromEpoch,
+		ToEpoch:    data.toEpoch,
+		Validators: make([]*validatorInclusions, 0, len(validators)),
+	}
+	inclusions := make(map[phase0.ValidatorIndex]*validatorInclusions)
+	pending := make(map[committeeKey][]*dutyInclusion)
+	for epoch := data.fromEpoch; epoch <= data.toEpoch; epoch++ {
+		dutiesResponse, err := data.eth2Client.(eth2client.AttesterDutiesProvider).AttesterDuties(ctx, &api.AttesterDutiesOpts{
+			Epoch:   epoch,
+			Indices: indices,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain attester duties for epoch %d", epoch))
+		}
+		for _, duty := range dutiesResponse.Data {
+			addDuty(report, inclusions, pending, epoch, duty)
+		}
+	}
+
+	// Attestations can be included up to an epoch after their slot.
+	slotsPerEpoch := phase0.Slot(data.chainTime.SlotsPerEpoch())
+	startSlot := data.chainTime.FirstSlotOfEpoch(data.fromEpoch) + 1
+	endSlot := data.chainTime.LastSlotOfEpoch(data.toEpoch) + slotsPerEpoch
+	currentSlot := data.chainTime.CurrentSlot()
+	if endSlot > currentSlot {
+		endSlot = currentSlot
+	}
+	roots := make(map[phase0.Slot]phase0.Root)
+	for slot := startSlot; slot <= endSlot; slot++ {
+		blockResponse, err := data.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// No block for this slot, that's fine.
+				continue
+			}
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block %d", slot))
+		}
+		block := blockResponse.Data
+		if block == nil {
+			continue
+		}
+		blockSlot, err := block.Slot()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain block slot")
+		}
+		if blockSlot != slot {
+			continue
+		}
+		if data.debug {
+			fmt.Printf("Fetched block for slot %d\n", slot)
+		}
+		attestations, err := block.Attestations()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain block attestations")
+		}
+		for _, attestation := range attestations {
+			if err := includeAttestation(ctx, data, pending, roots, slot, slotsPerEpoch, attestation); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	tallyDuties(report, currentSlot, slotsPerEpoch)
+	sort.Slice(report.Validators, func(i int, j int) bool {
+		return report.Validators[i].Validator < report.Validators[j].Validator
+	})
+
+	return &dataOut{
+		debug:      data.debug,
+		quiet:      data.quiet,
+		verbose:    data.verbose,
+		jsonOutput: data.jsonOutput,
+		csvOutput:  data.csvOutput,
+		report:     report,
+	}, nil
+}
+
+// reportValidators obtains the validators for the report.
+func reportValidators(ctx context.Context, data *dataIn) ([]*apiv1.Validator, error) {
+	validatorsProvider, isProvider := data.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("connection does not provide validator information")
+	}
+
+	if data.validator != "" {
+		validator, err := util.ParseValidator(ctx, validatorsProvider, data.validator, "head")
+		if err != nil {
+			return nil, err
+		}
+
+		return []*apiv1.Validator{validator}, nil
+	}
+
+	return util.ParseValidators(ctx, validatorsProvider, data.validators, "head")
+}
+
+// addDuty adds an attester duty to the report, and to the duties pending inclusion.
+func addDuty(report *inclusionReport,
+	inclusions map[phase0.ValidatorIndex]*validatorInclusions,
+	pending map[committeeKey][]*dutyInclusion,
+	epoch phase0.Epoch,
+	duty *apiv1.AttesterDuty,
+) {
+	validator, exists := inclusions[duty.ValidatorIndex]
+	if !exists {
+		validator = &validatorInclusions{
+			Validator: duty.ValidatorIndex,
+			Duties:    make([]*dutyInclusion, 0),
+		}
+		inclusions[duty.ValidatorIndex] = validator
+		report.Validators = append(report.Validators, validator)
+	}
+
+	inclusion := &dutyInclusion{
+		Epoch:                   epoch,
+		Slot:                    duty.Slot,
+		CommitteeIndex:          duty.CommitteeIndex,
+		validatorCommitteeIndex: duty.ValidatorCommitteeIndex,
+	}
+	validator.DutiesExpected++
+	validator.Duties = append(validator.Duties, inclusion)
+
+	key := committeeKey{slot: duty.Slot, index: duty.CommitteeIndex}
+	pending[key] = append(pending[key], inclusion)
+}
+
+// tallyDuties counts the missed duties for each validator.  Duties that have not been
+// included but whose inclusion window has yet to close are pending rather than missed.
+func tallyDuties(report *inclusionReport, currentSlot phase0.Slot, slotsPerEpoch phase0.Slot) {
+	for _, validator := range report.Validators {
+		for _, duty := range validator.Duties {
+			switch {
+			case duty.Included:
+			case duty.Slot+slotsPerEpoch > currentSlot:
+				duty.Pending = true
+				validator.DutiesPending++
+			default:
+				validator.DutiesMissed++
+			}
+		}
+	}
+}
+
+// includeAttestation marks the duties for which the attestation is the first inclusion.
+func includeAttestation(ctx context.Context,
+	data *dataIn,
+	pending map[committeeKey][]*dutyInclusion,
+	roots map[phase0.Slot]phase0.Root,
+	slot phase0.Slot,
+	slotsPerEpoch phase0.Slot,
+	attestation *spec.VersionedAttestation,
+) error {
+	attestationData, err := attestation.Data()
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attestation data")
+	}
+	if slot <= attestationData.Slot || slot > attestationData.Slot+slotsPerEpoch {
+		return nil
+	}
+	committeeBits, err := util.AttestationCommitteeBits(ctx, data.committeesCache, attestation)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain attestation committee bits")
+	}
+
+	// Correctness is the same for all duties in the attestation, so calculated once when required.
+	calculated := false
+	headCorrect := false
+	targetCorrect := false
+	for committeeIndex, aggregationBits := range committeeBits {
+		duties, exists := pending[committeeKey{slot: attestationData.Slot, index: committeeIndex}]
+		if !exists {
+			continue
+		}
+		for _, duty := range duties {
+			if duty.Included || !aggregationBits.BitAt(duty.validatorCommitteeIndex) {
+				continue
+			}
+			if !calculated {
+				headRoot, err := cachedCanonicalRoot(ctx, data, roots, attestationData.Slot)
+				if err != nil {
+					return errors.Wrap(err, "failed to obtain head correct result")
+				}
+				headCorrect = bytes.Equal(headRoot[:], attestationData.BeaconBlockRoot[:])
+				targetRoot, err := cachedCanonicalRoot(ctx, data, roots, data.chainTime.FirstSlotOfEpoch(attestationData.Target.Epoch))
+				if err != nil {
+					return errors.Wrap(err, "failed to obtain target correct result")
+				}
+				targetCorrect = bytes.Equal(targetRoot[:], attestationData.Target.Root[:])
+				calculated = true
+			}
+
+			duty.Included = true
+			duty.InclusionSlot = slot
+			duty.InclusionDelay = slot - duty.Slot
+			duty.SourceTimely = duty.InclusionDelay <= 5 // sqrt(32)
+			duty.TargetCorrect = targetCorrect
+			duty.TargetTimely = targetCorrect && duty.InclusionDelay <= 32
+			duty.HeadCorrect = headCorrect
+			duty.HeadTimely = headCorrect && duty.InclusionDelay == 1
+		}
+	}
+
+	return nil
+}
+
+// cachedCanonicalRoot returns the canonical root for the slot, using the cache where possible.
+func cachedCanonicalRoot(ctx context.Context, data *dataIn, roots map[phase0.Slot]phase0.Root, slot phase0.Slot) (phase0.Root, error) {
+	if root, exists := roots[slot]; exists {
+		return root, nil
+	}
+	root, err := canonicalRoot(ctx, data, slot)
+	if err != nil {
+		return phase0.Root{}, err
+	}
+	roots[slot] = root
+
+	return root, nil
+}
@@ -0,0 +1,211 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attesterinclusion
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestIncludeAttestation(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().AddDate(0, 0, -1))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+	data := &dataIn{
+		chainTime: chainTime,
+	}
+
+	report := &inclusionReport{}
+	inclusions := make(map[phase0.ValidatorIndex]*validatorInclusions)
+	pending := make(map[committeeKey][]*dutyInclusion)
+	for _, duty := range []*apiv1.AttesterDuty{
+		{ValidatorIndex: 2, Slot: 100, CommitteeIndex: 1, ValidatorCommitteeIndex: 0},
+		{ValidatorIndex: 1, Slot: 100, CommitteeIndex: 1, ValidatorCommitteeIndex: 1},
+		{ValidatorIndex: 3, Slot: 100, CommitteeIndex: 1, ValidatorCommitteeIndex: 2},
+	} {
+		addDuty(report, inclusions, pending, 3, duty)
+	}
+	require.Len(t, report.Validators, 3)
+
+	// Canonical roots are cached, so no beacon node is required.
+	headRoot := phase0.Root{0x01}
+	targetRoot := phase0.Root{0x02}
+	roots := map[phase0.Slot]phase0.Root{
+		100: headRoot,
+		96:  targetRoot,
+	}
+
+	newAttestation := func(bits ...uint64) *spec.VersionedAttestation {
+		aggregationBits := bitfield.NewBitlist(4)
+		for _, bit := range bits {
+			aggregationBits.SetBitAt(bit, true)
+		}
+		return &spec.VersionedAttestation{
+			Version: spec.DataVersionDeneb,
+			Deneb: &phase0.Attestation{
+				AggregationBits: aggregationBits,
+				Data: &phase0.AttestationData{
+					Slot:            100,
+					Index:           1,
+					BeaconBlockRoot: headRoot,
+					Source:          &phase0.Checkpoint{},
+					Target: &phase0.Checkpoint{
+						Epoch: 3,
+						Root:  targetRoot,
+					},
+				},
+			},
+		}
+	}
+
+	// First inclusion of validator committee index 0 at slot 101.
+	require.NoError(t, includeAttestation(ctx, data, pending, roots, 101, 32, newAttestation(0)))
+	// Later inclusion of validator committee index 0 does not change the result, index 1 first included at slot 103.
+	require.NoError(t, includeAttestation(ctx, data, pending, roots, 103, 32, newAttestation(0, 1)))
+	// Inclusion outside of the window is ignored.
+	require.NoError(t, includeAttestation(ctx, data, pending, roots, 133, 32, newAttestation(2)))
+
+	duty := inclusions[2].Duties[0]
+	require.True(t, duty.Included)
+	require.Equal(t, phase0.Slot(101), duty.InclusionSlot)
+	require.Equal(t, phase0.Slot(1), duty.InclusionDelay)
+	require.True(t, duty.HeadCorrect)
+	require.True(t, duty.HeadTimely)
+	require.True(t, duty.SourceTimely)
+	require.True(t, duty.TargetCorrect)
+	require.True(t, duty.TargetTimely)
+
+	duty = inclusions[1].Duties[0]
+	require.True(t, duty.Included)
+	require.Equal(t, phase0.Slot(103), duty.InclusionSlot)
+	require.Equal(t, phase0.Slot(3), duty.InclusionDelay)
+	require.True(t, duty.HeadCorrect)
+	require.False(t, duty.HeadTimely)
+
+	require.False(t, inclusions[3].Duties[0].Included)
+}
+
+func TestIncludeAttestationElectra(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().AddDate(0, 0, -1))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+	data := &dataIn{
+		chainTime: chainTime,
+		committeesCache: util.NewBeaconCommitteesCache(mock.NewBeaconCommitteesProvider([]*apiv1.BeaconCommittee{
+			{Slot: 100, Index: 0, Validators: []phase0.ValidatorIndex{10, 11, 12}},
+			{Slot: 100, Index: 1, Validators: []phase0.ValidatorIndex{2, 1, 3}},
+			{Slot: 100, Index: 2, Validators: []phase0.ValidatorIndex{20, 21}},
+		})),
+	}
+
+	report := &inclusionReport{}
+	inclusions := make(map[phase0.ValidatorIndex]*validatorInclusions)
+	pending := make(map[committeeKey][]*dutyInclusion)
+	for _, duty := range []*apiv1.AttesterDuty{
+		{ValidatorIndex: 10, Slot: 100, CommitteeIndex: 0, ValidatorCommitteeIndex: 0},
+		{ValidatorIndex: 2, Slot: 100, CommitteeIndex: 1, ValidatorCommitteeIndex: 0},
+		{ValidatorIndex: 1, Slot: 100, CommitteeIndex: 1, ValidatorCommitteeIndex: 1},
+		{ValidatorIndex: 21, Slot: 100, CommitteeIndex: 2, ValidatorCommitteeIndex: 1},
+	} {
+		addDuty(report, inclusions, pending, 3, duty)
+	}
+
+	headRoot := phase0.Root{0x01}
+	targetRoot := phase0.Root{0x02}
+	roots := map[phase0.Slot]phase0.Root{
+		100: headRoot,
+		96:  targetRoot,
+	}
+
+	// Aggregate covering committees 0 and 2; committee 0 occupies bits 0-2 and committee 2 bits 3-4.
+	aggregationBits := bitfield.NewBitlist(5)
+	aggregationBits.SetBitAt(0, true)
+	aggregationBits.SetBitAt(4, true)
+	committeeBits := bitfield.NewBitvector64()
+	committeeBits.SetBitAt(0, true)
+	committeeBits.SetBitAt(2, true)
+	attestation := &spec.VersionedAttestation{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.Attestation{
+			AggregationBits: aggregationBits,
+			CommitteeBits:   committeeBits,
+			Data: &phase0.AttestationData{
+				Slot:            100,
+				BeaconBlockRoot: headRoot,
+				Source:          &phase0.Checkpoint{},
+				Target: &phase0.Checkpoint{
+					Epoch: 3,
+					Root:  targetRoot,
+				},
+			},
+		},
+	}
+	require.NoError(t, includeAttestation(ctx, data, pending, roots, 101, 32, attestation))
+
+	require.True(t, inclusions[10].Duties[0].Included)
+	require.True(t, inclusions[10].Duties[0].HeadCorrect)
+	require.True(t, inclusions[21].Duties[0].Included)
+	// Committee 1 is not covered by the aggregate, even though its bits overlap the aggregation bits.
+	require.False(t, inclusions[2].Duties[0].Included)
+	require.False(t, inclusions[1].Duties[0].Included)
+}
+
+func TestTallyDuties(t *testing.T) {
+	report := &inclusionReport{}
+	inclusions := make(map[phase0.ValidatorIndex]*validatorInclusions)
+	pending := make(map[committeeKey][]*dutyInclusion)
+	for _, duty := range []*apiv1.AttesterDuty{
+		{ValidatorIndex: 1, Slot: 100},
+		{ValidatorIndex: 1, Slot: 110},
+		{ValidatorIndex: 1, Slot: 140},
+		{ValidatorIndex: 1, Slot: 150},
+	} {
+		addDuty(report, inclusions, pending, 3, duty)
+	}
+	inclusions[1].Duties[1].Included = true
+
+	// Inclusion window for slot 100 closed at slot 132, for slots 140 and 150 it is still open.
+	tallyDuties(report, 150, 32)
+
+	validator := inclusions[1]
+	require.Equal(t, 4, validator.DutiesExpected)
+	require.Equal(t, 1, validator.DutiesMissed)
+	require.Equal(t, 2, validator.DutiesPending)
+	require.False(t, validator.Duties[0].Pending)
+	require.False(t, validator.Duties[1].Pending)
+	require.True(t, validator.Duties[2].Pending)
+	require.True(t, validator.Duties[3].Pending)
+}
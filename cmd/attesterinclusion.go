@@ -28,6 +28,14 @@ var attesterInclusionCmd = &cobra.Command{
 
     ethdo attester inclusion --validator=Validators/00001 --epoch=12345
 
+Inclusion for a number of validators over a range of epochs can be obtained with --validators, --from-epoch and --to-epoch.  For example:
+
+    ethdo attester inclusion --validators=1,2,100-199,validators.txt --from-epoch=12300 --to-epoch=12345
+
+Validators can be supplied as indices, ranges of indices, public keys, or files containing any of these.  Each block is fetched
+once and shared between the validators, and the inclusion slot, inclusion delay, head and target correctness and missed duties
+are reported for each validator.  The report can be output as CSV with --csv, or as JSON with --json.
+
 In quiet mode this will return 0 if an attestation from the attester is found on the block of the given epoch, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := attesterinclusion.Run(cmd)
@@ -50,6 +58,10 @@ func init() {
 	attesterInclusionCmd.Flags().String("epoch", "-1", "the epoch for which to obtain the inclusion")
 	attesterInclusionCmd.Flags().String("validator", "", "the index, public key, or account of the validator")
 	attesterInclusionCmd.Flags().String("index", "", "the index of the attester")
+	attesterInclusionCmd.Flags().StringSlice("validators", nil, "the indices, public keys or files of the validators for a report")
+	attesterInclusionCmd.Flags().String("from-epoch", "", "the first epoch for which to obtain a report")
+	attesterInclusionCmd.Flags().String("to-epoch", "", "the last epoch for which to obtain a report")
+	attesterInclusionCmd.Flags().Bool("csv", false, "output the report as CSV")
 }
 
 func attesterInclusionBindings(cmd *cobra.Command) {
@@ -62,4 +74,16 @@ func attesterInclusionBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("index", cmd.Flags().Lookup("index")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/util"
 )
 
 type command struct {
@@ -35,16 +36,24 @@ type command struct {
 	connection               string
 	allowInsecureConnections bool
 
+	// Chain data cache.
+	cacheDir string
+
 	// Operation.
 	blockID    string
 	stream     bool
+	fromSlot   string
+	toSlot     string
 	jsonOutput bool
+	csvOutput  bool
 
 	// Data access.
-	eth2Client           eth2client.Service
-	chainTime            chaintime.Service
-	blocksProvider       eth2client.SignedBeaconBlockProvider
-	blockHeadersProvider eth2client.BeaconBlockHeadersProvider
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	blocksProvider         eth2client.SignedBeaconBlockProvider
+	blockHeadersProvider   eth2client.BeaconBlockHeadersProvider
+	proposerDutiesProvider eth2client.ProposerDutiesProvider
+	committeesCache        *util.BeaconCommitteesCache
 
 	// Constants.
 	timelySourceWeight uint64
@@ -67,6 +76,7 @@ type command struct {
 
 	// Results.
 	analysis *blockAnalysis
+	report   *packingReport
 }
 
 type blockAnalysis struct {
@@ -124,10 +134,32 @@ func newCommand(_ context.Context) (*command, error) {
 
 	c.connection = viper.GetString("connection")
 	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+	c.cacheDir = viper.GetString("cache-dir")
 
 	c.blockID = viper.GetString("blockid")
 	c.stream = viper.GetBool("stream")
+	c.fromSlot = viper.GetString("from-slot")
+	c.toSlot = viper.GetString("to-slot")
 	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+
+	if c.fromSlot != "" || c.toSlot != "" {
+		if c.fromSlot == "" || c.toSlot == "" {
+			return nil, errors.New("both from-slot and to-slot are required for a report")
+		}
+		if c.stream {
+			return nil, errors.New("cannot specify stream with from-slot and to-slot")
+		}
+		c.report = newPackingReport()
+	}
+	if c.csvOutput {
+		if c.report == nil {
+			return nil, errors.New("CSV output is only available with from-slot and to-slot")
+		}
+		if c.jsonOutput {
+			return nil, errors.New("cannot specify both JSON and CSV output")
+		}
+	}
 
 	return c, nil
 }
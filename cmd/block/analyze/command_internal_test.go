@@ -37,6 +37,43 @@ func TestInput(t *testing.T) {
 			vars: map[string]interface{}{},
 			err:  "timeout is required",
 		},
+		{
+			name: "FromSlotOnly",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"from-slot": "1",
+			},
+			err: "both from-slot and to-slot are required for a report",
+		},
+		{
+			name: "RangeWithStream",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"from-slot": "1",
+				"to-slot":   "2",
+				"stream":    true,
+			},
+			err: "cannot specify stream with from-slot and to-slot",
+		},
+		{
+			name: "CSVWithoutRange",
+			vars: map[string]interface{}{
+				"timeout": "5s",
+				"csv":     true,
+			},
+			err: "CSV output is only available with from-slot and to-slot",
+		},
+		{
+			name: "JSONAndCSV",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"from-slot": "1",
+				"to-slot":   "2",
+				"json":      true,
+				"csv":       true,
+			},
+			err: "cannot specify both JSON and CSV output",
+		},
 		{
 			name: "Good",
 			vars: map[string]interface{}{
@@ -15,6 +15,7 @@ package blockanalyze
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -26,6 +27,17 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
+	if c.report != nil {
+		switch {
+		case c.jsonOutput:
+			return c.outputReportJSON(ctx)
+		case c.csvOutput:
+			return c.outputReportCSV(ctx)
+		default:
+			return c.outputReportTxt(ctx)
+		}
+	}
+
 	if c.jsonOutput {
 		return c.outputJSON(ctx)
 	}
@@ -155,3 +167,117 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 
 	return builder.String(), nil
 }
+
+func (c *command) outputReportJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.report)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *command) outputReportCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+	writer := csv.NewWriter(&builder)
+
+	if err := writer.Write([]string{
+		"group",
+		"id",
+		"slots",
+		"blocks",
+		"missed_slots",
+		"attestations",
+		"wasted_attestations",
+		"new_votes",
+		"available_votes",
+		"vote_inclusion_rate",
+		"sync_contributions",
+		"possible_sync_contributions",
+		"sync_participation_rate",
+		"value",
+		"average_value",
+	}); err != nil {
+		return "", err
+	}
+	if err := writer.Write(csvRecord("network", "all", c.report.Network)); err != nil {
+		return "", err
+	}
+	for _, proposer := range c.report.Proposers {
+		if err := writer.Write(csvRecord("proposer", fmt.Sprintf("%d", proposer.Proposer), &proposer.packingStats)); err != nil {
+			return "", err
+		}
+	}
+	for _, client := range c.report.Clients {
+		if err := writer.Write(csvRecord("client", client.Client, &client.packingStats)); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+func csvRecord(group string, id string, stats *packingStats) []string {
+	return []string{
+		group,
+		id,
+		strconv.Itoa(stats.Slots),
+		strconv.Itoa(stats.Blocks),
+		strconv.Itoa(stats.MissedSlots),
+		strconv.Itoa(stats.Attestations),
+		strconv.Itoa(stats.WastedAttestations),
+		strconv.Itoa(stats.NewVotes),
+		strconv.Itoa(stats.AvailableVotes),
+		fmt.Sprintf("%.4f", stats.VoteInclusionRate),
+		strconv.Itoa(stats.SyncContributions),
+		strconv.Itoa(stats.PossibleSyncContributions),
+		fmt.Sprintf("%.4f", stats.SyncParticipationRate),
+		fmt.Sprintf("%.3f", stats.Value),
+		fmt.Sprintf("%.3f", stats.AverageValue),
+	}
+}
+
+func (c *command) outputReportTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Slots %d-%d:\n", c.report.FromSlot, c.report.ToSlot))
+	network := c.report.Network
+	builder.WriteString(fmt.Sprintf("  Blocks: %d/%d (%d missed or orphaned)\n", network.Blocks, network.Slots, network.MissedSlots))
+	builder.WriteString(fmt.Sprintf("  Votes included: %d/%d (%.2f%%)\n", network.NewVotes, network.AvailableVotes, 100*network.VoteInclusionRate))
+	builder.WriteString(fmt.Sprintf("  Wasted attestations: %d/%d\n", network.WastedAttestations, network.Attestations))
+	builder.WriteString(fmt.Sprintf("  Sync participation: %.2f%%\n", 100*network.SyncParticipationRate))
+	builder.WriteString(fmt.Sprintf("  Average block value: %.3f\n", network.AverageValue))
+
+	builder.WriteString("\nProposers:\n")
+	builder.WriteString(tableHeader("Proposer"))
+	for _, proposer := range c.report.Proposers {
+		builder.WriteString(tableRow(fmt.Sprintf("%d", proposer.Proposer), &proposer.packingStats))
+	}
+
+	builder.WriteString("\nClients:\n")
+	builder.WriteString(tableHeader("Client"))
+	for _, client := range c.report.Clients {
+		builder.WriteString(tableRow(client.Client, &client.packingStats))
+	}
+
+	return builder.String(), nil
+}
+
+func tableHeader(name string) string {
+	return fmt.Sprintf("  %-12s %7s %7s %9s %13s %7s %10s\n", name, "Blocks", "Missed", "Votes %", "Wasted", "Sync %", "Avg value")
+}
+
+func tableRow(name string, stats *packingStats) string {
+	return fmt.Sprintf("  %-12s %7d %7d %9.2f %13s %7.2f %10.3f\n",
+		name,
+		stats.Blocks,
+		stats.MissedSlots,
+		100*stats.VoteInclusionRate,
+		fmt.Sprintf("%d/%d", stats.WastedAttestations, stats.Attestations),
+		100*stats.SyncParticipationRate,
+		stats.AverageValue,
+	)
+}
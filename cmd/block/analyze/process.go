@@ -25,6 +25,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	diskchaincache "github.com/wealdtech/ethdo/services/chaincache/disk"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 )
@@ -35,6 +36,10 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
+	if c.report != nil {
+		return c.processRange(ctx)
+	}
+
 	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
 		Block: c.blockID,
 	})
@@ -63,8 +68,12 @@ func (c *command) process(ctx context.Context) error {
 	// Calculate how many parents we need to fetch.
 	minSlot := slot
 	for _, attestation := range attestations {
-		if attestation.Data.Slot < minSlot {
-			minSlot = attestation.Data.Slot
+		data, err := attestation.Data()
+		if err != nil {
+			return err
+		}
+		if data.Slot < minSlot {
+			minSlot = data.Slot
 		}
 	}
 	if c.debug {
@@ -78,6 +87,151 @@ func (c *command) process(ctx context.Context) error {
 	return c.analyze(ctx, block)
 }
 
+// processRange analyzes each block in the range, aggregating the results in to the report.
+func (c *command) processRange(ctx context.Context) error {
+	var err error
+	c.report.FromSlot, err = util.ParseSlot(ctx, c.chainTime, c.fromSlot)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from slot")
+	}
+	c.report.ToSlot, err = util.ParseSlot(ctx, c.chainTime, c.toSlot)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to slot")
+	}
+	if c.report.FromSlot > c.report.ToSlot {
+		return errors.New("from slot cannot be after to slot")
+	}
+	c.report.slotsPerEpoch = c.chainTime.SlotsPerEpoch()
+
+	// Attestations in the first block can be for slots as far back as the start of the previous
+	// epoch, so obtain the votes already included in the blocks from that point.
+	primeSlot := phase0.Slot(0)
+	if epoch := c.chainTime.SlotToEpoch(c.report.FromSlot); epoch > 0 {
+		primeSlot = c.chainTime.FirstSlotOfEpoch(epoch - 1)
+	}
+	for slot := primeSlot; slot < c.report.FromSlot; slot++ {
+		block, err := c.fetchBlock(ctx, slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			continue
+		}
+		if err := c.processParentBlock(ctx, block); err != nil {
+			return err
+		}
+	}
+
+	proposers := make(map[phase0.Slot]phase0.ValidatorIndex)
+	for slot := c.report.FromSlot; slot <= c.report.ToSlot; slot++ {
+		if c.debug {
+			fmt.Printf("Processing slot %d\n", slot)
+		}
+		block, err := c.fetchBlock(ctx, slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			// Missed slot; find out who should have proposed it.
+			if _, exists := proposers[slot]; !exists {
+				if err := c.fetchProposers(ctx, c.chainTime.SlotToEpoch(slot), proposers); err != nil {
+					return err
+				}
+			}
+			proposer, exists := proposers[slot]
+			if !exists {
+				return fmt.Errorf("no proposer duty for slot %d", slot)
+			}
+			c.report.addMissed(proposer)
+			continue
+		}
+
+		if err := c.analyzeRangeBlock(ctx, slot, block); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to analyze block at slot %d", slot))
+		}
+		proposer, err := block.ProposerIndex()
+		if err != nil {
+			return err
+		}
+		graffiti, err := block.Graffiti()
+		if err != nil {
+			return err
+		}
+		client := unknownClient
+		if identification := util.ParseGraffitiIdentification(graffiti[:]); identification != nil && identification.ConsensusClient != "" {
+			client = identification.ConsensusClient
+		}
+		c.report.addBlock(proposer, client, block.Version, c.analysis)
+	}
+
+	// Votes available to blocks at the end of the range can be included in the following epoch,
+	// so look ahead to find them.
+	lastSlot := c.report.ToSlot + phase0.Slot(c.chainTime.SlotsPerEpoch())
+	if currentSlot := c.chainTime.CurrentSlot(); lastSlot > currentSlot {
+		lastSlot = currentSlot
+	}
+	for slot := c.report.ToSlot + 1; slot <= lastSlot; slot++ {
+		block, err := c.fetchBlock(ctx, slot)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			continue
+		}
+		if err := c.analyzeRangeBlock(ctx, slot, block); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to analyze block at slot %d", slot))
+		}
+		c.report.addInclusions(c.analysis)
+	}
+
+	c.report.finalize()
+
+	return nil
+}
+
+// analyzeRangeBlock analyzes a block in a range, and adds its votes to those seen.
+func (c *command) analyzeRangeBlock(ctx context.Context, slot phase0.Slot, block *spec.VersionedSignedBeaconBlock) error {
+	c.analysis = &blockAnalysis{
+		Slot: slot,
+	}
+	if err := c.analyze(ctx, block); err != nil {
+		return err
+	}
+
+	return c.processParentBlock(ctx, block)
+}
+
+// fetchBlock fetches the canonical block at the given slot, returning nil if there is no block.
+func (c *command) fetchBlock(ctx context.Context, slot phase0.Slot) (*spec.VersionedSignedBeaconBlock, error) {
+	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: fmt.Sprintf("%d", slot),
+	})
+	if err != nil {
+		var apiError *api.Error
+		if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain beacon block at slot %d", slot))
+	}
+
+	return blockResponse.Data, nil
+}
+
+// fetchProposers fetches the proposers for the given epoch.
+func (c *command) fetchProposers(ctx context.Context, epoch phase0.Epoch, proposers map[phase0.Slot]phase0.ValidatorIndex) error {
+	response, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch: epoch,
+	})
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+	}
+	for _, duty := range response.Data {
+		proposers[duty.Slot] = duty.ValidatorIndex
+	}
+
+	return nil
+}
+
 func (c *command) analyze(ctx context.Context, block *spec.VersionedSignedBeaconBlock) error {
 	if err := c.analyzeAttestations(ctx, block); err != nil {
 		return err
@@ -103,10 +257,14 @@ func (c *command) analyzeAttestations(ctx context.Context, block *spec.Versioned
 		if c.debug {
 			fmt.Printf("Processing attestation %d\n", i)
 		}
+		data, err := attestation.Data()
+		if err != nil {
+			return err
+		}
 		analysis := &attestationAnalysis{
-			Head:     attestation.Data.BeaconBlockRoot,
-			Target:   attestation.Data.Target.Root,
-			Distance: int(slot - attestation.Data.Slot),
+			Head:     data.BeaconBlockRoot,
+			Target:   data.Target.Root,
+			Distance: int(slot - data.Slot),
 		}
 
 		root, err := attestation.HashTreeRoot()
@@ -116,55 +274,59 @@ func (c *command) analyzeAttestations(ctx context.Context, block *spec.Versioned
 		if info, exists := c.priorAttestations[fmt.Sprintf("%#x", root)]; exists {
 			analysis.Duplicate = info
 		} else {
-			data := attestation.Data
+			committeeBits, err := util.AttestationCommitteeBits(ctx, c.committeesCache, attestation)
+			if err != nil {
+				return err
+			}
 			_, exists := blockVotes[data.Slot]
 			if !exists {
 				blockVotes[data.Slot] = make(map[phase0.CommitteeIndex]bitfield.Bitlist)
 			}
-			_, exists = blockVotes[data.Slot][data.Index]
-			if !exists {
-				blockVotes[data.Slot][data.Index] = bitfield.NewBitlist(attestation.AggregationBits.Len())
-			}
+			for committeeIndex, aggregationBits := range committeeBits {
+				_, exists = blockVotes[data.Slot][committeeIndex]
+				if !exists {
+					blockVotes[data.Slot][committeeIndex] = bitfield.NewBitlist(aggregationBits.Len())
+				}
 
-			// Count new votes.
-			analysis.PossibleVotes = int(attestation.AggregationBits.Len())
-			for j := range attestation.AggregationBits.Len() {
-				if attestation.AggregationBits.BitAt(j) {
-					analysis.Votes++
-					if blockVotes[data.Slot][data.Index].BitAt(j) {
-						// Already attested to in this block; skip.
-						continue
+				// Count new votes.
+				analysis.PossibleVotes += int(aggregationBits.Len())
+				for j := range aggregationBits.Len() {
+					if aggregationBits.BitAt(j) {
+						analysis.Votes++
+						if blockVotes[data.Slot][committeeIndex].BitAt(j) {
+							// Already attested to in this block; skip.
+							continue
+						}
+						if c.votes[data.Slot][committeeIndex].BitAt(j) {
+							// Already attested to in a previous block; skip.
+							continue
+						}
+						analysis.NewVotes++
+						blockVotes[data.Slot][committeeIndex].SetBitAt(j, true)
 					}
-					if c.votes[data.Slot][data.Index].BitAt(j) {
-						// Already attested to in a previous block; skip.
-						continue
-					}
-					analysis.NewVotes++
-					blockVotes[data.Slot][data.Index].SetBitAt(j, true)
 				}
 			}
 			// Calculate head correct.
-			var err error
-			analysis.HeadCorrect, err = c.calcHeadCorrect(ctx, attestation)
+			analysis.HeadCorrect, err = c.calcHeadCorrect(ctx, data)
 			if err != nil {
 				return err
 			}
 
 			// Calculate head timely.
-			analysis.HeadTimely = analysis.HeadCorrect && attestation.Data.Slot == slot-1
+			analysis.HeadTimely = analysis.HeadCorrect && data.Slot == slot-1
 
 			// Calculate source timely.
-			analysis.SourceTimely = attestation.Data.Slot >= slot-5
+			analysis.SourceTimely = data.Slot >= slot-5
 
 			// Calculate target correct.
-			analysis.TargetCorrect, err = c.calcTargetCorrect(ctx, attestation)
+			analysis.TargetCorrect, err = c.calcTargetCorrect(ctx, data)
 			if err != nil {
 				return err
 			}
 
 			// Calculate target timely.
 			if block.Version < spec.DataVersionDeneb {
-				analysis.TargetTimely = attestation.Data.Slot >= slot-32
+				analysis.TargetTimely = data.Slot >= slot-32
 			} else {
 				analysis.TargetTimely = true
 			}
@@ -194,7 +356,7 @@ func (c *command) fetchParents(ctx context.Context, block *spec.VersionedSignedB
 	if err != nil {
 		return err
 	}
-	root, err := block.Deneb.HashTreeRoot()
+	root, err := block.Root()
 	if err != nil {
 		panic(err)
 	}
@@ -237,7 +399,7 @@ func (c *command) fetchParents(ctx context.Context, block *spec.VersionedSignedB
 	return c.fetchParents(ctx, parentBlock, minSlot)
 }
 
-func (c *command) processParentBlock(_ context.Context, block *spec.VersionedSignedBeaconBlock) error {
+func (c *command) processParentBlock(ctx context.Context, block *spec.VersionedSignedBeaconBlock) error {
 	attestations, err := block.Attestations()
 	if err != nil {
 		return err
@@ -260,18 +422,27 @@ func (c *command) processParentBlock(_ context.Context, block *spec.VersionedSig
 			Index: i,
 		}
 
-		data := attestation.Data
+		data, err := attestation.Data()
+		if err != nil {
+			return err
+		}
+		committeeBits, err := util.AttestationCommitteeBits(ctx, c.committeesCache, attestation)
+		if err != nil {
+			return err
+		}
 		_, exists := c.votes[data.Slot]
 		if !exists {
 			c.votes[data.Slot] = make(map[phase0.CommitteeIndex]bitfield.Bitlist)
 		}
-		_, exists = c.votes[data.Slot][data.Index]
-		if !exists {
-			c.votes[data.Slot][data.Index] = bitfield.NewBitlist(attestation.AggregationBits.Len())
-		}
-		for j := range attestation.AggregationBits.Len() {
-			if attestation.AggregationBits.BitAt(j) {
-				c.votes[data.Slot][data.Index].SetBitAt(j, true)
+		for committeeIndex, aggregationBits := range committeeBits {
+			_, exists = c.votes[data.Slot][committeeIndex]
+			if !exists {
+				c.votes[data.Slot][committeeIndex] = bitfield.NewBitlist(aggregationBits.Len())
+			}
+			for j := range aggregationBits.Len() {
+				if aggregationBits.BitAt(j) {
+					c.votes[data.Slot][committeeIndex].SetBitAt(j, true)
+				}
 			}
 		}
 	}
@@ -311,6 +482,29 @@ func (c *command) setup(ctx context.Context) error {
 	if !isProvider {
 		return errors.New("connection does not provide beacon block header information")
 	}
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duty information")
+	}
+	beaconCommitteesProvider, isProvider := c.eth2Client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide beacon committee information")
+	}
+
+	if c.cacheDir != "" {
+		// Use the on-disk cache for historical chain data.
+		cache, err := diskchaincache.New(ctx,
+			diskchaincache.WithBaseDir(c.cacheDir),
+			diskchaincache.WithClient(c.eth2Client),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to set up chain data cache")
+		}
+		c.blocksProvider = cache
+		c.blockHeadersProvider = cache
+		beaconCommitteesProvider = cache
+	}
+	c.committeesCache = util.NewBeaconCommitteesCache(beaconCommitteesProvider)
 
 	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
 	if !isProvider {
@@ -385,8 +579,8 @@ func (c *command) setup(ctx context.Context) error {
 	return nil
 }
 
-func (c *command) calcHeadCorrect(ctx context.Context, attestation *phase0.Attestation) (bool, error) {
-	slot := attestation.Data.Slot
+func (c *command) calcHeadCorrect(ctx context.Context, data *phase0.AttestationData) (bool, error) {
+	slot := data.Slot
 	root, exists := c.headRoots[slot]
 	if !exists {
 		for {
@@ -413,20 +607,20 @@ func (c *command) calcHeadCorrect(ctx context.Context, attestation *phase0.Attes
 				slot--
 				continue
 			}
-			c.headRoots[attestation.Data.Slot] = response.Data.Root
+			c.headRoots[data.Slot] = response.Data.Root
 			root = response.Data.Root
 			break
 		}
 	}
 
-	return bytes.Equal(root[:], attestation.Data.BeaconBlockRoot[:]), nil
+	return bytes.Equal(root[:], data.BeaconBlockRoot[:]), nil
 }
 
-func (c *command) calcTargetCorrect(ctx context.Context, attestation *phase0.Attestation) (bool, error) {
-	root, exists := c.targetRoots[attestation.Data.Slot]
+func (c *command) calcTargetCorrect(ctx context.Context, data *phase0.AttestationData) (bool, error) {
+	root, exists := c.targetRoots[data.Slot]
 	if !exists {
 		// Start with first slot of the target epoch.
-		slot := c.chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch)
+		slot := c.chainTime.FirstSlotOfEpoch(data.Target.Epoch)
 		for {
 			response, err := c.blockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
 				Block: fmt.Sprintf("%d", slot),
@@ -450,12 +644,12 @@ func (c *command) calcTargetCorrect(ctx context.Context, attestation *phase0.Att
 				slot--
 				continue
 			}
-			c.targetRoots[attestation.Data.Slot] = response.Data.Root
+			c.targetRoots[data.Slot] = response.Data.Root
 			root = response.Data.Root
 			break
 		}
 	}
-	return bytes.Equal(root[:], attestation.Data.Target.Root[:]), nil
+	return bytes.Equal(root[:], data.Target.Root[:]), nil
 }
 
 func (c *command) analyzeSyncCommittees(_ context.Context, block *spec.VersionedSignedBeaconBlock) error {
@@ -491,6 +685,13 @@ func (c *command) analyzeSyncCommittees(_ context.Context, block *spec.Versioned
 		c.analysis.SyncCommitee.Value = c.analysis.SyncCommitee.Score * float64(c.analysis.SyncCommitee.Contributions)
 		c.analysis.Value += c.analysis.SyncCommitee.Value
 		return nil
+	case spec.DataVersionElectra:
+		c.analysis.SyncCommitee.Contributions = int(block.Electra.Message.Body.SyncAggregate.SyncCommitteeBits.Count())
+		c.analysis.SyncCommitee.PossibleContributions = int(block.Electra.Message.Body.SyncAggregate.SyncCommitteeBits.Len())
+		c.analysis.SyncCommitee.Score = float64(c.syncRewardWeight) / float64(c.weightDenominator)
+		c.analysis.SyncCommitee.Value = c.analysis.SyncCommitee.Score * float64(c.analysis.SyncCommitee.Contributions)
+		c.analysis.Value += c.analysis.SyncCommitee.Value
+		return nil
 	default:
 		return fmt.Errorf("unsupported block version %d", block.Version)
 	}
@@ -18,8 +18,16 @@ import (
 	"os"
 	"testing"
 
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func TestProcess(t *testing.T) {
@@ -61,3 +69,94 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyzeElectra(t *testing.T) {
+	ctx := context.Background()
+
+	headRoot := phase0.Root{0x01}
+	targetRoot := phase0.Root{0x02}
+	c := &command{
+		timelySourceWeight: 14,
+		timelyTargetWeight: 26,
+		timelyHeadWeight:   14,
+		syncRewardWeight:   2,
+		weightDenominator:  64,
+		priorAttestations:  make(map[string]*attestationData),
+		headRoots:          map[phase0.Slot]phase0.Root{100: headRoot},
+		targetRoots:        map[phase0.Slot]phase0.Root{100: targetRoot},
+		votes:              make(map[phase0.Slot]map[phase0.CommitteeIndex]bitfield.Bitlist),
+		committeesCache: util.NewBeaconCommitteesCache(mock.NewBeaconCommitteesProvider([]*apiv1.BeaconCommittee{
+			{Slot: 100, Index: 0, Validators: []phase0.ValidatorIndex{1, 2}},
+			{Slot: 100, Index: 1, Validators: []phase0.ValidatorIndex{3, 4, 5}},
+		})),
+		analysis: &blockAnalysis{
+			Slot: 101,
+		},
+	}
+
+	// A vote from committee 1 was included in an earlier block.
+	c.votes[100] = map[phase0.CommitteeIndex]bitfield.Bitlist{
+		1: bitfield.NewBitlist(3),
+	}
+	c.votes[100][1].SetBitAt(0, true)
+
+	// Aggregate covering committees 0 and 1; committee 0 occupies bits 0-1 and committee 1 bits 2-4.
+	aggregationBits := bitfield.NewBitlist(5)
+	aggregationBits.SetBitAt(0, true)
+	aggregationBits.SetBitAt(2, true)
+	aggregationBits.SetBitAt(4, true)
+	committeeBits := bitfield.NewBitvector64()
+	committeeBits.SetBitAt(0, true)
+	committeeBits.SetBitAt(1, true)
+	syncCommitteeBits := bitfield.NewBitvector512()
+	syncCommitteeBits.SetBitAt(0, true)
+	syncCommitteeBits.SetBitAt(1, true)
+
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.SignedBeaconBlock{
+			Message: &electra.BeaconBlock{
+				Slot: 101,
+				Body: &electra.BeaconBlockBody{
+					Attestations: []*electra.Attestation{
+						{
+							AggregationBits: aggregationBits,
+							CommitteeBits:   committeeBits,
+							Data: &phase0.AttestationData{
+								Slot:            100,
+								BeaconBlockRoot: headRoot,
+								Source:          &phase0.Checkpoint{},
+								Target: &phase0.Checkpoint{
+									Epoch: 3,
+									Root:  targetRoot,
+								},
+							},
+						},
+					},
+					SyncAggregate: &altair.SyncAggregate{
+						SyncCommitteeBits: syncCommitteeBits,
+					},
+				},
+			},
+		},
+	}
+
+	require.NoError(t, c.analyze(ctx, block))
+	require.Len(t, c.analysis.Attestations, 1)
+	analysis := c.analysis.Attestations[0]
+	require.Equal(t, 5, analysis.PossibleVotes)
+	require.Equal(t, 3, analysis.Votes)
+	require.Equal(t, 2, analysis.NewVotes)
+	require.Equal(t, 1, analysis.Distance)
+	require.True(t, analysis.HeadCorrect)
+	require.True(t, analysis.HeadTimely)
+	require.True(t, analysis.TargetCorrect)
+	require.True(t, analysis.TargetTimely)
+	require.Equal(t, 2, c.analysis.SyncCommitee.Contributions)
+	require.Equal(t, 512, c.analysis.SyncCommitee.PossibleContributions)
+
+	// Processing the block records its votes against each committee.
+	require.NoError(t, c.processParentBlock(ctx, block))
+	require.Equal(t, []int{0}, c.votes[100][0].BitIndices())
+	require.Equal(t, []int{0, 2}, c.votes[100][1].BitIndices())
+}
@@ -0,0 +1,240 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockanalyze
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// unknownClient is the client name used for blocks without client identification in their graffiti.
+const unknownClient = "unknown"
+
+// packingReport contains block packing statistics over a range of slots.
+type packingReport struct {
+	FromSlot  phase0.Slot        `json:"from_slot"`
+	ToSlot    phase0.Slot        `json:"to_slot"`
+	Network   *packingStats      `json:"network"`
+	Proposers []*proposerPacking `json:"proposers"`
+	Clients   []*clientPacking   `json:"clients"`
+
+	slotsPerEpoch uint64
+	blocks        []*blockPacking
+	proposers     map[phase0.ValidatorIndex]*proposerPacking
+	clients       map[string]*clientPacking
+	// inclusions is attestation slot -> inclusion slot -> number of votes first included.
+	inclusions map[phase0.Slot]map[phase0.Slot]int
+}
+
+// packingStats contains aggregate block packing statistics.
+type packingStats struct {
+	Slots                     int     `json:"slots"`
+	Blocks                    int     `json:"blocks"`
+	MissedSlots               int     `json:"missed_slots"`
+	Attestations              int     `json:"attestations"`
+	WastedAttestations        int     `json:"wasted_attestations"`
+	NewVotes                  int     `json:"new_votes"`
+	AvailableVotes            int     `json:"available_votes"`
+	SyncContributions         int     `json:"sync_contributions"`
+	PossibleSyncContributions int     `json:"possible_sync_contributions"`
+	Value                     float64 `json:"value"`
+	VoteInclusionRate         float64 `json:"vote_inclusion_rate"`
+	SyncParticipationRate     float64 `json:"sync_participation_rate"`
+	AverageValue              float64 `json:"average_value"`
+}
+
+// proposerPacking contains the block packing statistics of a single proposer.
+type proposerPacking struct {
+	Proposer phase0.ValidatorIndex `json:"proposer"`
+	packingStats
+}
+
+// clientPacking contains the block packing statistics of a single client.
+type clientPacking struct {
+	Client string `json:"client"`
+	packingStats
+}
+
+// blockPacking contains the information about a block required to calculate its available votes.
+type blockPacking struct {
+	slot     phase0.Slot
+	version  spec.DataVersion
+	proposer *proposerPacking
+	client   *clientPacking
+}
+
+func newPackingReport() *packingReport {
+	return &packingReport{
+		Network:    &packingStats{},
+		Proposers:  make([]*proposerPacking, 0),
+		Clients:    make([]*clientPacking, 0),
+		blocks:     make([]*blockPacking, 0),
+		proposers:  make(map[phase0.ValidatorIndex]*proposerPacking),
+		clients:    make(map[string]*clientPacking),
+		inclusions: make(map[phase0.Slot]map[phase0.Slot]int),
+	}
+}
+
+// proposer returns the statistics for the given proposer, creating them if required.
+func (r *packingReport) proposer(index phase0.ValidatorIndex) *proposerPacking {
+	proposer, exists := r.proposers[index]
+	if !exists {
+		proposer = &proposerPacking{
+			Proposer: index,
+		}
+		r.proposers[index] = proposer
+	}
+
+	return proposer
+}
+
+// client returns the statistics for the given client, creating them if required.
+func (r *packingReport) client(name string) *clientPacking {
+	client, exists := r.clients[name]
+	if !exists {
+		client = &clientPacking{
+			Client: name,
+		}
+		r.clients[name] = client
+	}
+
+	return client
+}
+
+// addBlock adds the analysis of a block to the report.
+func (r *packingReport) addBlock(proposerIndex phase0.ValidatorIndex,
+	clientName string,
+	version spec.DataVersion,
+	analysis *blockAnalysis,
+) {
+	proposer := r.proposer(proposerIndex)
+	client := r.client(clientName)
+	for _, stats := range []*packingStats{r.Network, &proposer.packingStats, &client.packingStats} {
+		stats.Slots++
+		stats.Blocks++
+		for _, attestation := range analysis.Attestations {
+			stats.Attestations++
+			if attestation.NewVotes == 0 {
+				stats.WastedAttestations++
+			}
+			stats.NewVotes += attestation.NewVotes
+		}
+		if analysis.SyncCommitee != nil {
+			stats.SyncContributions += analysis.SyncCommitee.Contributions
+			stats.PossibleSyncContributions += analysis.SyncCommitee.PossibleContributions
+		}
+		stats.Value += analysis.Value
+	}
+
+	r.blocks = append(r.blocks, &blockPacking{
+		slot:     analysis.Slot,
+		version:  version,
+		proposer: proposer,
+		client:   client,
+	})
+	r.addInclusions(analysis)
+}
+
+// addMissed adds a slot without a canonical block to the report.
+func (r *packingReport) addMissed(proposerIndex phase0.ValidatorIndex) {
+	proposer := r.proposer(proposerIndex)
+	for _, stats := range []*packingStats{r.Network, &proposer.packingStats} {
+		stats.Slots++
+		stats.MissedSlots++
+	}
+}
+
+// addInclusions records the votes first included in a block.
+// This is also called for blocks after the end of the range, to find votes that were available
+// to blocks in the range but not included by them.
+func (r *packingReport) addInclusions(analysis *blockAnalysis) {
+	for _, attestation := range analysis.Attestations {
+		if attestation.NewVotes == 0 {
+			continue
+		}
+		attestationSlot := analysis.Slot - phase0.Slot(attestation.Distance)
+		if _, exists := r.inclusions[attestationSlot]; !exists {
+			r.inclusions[attestationSlot] = make(map[phase0.Slot]int)
+		}
+		r.inclusions[attestationSlot][analysis.Slot] += attestation.NewVotes
+	}
+}
+
+// includable returns true if an attestation for the given slot can be included in a block at the given slot.
+func (r *packingReport) includable(attestationSlot phase0.Slot, block *blockPacking) bool {
+	if attestationSlot >= block.slot {
+		return false
+	}
+	if block.version >= spec.DataVersionDeneb {
+		// Attestations from the current and previous epoch can be included.
+		return uint64(attestationSlot)/r.slotsPerEpoch+1 >= uint64(block.slot)/r.slotsPerEpoch
+	}
+
+	return uint64(block.slot-attestationSlot) <= r.slotsPerEpoch
+}
+
+// finalize calculates the available votes and rates, and orders the proposers and clients in the report.
+func (r *packingReport) finalize() {
+	// The votes available to a block are those that could be included by it, and were
+	// included by it or a later block.
+	for _, block := range r.blocks {
+		available := 0
+		for attestationSlot, inclusions := range r.inclusions {
+			if !r.includable(attestationSlot, block) {
+				continue
+			}
+			for inclusionSlot, votes := range inclusions {
+				if inclusionSlot >= block.slot {
+					available += votes
+				}
+			}
+		}
+		r.Network.AvailableVotes += available
+		block.proposer.AvailableVotes += available
+		block.client.AvailableVotes += available
+	}
+
+	r.Network.finalize()
+	r.Proposers = make([]*proposerPacking, 0, len(r.proposers))
+	for _, proposer := range r.proposers {
+		proposer.finalize()
+		r.Proposers = append(r.Proposers, proposer)
+	}
+	sort.Slice(r.Proposers, func(i int, j int) bool {
+		return r.Proposers[i].Proposer < r.Proposers[j].Proposer
+	})
+	r.Clients = make([]*clientPacking, 0, len(r.clients))
+	for _, client := range r.clients {
+		client.finalize()
+		r.Clients = append(r.Clients, client)
+	}
+	sort.Slice(r.Clients, func(i int, j int) bool {
+		return r.Clients[i].Client < r.Clients[j].Client
+	})
+}
+
+// finalize calculates the rates of the statistics.
+func (s *packingStats) finalize() {
+	if s.AvailableVotes > 0 {
+		s.VoteInclusionRate = float64(s.NewVotes) / float64(s.AvailableVotes)
+	}
+	if s.PossibleSyncContributions > 0 {
+		s.SyncParticipationRate = float64(s.SyncContributions) / float64(s.PossibleSyncContributions)
+	}
+	if s.Blocks > 0 {
+		s.AverageValue = s.Value / float64(s.Blocks)
+	}
+}
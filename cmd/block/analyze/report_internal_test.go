@@ -0,0 +1,140 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockanalyze
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	report := newPackingReport()
+	report.slotsPerEpoch = 32
+
+	// Block at slot 100 includes 60 of the 64 votes for slot 99, and wastes an attestation.
+	report.addBlock(1, "teku", spec.DataVersionDeneb, &blockAnalysis{
+		Slot: 100,
+		Attestations: []*attestationAnalysis{
+			{Distance: 1, NewVotes: 60},
+			{Distance: 1, NewVotes: 0},
+		},
+		SyncCommitee: &syncCommitteeAnalysis{
+			Contributions:         500,
+			PossibleContributions: 512,
+		},
+		Value: 10,
+	})
+	// Slot 101 is missed.
+	report.addMissed(2)
+	// Block at slot 102 includes the remaining 4 votes for slot 99 and all 64 votes for slot 100.
+	report.addBlock(1, unknownClient, spec.DataVersionDeneb, &blockAnalysis{
+		Slot: 102,
+		Attestations: []*attestationAnalysis{
+			{Distance: 3, NewVotes: 4},
+			{Distance: 2, NewVotes: 64},
+		},
+		SyncCommitee: &syncCommitteeAnalysis{
+			Contributions:         512,
+			PossibleContributions: 512,
+		},
+		Value: 20,
+	})
+	// Block after the range includes 64 votes for slot 102.
+	report.addInclusions(&blockAnalysis{
+		Slot: 103,
+		Attestations: []*attestationAnalysis{
+			{Distance: 1, NewVotes: 64},
+		},
+	})
+	report.finalize()
+
+	network := report.Network
+	require.Equal(t, 3, network.Slots)
+	require.Equal(t, 2, network.Blocks)
+	require.Equal(t, 1, network.MissedSlots)
+	require.Equal(t, 4, network.Attestations)
+	require.Equal(t, 1, network.WastedAttestations)
+	require.Equal(t, 128, network.NewVotes)
+	// Slot 100 could include the 64 votes for slot 99; slot 102 the 4 remaining for slot 99 and 64 for slot 100.
+	require.Equal(t, 132, network.AvailableVotes)
+	require.InDelta(t, 128.0/132.0, network.VoteInclusionRate, 0.0001)
+	require.InDelta(t, 1012.0/1024.0, network.SyncParticipationRate, 0.0001)
+	require.InDelta(t, 15.0, network.AverageValue, 0.0001)
+
+	require.Len(t, report.Proposers, 2)
+	require.Equal(t, phase0.ValidatorIndex(1), report.Proposers[0].Proposer)
+	require.Equal(t, 2, report.Proposers[0].Blocks)
+	require.Equal(t, 0, report.Proposers[0].MissedSlots)
+	require.Equal(t, phase0.ValidatorIndex(2), report.Proposers[1].Proposer)
+	require.Equal(t, 1, report.Proposers[1].Slots)
+	require.Equal(t, 1, report.Proposers[1].MissedSlots)
+
+	require.Len(t, report.Clients, 2)
+	require.Equal(t, "teku", report.Clients[0].Client)
+	require.Equal(t, 60, report.Clients[0].NewVotes)
+	require.Equal(t, 64, report.Clients[0].AvailableVotes)
+	require.Equal(t, 1, report.Clients[0].WastedAttestations)
+	require.Equal(t, unknownClient, report.Clients[1].Client)
+	require.Equal(t, 68, report.Clients[1].NewVotes)
+	require.Equal(t, 68, report.Clients[1].AvailableVotes)
+}
+
+func TestIncludable(t *testing.T) {
+	report := newPackingReport()
+	report.slotsPerEpoch = 32
+
+	tests := []struct {
+		name            string
+		attestationSlot phase0.Slot
+		block           *blockPacking
+		res             bool
+	}{
+		{
+			name:            "SameSlot",
+			attestationSlot: 100,
+			block:           &blockPacking{slot: 100, version: spec.DataVersionDeneb},
+		},
+		{
+			name:            "PreviousEpochDeneb",
+			attestationSlot: 64,
+			block:           &blockPacking{slot: 127, version: spec.DataVersionDeneb},
+			res:             true,
+		},
+		{
+			name:            "TooOldDeneb",
+			attestationSlot: 63,
+			block:           &blockPacking{slot: 96, version: spec.DataVersionDeneb},
+		},
+		{
+			name:            "WithinEpochCapella",
+			attestationSlot: 64,
+			block:           &blockPacking{slot: 96, version: spec.DataVersionCapella},
+			res:             true,
+		},
+		{
+			name:            "TooOldCapella",
+			attestationSlot: 64,
+			block:           &blockPacking{slot: 97, version: spec.DataVersionCapella},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.res, report.includable(test.attestationSlot, test.block))
+		})
+	}
+}
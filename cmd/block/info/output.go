@@ -19,7 +19,6 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
-	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -28,13 +27,16 @@ import (
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
 	"github.com/attestantio/go-eth2-client/spec/bellatrix"
 	"github.com/attestantio/go-eth2-client/spec/capella"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-string2eth"
 )
 
@@ -198,6 +200,95 @@ func outputBlockAttesterSlashings(ctx context.Context, eth2Client eth2client.Ser
 	return res.String(), nil
 }
 
+func outputElectraBlockAttestations(ctx context.Context, eth2Client eth2client.Service, verbose bool, attestations []*electra.Attestation) (string, error) {
+	res := strings.Builder{}
+
+	res.WriteString(fmt.Sprintf("Attestations: %d\n", len(attestations)))
+	if verbose {
+		var committeesCache *util.BeaconCommitteesCache
+		beaconCommitteesProvider, isProvider := eth2Client.(eth2client.BeaconCommitteesProvider)
+		if isProvider {
+			committeesCache = util.NewBeaconCommitteesCache(beaconCommitteesProvider)
+		}
+		for i, att := range attestations {
+			res.WriteString(fmt.Sprintf("  %d:\n", i))
+			res.WriteString(fmt.Sprintf("    Committee indices: %s\n", committeeIndices(att.CommitteeBits)))
+			res.WriteString(fmt.Sprintf("    Attesters: %d/%d\n", att.AggregationBits.Count(), att.AggregationBits.Len()))
+			res.WriteString(fmt.Sprintf("    Aggregation bits: %s\n", bitlistToString(att.AggregationBits)))
+			if committeesCache != nil {
+				indices, err := electraAttestingIndices(ctx, committeesCache, att)
+				if err != nil {
+					// Failed to get them; stop us continually attempting to re-fetch.
+					committeesCache = nil
+				} else {
+					res.WriteString(fmt.Sprintf("    Attesting indices: %s\n", indices))
+				}
+			}
+			res.WriteString(fmt.Sprintf("    Slot: %d\n", att.Data.Slot))
+			res.WriteString(fmt.Sprintf("    Beacon block root: %#x\n", att.Data.BeaconBlockRoot))
+			res.WriteString(fmt.Sprintf("    Source epoch: %d\n", att.Data.Source.Epoch))
+			res.WriteString(fmt.Sprintf("    Source root: %#x\n", att.Data.Source.Root))
+			res.WriteString(fmt.Sprintf("    Target epoch: %d\n", att.Data.Target.Epoch))
+			res.WriteString(fmt.Sprintf("    Target root: %#x\n", att.Data.Target.Root))
+		}
+	}
+
+	return res.String(), nil
+}
+
+// electraAttestingIndices returns the indices of the validators attesting in an Electra attestation,
+// in committee order.
+func electraAttestingIndices(ctx context.Context,
+	committeesCache *util.BeaconCommitteesCache,
+	att *electra.Attestation,
+) (
+	string,
+	error,
+) {
+	committeeBits, err := util.AttestationCommitteeBits(ctx, committeesCache, &spec.VersionedAttestation{
+		Version: spec.DataVersionElectra,
+		Electra: att,
+	})
+	if err != nil {
+		return "", err
+	}
+	committees, err := committeesCache.Fetch(ctx, att.Data.Slot)
+	if err != nil {
+		return "", err
+	}
+
+	indices := make([]string, 0, len(committeeBits))
+	for _, index := range att.CommitteeBits.BitIndices() {
+		committeeIndex := phase0.CommitteeIndex(index)
+		if committeeIndices := attestingIndices(committeeBits[committeeIndex], committees[committeeIndex]); committeeIndices != "" {
+			indices = append(indices, committeeIndices)
+		}
+	}
+
+	return strings.Join(indices, " "), nil
+}
+
+func outputElectraBlockAttesterSlashings(ctx context.Context, eth2Client eth2client.Service, verbose bool, attesterSlashings []*electra.AttesterSlashing) (string, error) {
+	// Electra attester slashings only differ from earlier slashings in the number of indices they can hold.
+	slashings := make([]*phase0.AttesterSlashing, len(attesterSlashings))
+	for i, slashing := range attesterSlashings {
+		slashings[i] = &phase0.AttesterSlashing{
+			Attestation1: &phase0.IndexedAttestation{
+				AttestingIndices: slashing.Attestation1.AttestingIndices,
+				Data:             slashing.Attestation1.Data,
+				Signature:        slashing.Attestation1.Signature,
+			},
+			Attestation2: &phase0.IndexedAttestation{
+				AttestingIndices: slashing.Attestation2.AttestingIndices,
+				Data:             slashing.Attestation2.Data,
+				Signature:        slashing.Attestation2.Signature,
+			},
+		}
+	}
+
+	return outputBlockAttesterSlashings(ctx, eth2Client, verbose, slashings)
+}
+
 func outputBlockDeposits(_ context.Context, verbose bool, deposits []*phase0.Deposit) (string, error) {
 	res := strings.Builder{}
 
@@ -264,6 +355,52 @@ func outputBlockBLSToExecutionChanges(ctx context.Context, eth2Client eth2client
 	return res.String(), nil
 }
 
+func outputElectraBlockExecutionRequests(_ context.Context, verbose bool, requests *electra.ExecutionRequests) (string, error) {
+	if requests == nil {
+		return "", nil
+	}
+
+	res := strings.Builder{}
+
+	res.WriteString(fmt.Sprintf("Deposit requests: %d\n", len(requests.Deposits)))
+	if verbose {
+		for i, request := range requests.Deposits {
+			res.WriteString(fmt.Sprintf("  %d:\n", i))
+			res.WriteString(fmt.Sprintf("    Public key: %#x\n", request.Pubkey))
+			res.WriteString(fmt.Sprintf("    Amount: %s\n", string2eth.GWeiToString(uint64(request.Amount), true)))
+			res.WriteString(fmt.Sprintf("    Withdrawal credentials: %#x\n", request.WithdrawalCredentials))
+			res.WriteString(fmt.Sprintf("    Signature: %#x\n", request.Signature))
+			res.WriteString(fmt.Sprintf("    Index: %d\n", request.Index))
+		}
+	}
+
+	res.WriteString(fmt.Sprintf("Withdrawal requests: %d\n", len(requests.Withdrawals)))
+	if verbose {
+		for i, request := range requests.Withdrawals {
+			res.WriteString(fmt.Sprintf("  %d:\n", i))
+			res.WriteString(fmt.Sprintf("    Source address: %s\n", request.SourceAddress.String()))
+			res.WriteString(fmt.Sprintf("    Validator public key: %#x\n", request.ValidatorPubkey))
+			if request.Amount == 0 {
+				res.WriteString("    Amount: full exit\n")
+			} else {
+				res.WriteString(fmt.Sprintf("    Amount: %s\n", string2eth.GWeiToString(uint64(request.Amount), true)))
+			}
+		}
+	}
+
+	res.WriteString(fmt.Sprintf("Consolidation requests: %d\n", len(requests.Consolidations)))
+	if verbose {
+		for i, request := range requests.Consolidations {
+			res.WriteString(fmt.Sprintf("  %d:\n", i))
+			res.WriteString(fmt.Sprintf("    Source address: %s\n", request.SourceAddress.String()))
+			res.WriteString(fmt.Sprintf("    Source public key: %#x\n", request.SourcePubkey))
+			res.WriteString(fmt.Sprintf("    Target public key: %#x\n", request.TargetPubkey))
+		}
+	}
+
+	return res.String(), nil
+}
+
 func outputBlockSyncAggregate(ctx context.Context, eth2Client eth2client.Service, verbose bool, syncAggregate *altair.SyncAggregate, epoch phase0.Epoch) (string, error) {
 	res := strings.Builder{}
 
@@ -401,6 +538,122 @@ func outputCapellaBlockText(ctx context.Context, data *dataOut, signedBlock *cap
 	return res.String(), nil
 }
 
+func outputElectraBlockText(ctx context.Context,
+	data *dataOut,
+	signedBlock *electra.SignedBeaconBlock,
+	blobs []*deneb.BlobSidecar,
+) (
+	string,
+	error,
+) {
+	if signedBlock == nil {
+		return "", errors.New("no block supplied")
+	}
+
+	body := signedBlock.Message.Body
+
+	res := strings.Builder{}
+
+	// General info.
+	blockRoot, err := signedBlock.Message.HashTreeRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to obtain block root")
+	}
+	bodyRoot, err := signedBlock.Message.Body.HashTreeRoot()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate body root")
+	}
+
+	tmp, err := outputBlockGeneral(ctx,
+		data.verbose,
+		signedBlock.Message.Slot,
+		signedBlock.Message.ProposerIndex,
+		blockRoot,
+		bodyRoot,
+		signedBlock.Message.ParentRoot,
+		signedBlock.Message.StateRoot,
+		signedBlock.Message.Body.Graffiti[:],
+		data.genesisTime,
+		data.slotDuration,
+		data.slotsPerEpoch)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Eth1 data.
+	if data.verbose {
+		tmp, err := outputBlockETH1Data(ctx, body.ETH1Data)
+		if err != nil {
+			return "", err
+		}
+		res.WriteString(tmp)
+	}
+
+	// Sync aggregate.
+	tmp, err = outputBlockSyncAggregate(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.SyncAggregate, phase0.Epoch(uint64(signedBlock.Message.Slot)/data.slotsPerEpoch))
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Attestations.
+	tmp, err = outputElectraBlockAttestations(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.Attestations)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Attester slashings.
+	tmp, err = outputElectraBlockAttesterSlashings(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.AttesterSlashings)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	res.WriteString(fmt.Sprintf("Proposer slashings: %d\n", len(body.ProposerSlashings)))
+	// Add verbose proposer slashings.
+
+	tmp, err = outputBlockDeposits(ctx, data.verbose, signedBlock.Message.Body.Deposits)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	// Voluntary exits.
+	tmp, err = outputBlockVoluntaryExits(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.VoluntaryExits)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	tmp, err = outputBlockBLSToExecutionChanges(ctx, data.eth2Client, data.verbose, signedBlock.Message.Body.BLSToExecutionChanges)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	tmp, err = outputDenebBlockExecutionPayload(ctx, data.verbose, signedBlock.Message.Body.ExecutionPayload)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	tmp, err = outputElectraBlockExecutionRequests(ctx, data.verbose, signedBlock.Message.Body.ExecutionRequests)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	tmp, err = outputDenebBlobInfo(ctx, data.verbose, signedBlock.Message.Body.BlobKZGCommitments, blobs)
+	if err != nil {
+		return "", err
+	}
+	res.WriteString(tmp)
+
+	return res.String(), nil
+}
+
 func outputDenebBlockText(ctx context.Context,
 	data *dataOut,
 	signedBlock *deneb.SignedBeaconBlock,
@@ -502,7 +755,7 @@ func outputDenebBlockText(ctx context.Context,
 	}
 	res.WriteString(tmp)
 
-	tmp, err = outputDenebBlobInfo(ctx, data.verbose, signedBlock.Message.Body, blobs)
+	tmp, err = outputDenebBlobInfo(ctx, data.verbose, signedBlock.Message.Body.BlobKZGCommitments, blobs)
 	if err != nil {
 		return "", err
 	}
@@ -899,18 +1152,14 @@ func outputDenebBlockExecutionPayload(_ context.Context,
 
 func outputDenebBlobInfo(_ context.Context,
 	verbose bool,
-	body *deneb.BeaconBlockBody,
+	commitments []deneb.KZGCommitment,
 	blobs []*deneb.BlobSidecar,
 ) (
 	string,
 	error,
 ) {
-	if body == nil {
-		return "", nil
-	}
-
 	if !verbose {
-		return fmt.Sprintf("Blobs: %d\n", len(body.BlobKZGCommitments)), nil
+		return fmt.Sprintf("Blobs: %d\n", len(commitments)), nil
 	}
 
 	res := strings.Builder{}
@@ -920,7 +1169,7 @@ func outputDenebBlobInfo(_ context.Context,
 			res.WriteString("Blobs\n")
 		}
 		res.WriteString(fmt.Sprintf("  Index: %d\n", blob.Index))
-		res.WriteString(fmt.Sprintf("  KZG commitment: %s\n", body.BlobKZGCommitments[i].String()))
+		res.WriteString(fmt.Sprintf("  KZG commitment: %s\n", commitments[i].String()))
 	}
 
 	return res.String(), nil
@@ -1029,6 +1278,16 @@ func bitlistToString(input bitfield.Bitlist) string {
 	return strings.TrimSpace(res)
 }
 
+// committeeIndices returns the indices of the committees set in the given bits.
+func committeeIndices(input bitfield.Bitvector64) string {
+	indices := make([]string, 0)
+	for _, index := range input.BitIndices() {
+		indices = append(indices, strconv.Itoa(index))
+	}
+
+	return strings.Join(indices, " ")
+}
+
 func bitvectorToString(input bitfield.Bitvector512) string {
 	bits := int(input.Len())
 
@@ -1072,74 +1331,35 @@ func blockGraffiti(_ context.Context, graffiti []byte) string {
 	}
 
 	// See if there is client identification information present in the graffiti.
-	// The client identification will always be the last entry in the graffiti, with a space beforehand.
-	parts := bytes.Split(graffiti, []byte{' '})
-
-	// Consensus and execution client values come from
-	// https://github.com/ethereum/execution-apis/blob/main/src/engine/identification.md
-	consensusClients := map[string]string{
-		"GR": "grandine",
-		"LH": "lighthouse",
-		"LS": "lodestar",
-		"NB": "nimbus",
-		"PM": "prysm",
-		"TK": "teku",
-	}
-	consensusRegex := regexp.MustCompile(`(GR|LH|LS|NB|PM|TK)([0-9a-f]*)`)
-	consensusData := consensusRegex.Find(parts[len(parts)-1])
-
-	executionClients := map[string]string{
-		"BU": "besu",
-		"EG": "erigon",
-		"EJ": "ethereumJS",
-		"GE": "go-ethereum",
-		"NM": "nethermind",
-		"RH": "reth",
-	}
-	executionRegex := regexp.MustCompile(`(BU|EG|EJ|GE|NM|RH)([0-9a-f]*)`)
-	executionData := executionRegex.Find(parts[len(parts)-1])
-
-	if len(consensusData) == 0 && len(executionData) == 0 {
+	identification := util.ParseGraffitiIdentification(graffiti)
+	if identification == nil {
 		// There is no identifier; return the graffiti as-is.
 		return fmt.Sprintf("Graffiti: %s\n", string(graffiti))
 	}
 
 	res := strings.Builder{}
 
-	truncatedGraffiti := bytes.Join(parts[0:len(parts)-1], []byte(" "))
-	if len(truncatedGraffiti) > 0 {
-		res.WriteString(fmt.Sprintf("Graffiti: %s\n", string(truncatedGraffiti)))
+	if len(identification.Graffiti) > 0 {
+		res.WriteString(fmt.Sprintf("Graffiti: %s\n", string(identification.Graffiti)))
 	}
 
-	if len(consensusData) > 0 {
-		consensusClient := consensusData[0:2]
-		consensusHash := ""
-		if len(consensusData) > 2 {
-			consensusHash = string(consensusData[2:])
-		}
-
+	if identification.ConsensusClient != "" {
 		res.WriteString("Consensus client: ")
-		res.WriteString(consensusClients[string(consensusClient)])
-		if consensusHash != "" {
+		res.WriteString(identification.ConsensusClient)
+		if identification.ConsensusHash != "" {
 			res.WriteString(" (version hash ")
-			res.WriteString(consensusHash)
+			res.WriteString(identification.ConsensusHash)
 			res.WriteString(")")
 		}
 		res.WriteString("\n")
 	}
 
-	if len(executionData) > 0 {
-		executionClient := executionData[0:2]
-		executionHash := ""
-		if len(executionData) > 2 {
-			executionHash = string(executionData[2:])
-		}
-
+	if identification.ExecutionClient != "" {
 		res.WriteString("Execution client: ")
-		res.WriteString(executionClients[string(executionClient)])
-		if executionHash != "" {
+		res.WriteString(identification.ExecutionClient)
+		if identification.ExecutionHash != "" {
 			res.WriteString(" (version hash ")
-			res.WriteString(executionHash)
+			res.WriteString(identification.ExecutionHash)
 			res.WriteString(")")
 		}
 		res.WriteString("\n")
@@ -16,9 +16,19 @@ package blockinfo
 import (
 	"context"
 	"testing"
+	"time"
 
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
 	"github.com/wealdtech/ethdo/testutil"
 )
 
@@ -230,3 +240,199 @@ func TestBlockGraffiti(t *testing.T) {
 		})
 	}
 }
+
+// committeesClient is a client that only provides beacon committees.
+type committeesClient struct {
+	eth2client.BeaconCommitteesProvider
+}
+
+func (committeesClient) Name() string    { return "mock" }
+func (committeesClient) Address() string { return "mock" }
+func (committeesClient) IsActive() bool  { return true }
+func (committeesClient) IsSynced() bool  { return true }
+
+func TestOutputElectraBlockAttestations(t *testing.T) {
+	eth2Client := &committeesClient{
+		BeaconCommitteesProvider: mock.NewBeaconCommitteesProvider([]*apiv1.BeaconCommittee{
+			{Slot: 100, Index: 0, Validators: []spec.ValidatorIndex{1, 2, 3}},
+			{Slot: 100, Index: 1, Validators: []spec.ValidatorIndex{4, 5}},
+			{Slot: 100, Index: 2, Validators: []spec.ValidatorIndex{6, 7, 8, 9}},
+		}),
+	}
+
+	// Aggregate covering committees 0 and 2; committee 0 occupies bits 0-2 and committee 2 bits 3-6.
+	aggregationBits := bitfield.NewBitlist(7)
+	aggregationBits.SetBitAt(1, true)
+	aggregationBits.SetBitAt(3, true)
+	aggregationBits.SetBitAt(6, true)
+	committeeBits := bitfield.NewBitvector64()
+	committeeBits.SetBitAt(0, true)
+	committeeBits.SetBitAt(2, true)
+	attestations := []*electra.Attestation{
+		{
+			AggregationBits: aggregationBits,
+			CommitteeBits:   committeeBits,
+			Data: &spec.AttestationData{
+				Slot:            100,
+				BeaconBlockRoot: testutil.HexToRoot("0x0101010101010101010101010101010101010101010101010101010101010101"),
+				Source: &spec.Checkpoint{
+					Epoch: 2,
+					Root:  testutil.HexToRoot("0x0202020202020202020202020202020202020202020202020202020202020202"),
+				},
+				Target: &spec.Checkpoint{
+					Epoch: 3,
+					Root:  testutil.HexToRoot("0x0303030303030303030303030303030303030303030303030303030303030303"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		verbose      bool
+		attestations []*electra.Attestation
+		res          string
+	}{
+		{
+			name: "Empty",
+			res:  "Attestations: 0\n",
+		},
+		{
+			name:         "Single",
+			attestations: attestations,
+			res:          "Attestations: 1\n",
+		},
+		{
+			name:         "SingleVerbose",
+			verbose:      true,
+			attestations: attestations,
+			res:          "Attestations: 1\n  0:\n    Committee indices: 0 2\n    Attesters: 3/7\n    Aggregation bits: ✕✓✕✓✕✕✓\n    Attesting indices: 2 6 9\n    Slot: 100\n    Beacon block root: 0x0101010101010101010101010101010101010101010101010101010101010101\n    Source epoch: 2\n    Source root: 0x0202020202020202020202020202020202020202020202020202020202020202\n    Target epoch: 3\n    Target root: 0x0303030303030303030303030303030303030303030303030303030303030303\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := outputElectraBlockAttestations(context.Background(), eth2Client, test.verbose, test.attestations)
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestOutputElectraBlockExecutionRequests(t *testing.T) {
+	requests := &electra.ExecutionRequests{
+		Deposits: []*electra.DepositRequest{
+			{
+				Pubkey:                testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+				WithdrawalCredentials: testutil.HexToBytes("0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b"),
+				Amount:                spec.Gwei(32000000000),
+				Signature:             testutil.HexToSignature("0xb7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2"),
+				Index:                 5,
+			},
+		},
+		Withdrawals: []*electra.WithdrawalRequest{
+			{
+				SourceAddress:   bellatrix.ExecutionAddress{0x01},
+				ValidatorPubkey: testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+			},
+			{
+				SourceAddress:   bellatrix.ExecutionAddress{0x01},
+				ValidatorPubkey: testutil.HexToPubKey("0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"),
+				Amount:          spec.Gwei(1000000000),
+			},
+		},
+		Consolidations: []*electra.ConsolidationRequest{},
+	}
+
+	tests := []struct {
+		name     string
+		verbose  bool
+		requests *electra.ExecutionRequests
+		res      string
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name:     "Empty",
+			requests: &electra.ExecutionRequests{},
+			res:      "Deposit requests: 0\nWithdrawal requests: 0\nConsolidation requests: 0\n",
+		},
+		{
+			name:     "Good",
+			requests: requests,
+			res:      "Deposit requests: 1\nWithdrawal requests: 2\nConsolidation requests: 0\n",
+		},
+		{
+			name:     "GoodVerbose",
+			verbose:  true,
+			requests: requests,
+			res:      "Deposit requests: 1\n  0:\n    Public key: 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\n    Amount: 32 Ether\n    Withdrawal credentials: 0x00fad2a6bfb0e7f1f0f45460944fbd8dfa7f37da06a4d13b3983cc90bb46963b\n    Signature: 0xb7a757a4c506ac6ac5f2d23e065de7d00dc9f5a6a3f9610a8b60b65f166379139ae382c91ecbbf5c9fabc34b1cd2cf8f0211488d50d8754716d8e72e17c1a00b5d9b37cc73767946790ebe66cf9669abfc5c25c67e1e2d1c2e11429d149c25a2\n    Index: 5\nWithdrawal requests: 2\n  0:\n    Source address: 0x0100000000000000000000000000000000000000\n    Validator public key: 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\n    Amount: full exit\n  1:\n    Source address: 0x0100000000000000000000000000000000000000\n    Validator public key: 0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c\n    Amount: 1 Ether\nConsolidation requests: 0\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := outputElectraBlockExecutionRequests(context.Background(), test.verbose, test.requests)
+			require.NoError(t, err)
+			require.Equal(t, test.res, res)
+		})
+	}
+}
+
+func TestOutputElectraBlockText(t *testing.T) {
+	syncCommitteeBits := bitfield.NewBitvector512()
+	syncCommitteeBits.SetBitAt(0, true)
+	aggregationBits := bitfield.NewBitlist(4)
+	aggregationBits.SetBitAt(1, true)
+	committeeBits := bitfield.NewBitvector64()
+	committeeBits.SetBitAt(0, true)
+
+	signedBlock := &electra.SignedBeaconBlock{
+		Message: &electra.BeaconBlock{
+			Slot:          100,
+			ProposerIndex: 12,
+			Body: &electra.BeaconBlockBody{
+				ETH1Data: &spec.ETH1Data{
+					BlockHash: make([]byte, 32),
+				},
+				SyncAggregate: &altair.SyncAggregate{
+					SyncCommitteeBits: syncCommitteeBits,
+				},
+				Attestations: []*electra.Attestation{
+					{
+						AggregationBits: aggregationBits,
+						CommitteeBits:   committeeBits,
+						Data: &spec.AttestationData{
+							Slot:   99,
+							Source: &spec.Checkpoint{},
+							Target: &spec.Checkpoint{},
+						},
+					},
+				},
+				ExecutionPayload: &deneb.ExecutionPayload{
+					BlockNumber:   1234,
+					BaseFeePerGas: uint256.NewInt(7),
+					Transactions:  []bellatrix.Transaction{{0x01}},
+				},
+				ExecutionRequests: &electra.ExecutionRequests{
+					Consolidations: []*electra.ConsolidationRequest{{}},
+				},
+				BlobKZGCommitments: []deneb.KZGCommitment{{}, {}},
+			},
+		},
+	}
+
+	res, err := outputElectraBlockText(context.Background(), &dataOut{
+		genesisTime:   time.Unix(1606824023, 0),
+		slotDuration:  12 * time.Second,
+		slotsPerEpoch: 32,
+	}, signedBlock, nil)
+	require.NoError(t, err)
+	require.Contains(t, res, "Slot: 100\nProposing validator index: 12\nEpoch: 3\n")
+	require.Contains(t, res, "Sync aggregate: 1/512\nAttestations: 1\nAttester slashings: 0\nProposer slashings: 0\nDeposits: 0\nVoluntary exits: 0\nBLS to execution changes: 0\n")
+	require.Contains(t, res, "Execution block number: 1234\nTransactions: 1\nDeposit requests: 0\nWithdrawal requests: 0\nConsolidation requests: 1\nBlobs: 2\n")
+
+	_, err = outputElectraBlockText(context.Background(), &dataOut{}, nil, nil)
+	require.EqualError(t, err, "no block supplied")
+}
@@ -0,0 +1,96 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockreorgs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// seenWindow is the number of slots behind the head for which seen blocks are retained.
+const seenWindow = 64
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	jsonOutput bool
+
+	// Data access.
+	eth2Client     eth2client.Service
+	eventsProvider eth2client.EventsProvider
+	blocksProvider eth2client.SignedBeaconBlockProvider
+
+	// Processing.
+	mutex    sync.Mutex
+	head     *block
+	seen     map[phase0.Root]*block
+	reported map[string]phase0.Slot
+}
+
+// block is a block seen on the chain.
+type block struct {
+	Slot       phase0.Slot           `json:"slot"`
+	Root       phase0.Root           `json:"root"`
+	ParentRoot phase0.Root           `json:"parent_root"`
+	Proposer   phase0.ValidatorIndex `json:"proposer_index"`
+	Graffiti   string                `json:"graffiti"`
+}
+
+// reorg is a reorganization of the chain.
+type reorg struct {
+	Slot           phase0.Slot  `json:"slot"`
+	Depth          uint64       `json:"depth"`
+	OldHead        phase0.Root  `json:"old_head_block"`
+	NewHead        phase0.Root  `json:"new_head_block"`
+	CommonAncestor *phase0.Root `json:"common_ancestor,omitempty"`
+	DroppedBlocks  []*block     `json:"dropped_blocks"`
+	NewBlocks      []*block     `json:"new_blocks"`
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		seen:     make(map[phase0.Root]*block),
+		reported: make(map[string]phase0.Slot),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	c.jsonOutput = viper.GetBool("json")
+
+	return c, nil
+}
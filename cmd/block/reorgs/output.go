@@ -0,0 +1,84 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockreorgs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+func (c *command) output(ctx context.Context, res *reorg) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.jsonOutput {
+		return c.outputJSON(ctx, res)
+	}
+
+	return c.outputTxt(ctx, res)
+}
+
+func (*command) outputJSON(_ context.Context, res *reorg) (string, error) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (*command) outputTxt(_ context.Context, res *reorg) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Reorg of depth %d at slot %d: head changed from %#x to %#x\n", res.Depth, res.Slot, res.OldHead, res.NewHead))
+	if res.CommonAncestor == nil {
+		builder.WriteString(fmt.Sprintf("  Common ancestor not found within %d slots\n", seenWindow))
+	}
+	builder.WriteString("  Dropped blocks:\n")
+	for _, dropped := range res.DroppedBlocks {
+		builder.WriteString(blockTxt(dropped))
+	}
+	if len(res.NewBlocks) > 0 {
+		builder.WriteString("  New canonical blocks:\n")
+		for _, added := range res.NewBlocks {
+			builder.WriteString(blockTxt(added))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func blockTxt(info *block) string {
+	res := fmt.Sprintf("    Slot %d (%#x), proposer %d", info.Slot, info.Root, info.Proposer)
+	if info.Graffiti != "" {
+		res += fmt.Sprintf(", graffiti %q", info.Graffiti)
+	}
+
+	return res + "\n"
+}
+
+// graffitiString returns a printable version of graffiti.
+func graffitiString(graffiti []byte) string {
+	// Remove any trailing null characters.
+	graffiti = bytes.TrimRight(graffiti, "\u0000")
+	if !utf8.Valid(graffiti) {
+		return fmt.Sprintf("%#x", graffiti)
+	}
+
+	return string(graffiti)
+}
@@ -0,0 +1,285 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockreorgs
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.eventsProvider.Events(ctx, []string{"head", "chain_reorg"}, c.handleEvent); err != nil {
+		return errors.Wrap(err, "failed to subscribe to events")
+	}
+	<-ctx.Done()
+
+	return nil
+}
+
+// handleEvent handles events from the beacon node.
+func (c *command) handleEvent(event *apiv1.Event) {
+	ctx := context.Background()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var res *reorg
+	var err error
+	switch data := event.Data.(type) {
+	case *apiv1.HeadEvent:
+		res, err = c.handleHead(ctx, data.Block)
+	case *apiv1.ChainReorgEvent:
+		res, err = c.handleChainReorg(ctx, data)
+	}
+	if err != nil {
+		// Errors are not fatal, as the stream should continue with later events.
+		fmt.Fprintf(os.Stderr, "Failed to process %s event: %v\n", event.Topic, err)
+		return
+	}
+	if res == nil {
+		return
+	}
+
+	output, err := c.output(ctx, res)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to output reorg: %v\n", err)
+		return
+	}
+	if output != "" {
+		fmt.Println(output)
+	}
+}
+
+// handleHead handles a new head of the chain, returning a reorg if the head does not build on the previous head.
+func (c *command) handleHead(ctx context.Context, root phase0.Root) (*reorg, error) {
+	head, err := c.block(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if c.verbose && !c.quiet && !c.jsonOutput {
+		fmt.Printf("Head is block %d (%#x)\n", head.Slot, head.Root)
+	}
+
+	var res *reorg
+	if c.head != nil && c.head.Root != head.Root && c.head.Root != head.ParentRoot {
+		res, err = c.reorg(ctx, c.head.Root, head.Root, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+	c.setHead(head)
+
+	return res, nil
+}
+
+// handleChainReorg handles a reorg reported by the beacon node.
+func (c *command) handleChainReorg(ctx context.Context, event *apiv1.ChainReorgEvent) (*reorg, error) {
+	res, err := c.reorg(ctx, event.OldHeadBlock, event.NewHeadBlock, event.Depth)
+	if err != nil {
+		return nil, err
+	}
+	head, err := c.block(ctx, event.NewHeadBlock)
+	if err != nil {
+		return nil, err
+	}
+	c.setHead(head)
+
+	return res, nil
+}
+
+// reorg calculates the blocks dropped and added when the head moves from the old to the new root.
+// This returns nil if no blocks were dropped, or if the reorg has already been reported.
+func (c *command) reorg(ctx context.Context, oldRoot phase0.Root, newRoot phase0.Root, depth uint64) (*reorg, error) {
+	key := fmt.Sprintf("%#x:%#x", oldRoot, newRoot)
+	if _, reported := c.reported[key]; reported {
+		return nil, nil
+	}
+
+	oldHead, err := c.block(ctx, oldRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain old head")
+	}
+	newHead, err := c.block(ctx, newRoot)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain new head")
+	}
+	minSlot := max(oldHead.Slot, newHead.Slot)
+	if minSlot > seenWindow {
+		minSlot -= seenWindow
+	} else {
+		minSlot = 0
+	}
+
+	// Walk back along the old chain through the blocks that have been seen, to the limit of the window.
+	oldChain := make([]*block, 0)
+	oldIndices := make(map[phase0.Root]int)
+	for current := oldHead; ; {
+		oldIndices[current.Root] = len(oldChain)
+		oldChain = append(oldChain, current)
+		parent, exists := c.seen[current.ParentRoot]
+		if !exists || current.Slot <= minSlot {
+			break
+		}
+		current = parent
+	}
+	earliestSlot := oldChain[len(oldChain)-1].Slot
+
+	// Walk back along the new chain until it meets the old chain.
+	newChain := make([]*block, 0)
+	dropped := oldChain
+	var ancestor *block
+	for current := newHead; ; {
+		if index, exists := oldIndices[current.Root]; exists {
+			ancestor = current
+			dropped = oldChain[:index]
+			break
+		}
+		newChain = append(newChain, current)
+		if current.Slot <= earliestSlot || current.ParentRoot.IsZero() {
+			// Cannot meet the old chain.
+			break
+		}
+		current, err = c.block(ctx, current.ParentRoot)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain new chain")
+		}
+	}
+	if len(dropped) == 0 {
+		// The new head builds on the old head.
+		return nil, nil
+	}
+
+	res := &reorg{
+		Slot:          newHead.Slot,
+		Depth:         depth,
+		OldHead:       oldRoot,
+		NewHead:       newRoot,
+		DroppedBlocks: make([]*block, 0, len(dropped)),
+		NewBlocks:     make([]*block, 0, len(newChain)),
+	}
+	if ancestor != nil {
+		res.Depth = uint64(oldHead.Slot - ancestor.Slot)
+		res.CommonAncestor = &ancestor.Root
+	}
+	// Chains are walked backwards; report them in slot order.
+	for i := len(dropped) - 1; i >= 0; i-- {
+		res.DroppedBlocks = append(res.DroppedBlocks, dropped[i])
+	}
+	for i := len(newChain) - 1; i >= 0; i-- {
+		res.NewBlocks = append(res.NewBlocks, newChain[i])
+	}
+	c.reported[key] = newHead.Slot
+
+	return res, nil
+}
+
+// setHead sets the head of the chain, and removes blocks and reorgs outside of the window from those seen.
+func (c *command) setHead(head *block) {
+	c.head = head
+	for root, seen := range c.seen {
+		if seen.Slot+seenWindow < head.Slot {
+			delete(c.seen, root)
+		}
+	}
+	for key, slot := range c.reported {
+		if slot+seenWindow < head.Slot {
+			delete(c.reported, key)
+		}
+	}
+}
+
+// block returns the block with the given root, fetching it if it has not been seen.
+func (c *command) block(ctx context.Context, root phase0.Root) (*block, error) {
+	if seen, exists := c.seen[root]; exists {
+		return seen, nil
+	}
+
+	// Events are handled one at a time, so ensure that a slow fetch does not hold up the stream.
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+		Block: fmt.Sprintf("%#x", root),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain block %#x", root))
+	}
+	signedBlock := blockResponse.Data
+
+	slot, err := signedBlock.Slot()
+	if err != nil {
+		return nil, err
+	}
+	parentRoot, err := signedBlock.ParentRoot()
+	if err != nil {
+		return nil, err
+	}
+	proposer, err := signedBlock.ProposerIndex()
+	if err != nil {
+		return nil, err
+	}
+	graffiti, err := signedBlock.Graffiti()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &block{
+		Slot:       slot,
+		Root:       root,
+		ParentRoot: parentRoot,
+		Proposer:   proposer,
+		Graffiti:   graffitiString(graffiti[:]),
+	}
+	c.seen[root] = res
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	var isProvider bool
+	c.eventsProvider, isProvider = c.eth2Client.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide events")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon block information")
+	}
+
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blockreorgs
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorgs(t *testing.T) {
+	ctx := context.Background()
+
+	// Chain is genesis <- a <- b <- d, with c a fork from a.
+	genesis := &block{Slot: 10, Root: phase0.Root{0x10}}
+	a := &block{Slot: 11, Root: phase0.Root{0x11}, ParentRoot: genesis.Root, Proposer: 1}
+	b := &block{Slot: 12, Root: phase0.Root{0x12}, ParentRoot: a.Root, Proposer: 2, Graffiti: "b"}
+	c := &block{Slot: 13, Root: phase0.Root{0x13}, ParentRoot: a.Root, Proposer: 3}
+	d := &block{Slot: 14, Root: phase0.Root{0x14}, ParentRoot: b.Root, Proposer: 4}
+
+	cmd := &command{
+		seen: map[phase0.Root]*block{
+			genesis.Root: genesis,
+			a.Root:       a,
+			b.Root:       b,
+			c.Root:       c,
+			d.Root:       d,
+		},
+		reported: make(map[string]phase0.Slot),
+	}
+
+	// Heads that build on each other are not reorgs.
+	for _, head := range []*block{a, b} {
+		res, err := cmd.handleHead(ctx, head.Root)
+		require.NoError(t, err)
+		require.Nil(t, res)
+	}
+
+	// New head c does not build on b.
+	res, err := cmd.handleHead(ctx, c.Root)
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, phase0.Slot(13), res.Slot)
+	require.Equal(t, uint64(1), res.Depth)
+	require.Equal(t, b.Root, res.OldHead)
+	require.Equal(t, c.Root, res.NewHead)
+	require.Equal(t, &a.Root, res.CommonAncestor)
+	require.Equal(t, []*block{b}, res.DroppedBlocks)
+	require.Equal(t, []*block{c}, res.NewBlocks)
+
+	// The same reorg reported by the beacon node is not reported again.
+	res, err = cmd.handleChainReorg(ctx, &apiv1.ChainReorgEvent{
+		Slot:         13,
+		Depth:        1,
+		OldHeadBlock: b.Root,
+		NewHeadBlock: c.Root,
+	})
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	// Reorg reported by the beacon node back to d, which builds on b.
+	res, err = cmd.handleChainReorg(ctx, &apiv1.ChainReorgEvent{
+		Slot:         14,
+		Depth:        2,
+		OldHeadBlock: c.Root,
+		NewHeadBlock: d.Root,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	require.Equal(t, uint64(2), res.Depth)
+	require.Equal(t, []*block{c}, res.DroppedBlocks)
+	require.Equal(t, []*block{b, d}, res.NewBlocks)
+	require.Equal(t, d, cmd.head)
+
+	// Head event for d following the chain reorg event is not a reorg.
+	res, err = cmd.handleHead(ctx, d.Root)
+	require.NoError(t, err)
+	require.Nil(t, res)
+	require.Len(t, cmd.reported, 2)
+
+	// Reported reorgs are removed once they are outside of the window.
+	cmd.setHead(&block{Slot: 14 + seenWindow, Root: phase0.Root{0x20}})
+	require.Len(t, cmd.reported, 1)
+	cmd.setHead(&block{Slot: 15 + seenWindow, Root: phase0.Root{0x21}})
+	require.Empty(t, cmd.reported)
+}
+
+func TestOutputTxt(t *testing.T) {
+	ancestor := phase0.Root{0x01}
+	res := &reorg{
+		Slot:           13,
+		Depth:          1,
+		OldHead:        phase0.Root{0x02},
+		NewHead:        phase0.Root{0x03},
+		CommonAncestor: &ancestor,
+		DroppedBlocks: []*block{
+			{Slot: 12, Root: phase0.Root{0x02}, Proposer: 2, Graffiti: "dropped"},
+		},
+		NewBlocks: []*block{
+			{Slot: 13, Root: phase0.Root{0x03}, Proposer: 3},
+		},
+	}
+
+	output, err := (&command{}).output(context.Background(), res)
+	require.NoError(t, err)
+	require.Equal(t, `Reorg of depth 1 at slot 13: head changed from 0x0200000000000000000000000000000000000000000000000000000000000000 to 0x0300000000000000000000000000000000000000000000000000000000000000
+  Dropped blocks:
+    Slot 12 (0x0200000000000000000000000000000000000000000000000000000000000000), proposer 2, graffiti "dropped"
+  New canonical blocks:
+    Slot 13 (0x0300000000000000000000000000000000000000000000000000000000000000), proposer 3`, output)
+}
@@ -28,6 +28,12 @@ var blockAnalyzeCmd = &cobra.Command{
 
     ethdo block analyze --blockid=12345
 
+A report of block packing over a range of slots, with statistics for the network, each proposer and each client (as identified by block graffiti), can be generated with --from-slot and --to-slot.  For example:
+
+    ethdo block analyze --from-slot=12300 --to-slot=12345
+
+The report can be output as CSV with --csv, or as JSON with --json.
+
 In quiet mode this will return 0 if the block information is present and not skipped, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := blockanalyze.Run(cmd)
@@ -49,6 +55,9 @@ func init() {
 	blockFlags(blockAnalyzeCmd)
 	blockAnalyzeCmd.Flags().String("blockid", "head", "the ID of the block to fetch")
 	blockAnalyzeCmd.Flags().Bool("stream", false, "continually stream blocks as they arrive")
+	blockAnalyzeCmd.Flags().String("from-slot", "", "the first slot for which to obtain a block packing report")
+	blockAnalyzeCmd.Flags().String("to-slot", "", "the last slot for which to obtain a block packing report")
+	blockAnalyzeCmd.Flags().Bool("csv", false, "output the block packing report as CSV")
 }
 
 func blockAnalyzeBindings(cmd *cobra.Command) {
@@ -58,4 +67,13 @@ func blockAnalyzeBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("stream", cmd.Flags().Lookup("stream")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("from-slot", cmd.Flags().Lookup("from-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-slot", cmd.Flags().Lookup("to-slot")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
 }
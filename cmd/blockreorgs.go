@@ -0,0 +1,49 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	blockreorgs "github.com/wealdtech/ethdo/cmd/block/reorgs"
+)
+
+var blockReorgsCmd = &cobra.Command{
+	Use:   "reorgs",
+	Short: "Stream reorgs of the chain",
+	Long: `Stream reorgs of the chain as they occur.  For example:
+
+    ethdo block reorgs
+
+Head and chain reorg events from the beacon node are followed, and each time a previously seen head is reorged out the depth of
+the reorg, the dropped blocks and the new canonical blocks are reported along with the proposer and graffiti of each block.
+Output with --json is a single JSON object per line, suitable for alerting.  With --verbose each new head is also reported.
+The command runs until interrupted.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := blockreorgs.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	blockCmd.AddCommand(blockReorgsCmd)
+	blockFlags(blockReorgsCmd)
+}
@@ -35,6 +35,9 @@ type command struct {
 	connection               string
 	allowInsecureConnections bool
 
+	// Chain data cache.
+	cacheDir string
+
 	// Input.
 	xepoch  string
 	xperiod string
@@ -81,6 +84,7 @@ func newCommand(_ context.Context) (*command, error) {
 
 	c.connection = viper.GetString("connection")
 	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+	c.cacheDir = viper.GetString("cache-dir")
 
 	return c, nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	diskchaincache "github.com/wealdtech/ethdo/services/chaincache/disk"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 )
@@ -97,6 +98,9 @@ func (c *command) process(ctx context.Context) error {
 	case spec.DataVersionDeneb:
 		c.incumbent = state.Deneb.ETH1Data
 		c.eth1DataVotes = state.Deneb.ETH1DataVotes
+	case spec.DataVersionElectra:
+		c.incumbent = state.Electra.ETH1Data
+		c.eth1DataVotes = state.Electra.ETH1DataVotes
 	default:
 		return fmt.Errorf("unhandled beacon state version %v", state.Version)
 	}
@@ -146,6 +150,19 @@ func (c *command) setup(ctx context.Context) error {
 	if !isProvider {
 		return errors.New("connection does not provide beacon state")
 	}
+
+	if c.cacheDir != "" {
+		// Use the on-disk cache for historical chain data.
+		cache, err := diskchaincache.New(ctx,
+			diskchaincache.WithBaseDir(c.cacheDir),
+			diskchaincache.WithClient(c.eth2Client),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to set up chain data cache")
+		}
+		c.beaconStateProvider = cache
+	}
+
 	specProvider, isProvider := c.eth2Client.(eth2client.SpecProvider)
 	if !isProvider {
 		return errors.New("connection does not provide spec information")
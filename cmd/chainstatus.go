@@ -1,4 +1,4 @@
-// Copyright © 2020, 2021 Weald Technology Trading.
+// Copyright © 2020 - 2024 Weald Technology Trading.
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -25,6 +25,7 @@ import (
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
@@ -39,6 +40,8 @@ var chainStatusCmd = &cobra.Command{
 
     ethdo chain status
 
+If --quorum is supplied the finality information is cross-checked between the beacon nodes in --connection, and any disagreements reported.
+
 In quiet mode this will return 0 if the chain status can be obtained, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
@@ -57,13 +60,16 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 		)
 		errCheck(err, "Failed to configure chaintime service")
 
-		finalityProvider, isProvider := eth2Client.(eth2client.FinalityProvider)
-		assert(isProvider, "beacon node does not provide finality; cannot report on chain status")
-		finalityResponse, err := finalityProvider.Finality(ctx, &api.FinalityOpts{
-			State: "head",
-		})
-		errCheck(err, "Failed to obtain finality information")
-		finality := finalityResponse.Data
+		var finality *apiv1.Finality
+		if quorum := viper.GetInt("quorum"); quorum > 0 {
+			var disagreements []*util.QuorumDisagreement
+			finality, disagreements, err = util.QuorumFetch(ctx, quorumClients(ctx), quorum, fetchFinality, summariseFinality)
+			reportDisagreements(disagreements)
+			errCheck(err, "Failed to obtain finality information")
+		} else {
+			finality, err = fetchFinality(ctx, eth2Client)
+			errCheck(err, "Failed to obtain finality information")
+		}
 
 		slot := chainTime.CurrentSlot()
 
@@ -195,6 +201,32 @@ In quiet mode this will return 0 if the chain status can be obtained, otherwise
 	},
 }
 
+// fetchFinality fetches the finality information from a beacon node.
+func fetchFinality(ctx context.Context, eth2Client eth2client.Service) (*apiv1.Finality, error) {
+	finalityProvider, isProvider := eth2Client.(eth2client.FinalityProvider)
+	if !isProvider {
+		return nil, errors.New("beacon node does not provide finality; cannot report on chain status")
+	}
+	finalityResponse, err := finalityProvider.Finality(ctx, &api.FinalityOpts{
+		State: "head",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return finalityResponse.Data, nil
+}
+
+// summariseFinality summarises finality information for comparison between beacon nodes.
+func summariseFinality(finality *apiv1.Finality) string {
+	return fmt.Sprintf("justified %d (%#x), finalized %d (%#x)",
+		finality.Justified.Epoch,
+		finality.Justified.Root,
+		finality.Finalized.Epoch,
+		finality.Finalized.Root,
+	)
+}
+
 func init() {
 	chainCmd.AddCommand(chainStatusCmd)
 	chainFlags(chainStatusCmd)
@@ -35,6 +35,7 @@ var (
 	depositVerifyData              string
 	depositVerifyWithdrawalPubKey  string
 	depositVerifyWithdrawalAddress string
+	depositVerifyCompounding       bool
 	depositVerifyValidatorPubKey   string
 	depositVerifyDepositAmount     string
 	depositVerifyForkVersion       string
@@ -47,7 +48,7 @@ var depositVerifyCmd = &cobra.Command{
 
     ethdo deposit verify --data=depositdata.json --withdrawalaccount=primary/current --value="32 Ether"
 
-The deposit data is compared to the supplied withdrawal account/public key, validator public key, and value to ensure they match.
+The deposit data is compared to the supplied withdrawal account/public key, validator public key, and value to ensure they match.  If the deposit uses compounding (0x02) withdrawal credentials then --compounding should be supplied alongside --withdrawaladdress.
 
 In quiet mode this will return 0 if the data is verified correctly, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
@@ -83,6 +84,7 @@ In quiet mode this will return 0 if the data is verified correctly, otherwise 1.
 			}
 		}
 
+		assert(!depositVerifyCompounding || depositVerifyWithdrawalAddress != "", "--compounding requires --withdrawaladdress")
 		var withdrawalCredentials []byte
 		if depositVerifyWithdrawalPubKey != "" {
 			withdrawalPubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(depositVerifyWithdrawalPubKey, "0x"))
@@ -97,7 +99,11 @@ In quiet mode this will return 0 if the data is verified correctly, otherwise 1.
 			errCheck(err, "Invalid withdrawal address")
 			assert(len(withdrawalAddressBytes) == 20, "address should be 20 bytes")
 			withdrawalCredentials = make([]byte, 32)
-			withdrawalCredentials[0] = 0x01 // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+			if depositVerifyCompounding {
+				withdrawalCredentials[0] = 0x02 // COMPOUNDING_WITHDRAWAL_PREFIX
+			} else {
+				withdrawalCredentials[0] = 0x01 // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+			}
 			copy(withdrawalCredentials[12:], withdrawalAddressBytes)
 		}
 		outputIf(viper.GetBool("debug"), fmt.Sprintf("Withdrawal credentials are %#x", withdrawalCredentials))
@@ -334,6 +340,7 @@ func init() {
 	depositVerifyCmd.Flags().StringVar(&depositVerifyData, "data", "", "JSON data, or path to JSON data")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyWithdrawalPubKey, "withdrawalpubkey", "", "Public key of the account to which the validator funds will be withdrawn")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyWithdrawalAddress, "withdrawaladdress", "", "Ethereum 1 address of the account to which the validator funds will be withdrawn")
+	depositVerifyCmd.Flags().BoolVar(&depositVerifyCompounding, "compounding", false, "Withdrawal address is expected to have compounding (0x02) withdrawal credentials")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyDepositAmount, "depositvalue", "32 Ether", "Value of the amount to be deposited")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyValidatorPubKey, "validatorpubkey", "", "Public key(s) of the account(s) that will be carrying out validation")
 	depositVerifyCmd.Flags().StringVar(&depositVerifyForkVersion, "forkversion", "0x00000000", "Fork version of the chain of the deposit")
@@ -35,6 +35,9 @@ type command struct {
 	connection               string
 	allowInsecureConnections bool
 
+	// Chain data cache.
+	cacheDir string
+
 	// Operation.
 	epoch         string
 	validatorsStr []string
@@ -126,6 +129,7 @@ func newCommand(_ context.Context) (*command, error) {
 
 	c.connection = viper.GetString("connection")
 	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+	c.cacheDir = viper.GetString("cache-dir")
 
 	c.epoch = viper.GetString("epoch")
 	c.stream = viper.GetBool("stream")
@@ -25,6 +25,7 @@ import (
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	diskchaincache "github.com/wealdtech/ethdo/services/chaincache/disk"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 )
@@ -215,11 +216,37 @@ func (c *command) processSlots(ctx context.Context,
 	sourceTimelys := make(map[phase0.ValidatorIndex]struct{})
 	targetCorrects := make(map[phase0.ValidatorIndex]struct{})
 	targetTimelys := make(map[phase0.ValidatorIndex]struct{})
-	allCommittees := make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
 	participations := make(map[phase0.ValidatorIndex]*attestingValidator)
 
 	// Need a cache of beacon block headers to reduce lookup times.
 	headersCache := util.NewBeaconBlockHeaderCache(c.beaconBlockHeadersProvider)
+	// Need a cache of beacon committees to decode aggregation bits.
+	committeesCache := util.NewBeaconCommitteesCache(c.beaconCommitteesProvider)
+
+	for slot := c.chainTime.FirstSlotOfEpoch(c.summary.Epoch); slot < c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1); slot++ {
+		slotCommittees, err := committeesCache.Fetch(ctx, slot)
+		if err != nil {
+			return nil, nil, nil, nil, nil, nil, nil, errors.Wrap(err, fmt.Sprintf("failed to obtain committees for slot %d", slot))
+		}
+		for committeeIndex, committee := range slotCommittees {
+			for _, index := range committee {
+				if len(c.validators) > 0 {
+					if _, exists := c.validators[index]; !exists {
+						// Not one of our validators.
+						continue
+					}
+				}
+
+				if _, exists := participations[index]; !exists {
+					participations[index] = &attestingValidator{
+						Validator: index,
+						Slot:      slot,
+						Committee: committeeIndex,
+					}
+				}
+			}
+		}
+	}
 
 	for slot := firstSlot; slot <= lastSlot; slot++ {
 		block, err := c.fetchBlock(ctx, fmt.Sprintf("%d", slot))
@@ -239,47 +266,24 @@ func (c *command) processSlots(ctx context.Context,
 			return nil, nil, nil, nil, nil, nil, nil, err
 		}
 		for _, attestation := range attestations {
-			if attestation.Data.Slot < c.chainTime.FirstSlotOfEpoch(c.summary.Epoch) || attestation.Data.Slot >= c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1) {
+			data, err := attestation.Data()
+			if err != nil {
+				return nil, nil, nil, nil, nil, nil, nil, err
+			}
+			if data.Slot < c.chainTime.FirstSlotOfEpoch(c.summary.Epoch) || data.Slot >= c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1) {
 				// Outside of this epoch's range.
 				continue
 			}
-			slotCommittees, exists := allCommittees[attestation.Data.Slot]
-			if !exists {
-				response, err := c.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
-					State: fmt.Sprintf("%d", attestation.Data.Slot),
-				})
-				if err != nil {
-					return nil, nil, nil, nil, nil, nil, nil, errors.Wrap(err, fmt.Sprintf("failed to obtain committees for slot %d", attestation.Data.Slot))
-				}
-				for _, beaconCommittee := range response.Data {
-					if _, exists := allCommittees[beaconCommittee.Slot]; !exists {
-						allCommittees[beaconCommittee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
-					}
-
-					allCommittees[beaconCommittee.Slot][beaconCommittee.Index] = beaconCommittee.Validators
-
-					for _, index := range beaconCommittee.Validators {
-						if len(c.validators) > 0 {
-							if _, exists := c.validators[index]; !exists {
-								// Not one of our validators.
-								continue
-							}
-						}
-
-						if _, exists := participations[index]; !exists {
-							participations[index] = &attestingValidator{
-								Validator: index,
-								Slot:      beaconCommittee.Slot,
-								Committee: beaconCommittee.Index,
-							}
-						}
-					}
-				}
-				slotCommittees = allCommittees[attestation.Data.Slot]
+			slotCommittees, err := committeesCache.Fetch(ctx, data.Slot)
+			if err != nil {
+				return nil, nil, nil, nil, nil, nil, nil, errors.Wrap(err, fmt.Sprintf("failed to obtain committees for slot %d", data.Slot))
+			}
+			committeeBits, err := util.AttestationCommitteeBits(ctx, committeesCache, attestation)
+			if err != nil {
+				return nil, nil, nil, nil, nil, nil, nil, err
 			}
-			committee := slotCommittees[attestation.Data.Index]
 
-			inclusionDistance := slot - attestation.Data.Slot
+			inclusionDistance := slot - data.Slot
 
 			head, err := util.AttestationHead(ctx, headersCache, attestation)
 			if err != nil {
@@ -298,40 +302,43 @@ func (c *command) processSlots(ctx context.Context,
 				return nil, nil, nil, nil, nil, nil, nil, err
 			}
 
-			for i := range attestation.AggregationBits.Len() {
-				if attestation.AggregationBits.BitAt(i) {
-					validatorIndex := committee[int(i)]
-					if len(c.validators) > 0 {
-						if _, exists := c.validators[validatorIndex]; !exists {
-							// Not one of our validators.
-							continue
+			for committeeIndex, aggregationBits := range committeeBits {
+				committee := slotCommittees[committeeIndex]
+				for i := range aggregationBits.Len() {
+					if aggregationBits.BitAt(i) {
+						validatorIndex := committee[int(i)]
+						if len(c.validators) > 0 {
+							if _, exists := c.validators[validatorIndex]; !exists {
+								// Not one of our validators.
+								continue
+							}
 						}
-					}
 
-					// Only set the information from the first attestation we find for this validator.
-					if participations[validatorIndex].InclusionSlot == 0 {
-						participations[validatorIndex].HeadVote = &attestation.Data.BeaconBlockRoot
-						participations[validatorIndex].Head = &head
-						participations[validatorIndex].TargetVote = &attestation.Data.Target.Root
-						participations[validatorIndex].Target = &target
-						participations[validatorIndex].InclusionSlot = slot
-					}
+						// Only set the information from the first attestation we find for this validator.
+						if participations[validatorIndex].InclusionSlot == 0 {
+							participations[validatorIndex].HeadVote = &data.BeaconBlockRoot
+							participations[validatorIndex].Head = &head
+							participations[validatorIndex].TargetVote = &data.Target.Root
+							participations[validatorIndex].Target = &target
+							participations[validatorIndex].InclusionSlot = slot
+						}
 
-					votes[validatorIndex] = struct{}{}
-					if _, exists := headCorrects[validatorIndex]; !exists && headCorrect {
-						headCorrects[validatorIndex] = struct{}{}
-					}
-					if _, exists := headTimelys[validatorIndex]; !exists && headCorrect && inclusionDistance == 1 {
-						headTimelys[validatorIndex] = struct{}{}
-					}
-					if _, exists := sourceTimelys[validatorIndex]; !exists && inclusionDistance <= 5 {
-						sourceTimelys[validatorIndex] = struct{}{}
-					}
-					if _, exists := targetCorrects[validatorIndex]; !exists && targetCorrect {
-						targetCorrects[validatorIndex] = struct{}{}
-					}
-					if _, exists := targetTimelys[validatorIndex]; !exists && targetCorrect && inclusionDistance <= 32 {
-						targetTimelys[validatorIndex] = struct{}{}
+						votes[validatorIndex] = struct{}{}
+						if _, exists := headCorrects[validatorIndex]; !exists && headCorrect {
+							headCorrects[validatorIndex] = struct{}{}
+						}
+						if _, exists := headTimelys[validatorIndex]; !exists && headCorrect && inclusionDistance == 1 {
+							headTimelys[validatorIndex] = struct{}{}
+						}
+						if _, exists := sourceTimelys[validatorIndex]; !exists && inclusionDistance <= 5 {
+							sourceTimelys[validatorIndex] = struct{}{}
+						}
+						if _, exists := targetCorrects[validatorIndex]; !exists && targetCorrect {
+							targetCorrects[validatorIndex] = struct{}{}
+						}
+						if _, exists := targetTimelys[validatorIndex]; !exists && targetCorrect && inclusionDistance <= 32 {
+							targetTimelys[validatorIndex] = struct{}{}
+						}
 					}
 				}
 			}
@@ -484,6 +491,21 @@ func (c *command) setup(ctx context.Context) error {
 		return errors.New("connection does not provide beacon block headers")
 	}
 
+	if c.cacheDir != "" {
+		// Use the on-disk cache for historical chain data.
+		cache, err := diskchaincache.New(ctx,
+			diskchaincache.WithBaseDir(c.cacheDir),
+			diskchaincache.WithClient(c.eth2Client),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to set up chain data cache")
+		}
+		c.blocksProvider = cache
+		c.validatorsProvider = cache
+		c.beaconCommitteesProvider = cache
+		c.beaconBlockHeadersProvider = cache
+	}
+
 	return nil
 }
 
@@ -501,6 +523,8 @@ func (c *command) processBlobs(ctx context.Context) error {
 			// No blobs in these forks.
 		case spec.DataVersionDeneb:
 			c.summary.Blobs += len(block.Deneb.Message.Body.BlobKZGCommitments)
+		case spec.DataVersionElectra:
+			c.summary.Blobs += len(block.Electra.Message.Body.BlobKZGCommitments)
 		default:
 			return fmt.Errorf("unhandled block version %v", block.Version)
 		}
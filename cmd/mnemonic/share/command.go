@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicshare
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	json    bool
+
+	// Input.
+	mnemonic     string
+	participants uint32
+	threshold    uint32
+
+	// Output.
+	shares []*util.MnemonicShare
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:        viper.GetBool("quiet"),
+		verbose:      viper.GetBool("verbose"),
+		debug:        viper.GetBool("debug"),
+		json:         viper.GetBool("json"),
+		mnemonic:     viper.GetString("mnemonic"),
+		participants: viper.GetUint32("participants"),
+		threshold:    viper.GetUint32("threshold"),
+	}
+
+	if c.mnemonic == "" {
+		return nil, errors.New("mnemonic is required")
+	}
+	if c.participants == 0 {
+		return nil, errors.New("participants is required")
+	}
+	if c.threshold == 0 {
+		return nil, errors.New("threshold is required")
+	}
+
+	return c, nil
+}
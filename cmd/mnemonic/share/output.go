@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mnemonicshare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type shareJSON struct {
+	Index     uint8  `json:"index"`
+	Threshold uint8  `json:"threshold"`
+	Words     string `json:"words"`
+}
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.json {
+		shares := make([]*shareJSON, len(c.shares))
+		for i, share := range c.shares {
+			shares[i] = &shareJSON{
+				Index:     share.Index,
+				Threshold: share.Threshold,
+				Words:     share.Words,
+			}
+		}
+		data, err := json.Marshal(shares)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal JSON")
+		}
+
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+	for i, share := range c.shares {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		builder.WriteString(fmt.Sprintf("Share %d: %s", i+1, share.Words))
+	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("\nAny %d of the %d shares can recover the mnemonic", c.threshold, c.participants))
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	mnemonicrecover "github.com/wealdtech/ethdo/cmd/mnemonic/recover"
+)
+
+var mnemonicRecoverCmd = &cobra.Command{
+	Use:   "recover",
+	Short: "Recover a mnemonic from Shamir secret shares",
+	Long: `Recover a mnemonic from shares created by "ethdo mnemonic share".  For example:
+
+    ethdo mnemonic recover --share="..." --share="..." --share="..."
+
+At least the threshold number of shares must be supplied.  Words in shares can be abbreviated to their first four letters.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := mnemonicrecover.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	mnemonicCmd.AddCommand(mnemonicRecoverCmd)
+	mnemonicFlags(mnemonicRecoverCmd)
+	mnemonicRecoverCmd.Flags().StringSlice("share", nil, "a share of the mnemonic (supply once for each share)")
+}
+
+func mnemonicRecoverBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("share", cmd.Flags().Lookup("share")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	mnemonicshare "github.com/wealdtech/ethdo/cmd/mnemonic/share"
+)
+
+var mnemonicShareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "Split a mnemonic in to shares using Shamir secret sharing",
+	Long: `Split a mnemonic in to shares using Shamir secret sharing.  For example:
+
+    ethdo mnemonic share --mnemonic="..." --participants=5 --threshold=3
+
+Each share is a list of words that can be written down, and contains a checksum to detect transcription errors.  Any threshold of the shares can recover the mnemonic with "ethdo mnemonic recover".  Shares do not include any passphrase used with the mnemonic, which must be backed up separately.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := mnemonicshare.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	mnemonicCmd.AddCommand(mnemonicShareCmd)
+	mnemonicFlags(mnemonicShareCmd)
+	mnemonicShareCmd.Flags().Uint32("participants", 0, "Number of shares to create")
+	mnemonicShareCmd.Flags().Uint32("threshold", 0, "Number of shares required to recover the mnemonic")
+}
+
+func mnemonicShareBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("participants", cmd.Flags().Lookup("participants")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("threshold", cmd.Flags().Lookup("threshold")); err != nil {
+		panic(err)
+	}
+}
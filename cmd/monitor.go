@@ -0,0 +1,58 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/cmd/monitor"
+)
+
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Monitor validators and expose Prometheus metrics",
+	Long: `Monitor validators and expose Prometheus metrics.  For example:
+
+    ethdo monitor --validators=1,2,3 --metrics-address=localhost:9099
+
+Each epoch the participation, missed duties, balance changes and inclusion delay of the validators are
+calculated and exposed on the /metrics endpoint of the given address.  The command runs until interrupted.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := monitor.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(monitorCmd)
+	monitorCmd.Flags().StringSlice("validators", nil, "the list of validators to monitor")
+	monitorCmd.Flags().String("metrics-address", "localhost:9099", "the address on which to serve metrics")
+}
+
+func monitorBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("metrics-address", cmd.Flags().Lookup("metrics-address")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Operation.
+	metricsAddress string
+
+	// Data access.
+	eth2Client     eth2client.Service
+	chainTime      chaintime.Service
+	eventsProvider eth2client.EventsProvider
+	summarizer     *validatorsummary.Summarizer
+
+	// Processing.
+	metrics      *metrics
+	epochs       chan phase0.Epoch
+	lastEpoch    phase0.Epoch
+	lastEpochSet bool
+	lastBalances map[phase0.ValidatorIndex]phase0.Gwei
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:        viper.GetBool("quiet"),
+		verbose:      viper.GetBool("verbose"),
+		debug:        viper.GetBool("debug"),
+		epochs:       make(chan phase0.Epoch, 4),
+		lastBalances: make(map[phase0.ValidatorIndex]phase0.Gwei),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+
+	if len(viper.GetStringSlice("validators")) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	c.metricsAddress = viper.GetString("metrics-address")
+	if c.metricsAddress == "" {
+		return nil, errors.New("metrics address is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,220 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+)
+
+const metricsNamespace = "ethdo_monitor"
+
+type metrics struct {
+	registry *prometheus.Registry
+
+	headSlot                    prometheus.Gauge
+	finalizedEpoch              prometheus.Gauge
+	processedEpoch              prometheus.Gauge
+	participationRate           prometheus.Gauge
+	inclusionDelay              prometheus.Histogram
+	attestationIncluded         *prometheus.GaugeVec
+	attestationCorrectHead      *prometheus.GaugeVec
+	attestationCorrectTarget    *prometheus.GaugeVec
+	attestationTimelySource     *prometheus.GaugeVec
+	missedAttestations          *prometheus.CounterVec
+	proposals                   *prometheus.CounterVec
+	missedProposals             *prometheus.CounterVec
+	syncCommitteeMessages       *prometheus.CounterVec
+	missedSyncCommitteeMessages *prometheus.CounterVec
+	balance                     *prometheus.GaugeVec
+	balanceDelta                *prometheus.GaugeVec
+}
+
+func newMetrics() (*metrics, error) {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		headSlot: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "head_slot",
+			Help:      "The slot of the current head of the chain.",
+		}),
+		finalizedEpoch: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "finalized_epoch",
+			Help:      "The latest finalized epoch.",
+		}),
+		processedEpoch: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "processed_epoch",
+			Help:      "The latest epoch for which validator metrics have been calculated.",
+		}),
+		participationRate: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "participation_rate",
+			Help:      "The proportion of active validators whose attestations were included in the processed epoch.",
+		}),
+		inclusionDelay: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "inclusion_delay_slots",
+			Help:      "The inclusion delay of validators' attestations.",
+			Buckets:   []float64{1, 2, 3, 4, 5, 8, 16, 32},
+		}),
+		attestationIncluded: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "attestation_included",
+			Help:      "1 if the validator's attestation was included in the processed epoch, otherwise 0.",
+		}, []string{"validator"}),
+		attestationCorrectHead: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "attestation_correct_head",
+			Help:      "1 if the validator's attestation had the correct head in the processed epoch, otherwise 0.",
+		}, []string{"validator"}),
+		attestationCorrectTarget: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "attestation_correct_target",
+			Help:      "1 if the validator's attestation had the correct target in the processed epoch, otherwise 0.",
+		}, []string{"validator"}),
+		attestationTimelySource: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "attestation_timely_source",
+			Help:      "1 if the validator's attestation had a timely source in the processed epoch, otherwise 0.",
+		}, []string{"validator"}),
+		missedAttestations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "missed_attestations_total",
+			Help:      "The number of attestations missed by the validator.",
+		}, []string{"validator"}),
+		proposals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "proposals_total",
+			Help:      "The number of proposals expected of the validator.",
+		}, []string{"validator"}),
+		missedProposals: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "missed_proposals_total",
+			Help:      "The number of proposals missed by the validator.",
+		}, []string{"validator"}),
+		syncCommitteeMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "sync_committee_messages_total",
+			Help:      "The number of sync committee messages expected of the validator.",
+		}, []string{"validator"}),
+		missedSyncCommitteeMessages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "missed_sync_committee_messages_total",
+			Help:      "The number of sync committee messages missed by the validator.",
+		}, []string{"validator"}),
+		balance: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "balance_gwei",
+			Help:      "The balance of the validator at the start of the processed epoch.",
+		}, []string{"validator"}),
+		balanceDelta: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "balance_delta_gwei",
+			Help:      "The change in balance of the validator since the previous processed epoch.",
+		}, []string{"validator"}),
+	}
+
+	for _, collector := range []prometheus.Collector{
+		m.headSlot,
+		m.finalizedEpoch,
+		m.processedEpoch,
+		m.participationRate,
+		m.inclusionDelay,
+		m.attestationIncluded,
+		m.attestationCorrectHead,
+		m.attestationCorrectTarget,
+		m.attestationTimelySource,
+		m.missedAttestations,
+		m.proposals,
+		m.missedProposals,
+		m.syncCommitteeMessages,
+		m.missedSyncCommitteeMessages,
+		m.balance,
+		m.balanceDelta,
+	} {
+		if err := m.registry.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// update updates the metrics with the performance of validators in an epoch.
+// lastBalances contains the balances from the previous update, and is updated in place.
+func (m *metrics) update(epoch phase0.Epoch,
+	performances []*validatorsummary.EpochPerformance,
+	lastBalances map[phase0.ValidatorIndex]phase0.Gwei,
+) {
+	active := 0
+	included := 0
+	for _, performance := range performances {
+		validator := fmt.Sprintf("%d", performance.Validator)
+
+		m.balance.WithLabelValues(validator).Set(float64(performance.Balance))
+		if lastBalance, exists := lastBalances[performance.Validator]; exists {
+			m.balanceDelta.WithLabelValues(validator).Set(float64(performance.Balance) - float64(lastBalance))
+		}
+		lastBalances[performance.Validator] = performance.Balance
+
+		if performance.ProposalsExpected > 0 {
+			m.proposals.WithLabelValues(validator).Add(float64(performance.ProposalsExpected))
+			m.missedProposals.WithLabelValues(validator).Add(float64(performance.ProposalsMissed))
+		}
+		if performance.SyncCommitteeExpected > 0 {
+			m.syncCommitteeMessages.WithLabelValues(validator).Add(float64(performance.SyncCommitteeExpected))
+			m.missedSyncCommitteeMessages.WithLabelValues(validator).Add(float64(performance.SyncCommitteeMissed))
+		}
+
+		if !performance.Active {
+			continue
+		}
+		active++
+		if !performance.AttestationIncluded {
+			m.attestationIncluded.WithLabelValues(validator).Set(0)
+			m.attestationCorrectHead.WithLabelValues(validator).Set(0)
+			m.attestationCorrectTarget.WithLabelValues(validator).Set(0)
+			m.attestationTimelySource.WithLabelValues(validator).Set(0)
+			m.missedAttestations.WithLabelValues(validator).Inc()
+
+			continue
+		}
+		included++
+		m.attestationIncluded.WithLabelValues(validator).Set(1)
+		m.attestationCorrectHead.WithLabelValues(validator).Set(boolToFloat(performance.CorrectHead))
+		m.attestationCorrectTarget.WithLabelValues(validator).Set(boolToFloat(performance.CorrectTarget))
+		m.attestationTimelySource.WithLabelValues(validator).Set(boolToFloat(performance.TimelySource))
+		// Ensure the counter exists even if the validator has never missed an attestation.
+		m.missedAttestations.WithLabelValues(validator).Add(0)
+		m.inclusionDelay.Observe(float64(performance.InclusionDelay))
+	}
+
+	if active > 0 {
+		m.participationRate.Set(float64(included) / float64(active))
+	}
+	m.processedEpoch.Set(float64(epoch))
+}
+
+func boolToFloat(input bool) float64 {
+	if input {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,80 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+)
+
+func TestMetricsUpdate(t *testing.T) {
+	m, err := newMetrics()
+	require.NoError(t, err)
+
+	lastBalances := make(map[phase0.ValidatorIndex]phase0.Gwei)
+	m.update(10, []*validatorsummary.EpochPerformance{
+		{
+			Validator:           1,
+			Balance:             32000000000,
+			Active:              true,
+			AttestationIncluded: true,
+			InclusionDelay:      1,
+			CorrectHead:         true,
+			CorrectTarget:       true,
+			TimelySource:        true,
+		},
+		{
+			Validator:         2,
+			Balance:           32000000000,
+			Active:            true,
+			ProposalsExpected: 1,
+			ProposalsMissed:   1,
+		},
+	}, lastBalances)
+
+	require.InDelta(t, 10, testutil.ToFloat64(m.processedEpoch), 0)
+	require.InDelta(t, 0.5, testutil.ToFloat64(m.participationRate), 0.0001)
+	require.InDelta(t, 1, testutil.ToFloat64(m.attestationIncluded.WithLabelValues("1")), 0)
+	require.InDelta(t, 0, testutil.ToFloat64(m.attestationIncluded.WithLabelValues("2")), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(m.missedAttestations.WithLabelValues("2")), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(m.missedProposals.WithLabelValues("2")), 0)
+	require.Equal(t, 0, testutil.CollectAndCount(m.balanceDelta))
+
+	m.update(11, []*validatorsummary.EpochPerformance{
+		{
+			Validator:           1,
+			Balance:             32000010000,
+			Active:              true,
+			AttestationIncluded: true,
+			InclusionDelay:      2,
+		},
+		{
+			Validator:           2,
+			Balance:             31999990000,
+			Active:              true,
+			AttestationIncluded: true,
+			InclusionDelay:      1,
+		},
+	}, lastBalances)
+
+	require.InDelta(t, 1, testutil.ToFloat64(m.participationRate), 0.0001)
+	require.InDelta(t, 10000, testutil.ToFloat64(m.balanceDelta.WithLabelValues("1")), 0)
+	require.InDelta(t, -10000, testutil.ToFloat64(m.balanceDelta.WithLabelValues("2")), 0)
+	require.InDelta(t, 0, testutil.ToFloat64(m.attestationCorrectHead.WithLabelValues("1")), 0)
+	require.InDelta(t, 1, testutil.ToFloat64(m.missedAttestations.WithLabelValues("2")), 0)
+}
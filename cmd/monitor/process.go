@@ -0,0 +1,165 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	validatorsummary "github.com/wealdtech/ethdo/cmd/validator/summary"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// epochLag is the number of epochs behind the current epoch that are processed,
+// to ensure that all attestations for the epoch have had the chance to be included.
+const epochLag = 2
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", c.metricsAddress)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen for metrics requests")
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(c.metrics.registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "Metrics server failed: %v\n", err)
+		}
+	}()
+	defer server.Close()
+	if !c.quiet {
+		fmt.Fprintf(os.Stderr, "Serving metrics on http://%s/metrics\n", listener.Addr())
+	}
+
+	// Process the latest complete epoch immediately rather than waiting for the next one.
+	c.queueEpoch(c.chainTime.CurrentEpoch())
+
+	if err := c.eventsProvider.Events(ctx, []string{"head", "finalized_checkpoint"}, c.handleEvent); err != nil {
+		return errors.Wrap(err, "failed to subscribe to events")
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case epoch := <-c.epochs:
+			c.processEpoch(ctx, epoch)
+		}
+	}
+}
+
+// handleEvent handles events from the beacon node.
+func (c *command) handleEvent(event *apiv1.Event) {
+	switch data := event.Data.(type) {
+	case *apiv1.HeadEvent:
+		c.metrics.headSlot.Set(float64(data.Slot))
+		c.queueEpoch(c.chainTime.SlotToEpoch(data.Slot))
+	case *apiv1.FinalizedCheckpointEvent:
+		c.metrics.finalizedEpoch.Set(float64(data.Epoch))
+	}
+}
+
+// queueEpoch queues the epoch for processing that is complete as of the given current epoch.
+func (c *command) queueEpoch(currentEpoch phase0.Epoch) {
+	if currentEpoch < epochLag {
+		return
+	}
+	epoch := currentEpoch - epochLag
+	if c.lastEpochSet && epoch <= c.lastEpoch {
+		return
+	}
+	c.lastEpoch = epoch
+	c.lastEpochSet = true
+
+	select {
+	case c.epochs <- epoch:
+	default:
+		fmt.Fprintf(os.Stderr, "Processing is falling behind; skipping epoch %d\n", epoch)
+	}
+}
+
+func (c *command) processEpoch(ctx context.Context, epoch phase0.Epoch) {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Processing epoch %d\n", epoch)
+	}
+	performances, err := c.summarizer.Summarize(ctx, epoch)
+	if err != nil {
+		// Errors are not fatal, as the monitor should continue with later epochs.
+		fmt.Fprintf(os.Stderr, "Failed to process epoch %d: %v\n", epoch, err)
+		return
+	}
+	c.metrics.update(epoch, performances, c.lastBalances)
+	if c.verbose {
+		fmt.Fprintf(os.Stderr, "Processed epoch %d\n", epoch)
+	}
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.eventsProvider, isProvider = c.eth2Client.(eth2client.EventsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide events")
+	}
+
+	c.summarizer, err = validatorsummary.NewSummarizer(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up validator summarizer")
+	}
+
+	c.metrics, err = newMetrics()
+	if err != nil {
+		return errors.Wrap(err, "failed to set up metrics")
+	}
+
+	return nil
+}
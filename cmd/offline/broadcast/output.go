@@ -0,0 +1,42 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlinebroadcast
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Broadcast %d exit(s) and %d credentials change(s)",
+		len(c.bundle.VoluntaryExits),
+		len(c.bundle.BLSToExecutionChanges),
+	))
+	if len(c.bundle.Deposits) > 0 {
+		builder.WriteString(fmt.Sprintf("\n%d deposit(s) verified; deposits must be submitted to the deposit contract", len(c.bundle.Deposits)))
+	}
+	if c.verbose {
+		for _, deposit := range c.bundle.Deposits {
+			builder.WriteString(fmt.Sprintf("\n  %#x: %d Gwei", deposit.PublicKey, deposit.Amount))
+		}
+	}
+
+	return builder.String(), nil
+}
@@ -0,0 +1,180 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlinebroadcast
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// minTimeout is the minimum timeout for this command.
+// It needs to be set here as we want timeouts to be low in general, but this can be pulling
+// a lot of data for an unsophisticated audience so it's easier to set a higher timeout..
+var minTimeout = 5 * time.Minute
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.obtainBundle(ctx); err != nil {
+		return err
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Populating chain info from beacon node\n")
+	}
+	var err error
+	c.chainInfo, err = beacon.ObtainChainInfoFromNode(ctx, c.consensusClient, c.chainTime)
+	if err != nil {
+		return err
+	}
+
+	if err := c.bundle.Verify(ctx, c.chainInfo); err != nil {
+		return errors.Wrap(err, "signed bundle failed verification")
+	}
+
+	if err := c.validateOperations(ctx); err != nil {
+		return err
+	}
+
+	return c.broadcastOperations(ctx)
+}
+
+func (c *command) obtainBundle(_ context.Context) error {
+	data, err := os.ReadFile(c.bundleFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read signed bundle")
+	}
+	c.bundle = &beacon.SignedBundle{}
+	if err := json.Unmarshal(data, c.bundle); err != nil {
+		return errors.Wrap(err, "signed bundle invalid")
+	}
+	if c.bundle.Operations() == 0 {
+		return errors.New("signed bundle contains no operations")
+	}
+
+	return nil
+}
+
+// validateOperations ensures that the operations in the bundle are still applicable to the current state of the chain.
+func (c *command) validateOperations(ctx context.Context) error {
+	for _, exit := range c.bundle.VoluntaryExits {
+		validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, fmt.Sprintf("%d", exit.Message.ValidatorIndex))
+		if err != nil {
+			return err
+		}
+		switch validatorInfo.State {
+		case apiv1.ValidatorStateActiveOngoing, apiv1.ValidatorStatePendingInitialized, apiv1.ValidatorStatePendingQueued:
+			// Good.
+		default:
+			return fmt.Errorf("validator %d is in state %v, not suitable to exit", validatorInfo.Index, validatorInfo.State)
+		}
+		if exit.Message.Epoch > c.chainInfo.Epoch {
+			return fmt.Errorf("exit for validator %d is for future epoch %d", validatorInfo.Index, exit.Message.Epoch)
+		}
+	}
+
+	for _, change := range c.bundle.BLSToExecutionChanges {
+		validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, fmt.Sprintf("%d", change.Message.ValidatorIndex))
+		if err != nil {
+			return err
+		}
+		if validatorInfo.WithdrawalCredentials[0] != 0x00 {
+			return fmt.Errorf("validator %d no longer has BLS withdrawal credentials", validatorInfo.Index)
+		}
+	}
+
+	for _, deposit := range c.bundle.Deposits {
+		if !bytes.Equal(deposit.ForkVersion, c.chainInfo.GenesisForkVersion[:]) {
+			return fmt.Errorf("deposit for %#x is for fork version %#x, but the chain has fork version %#x", deposit.PublicKey, deposit.ForkVersion, c.chainInfo.GenesisForkVersion)
+		}
+	}
+
+	return nil
+}
+
+func (c *command) broadcastOperations(ctx context.Context) error {
+	if len(c.bundle.BLSToExecutionChanges) > 0 {
+		// Check for support before submitting anything, to avoid a partial broadcast.
+		if _, isSubmitter := c.consensusClient.(consensusclient.BLSToExecutionChangesSubmitter); !isSubmitter {
+			return errors.New("connection does not support submitting credentials changes; use a single beacon node")
+		}
+	}
+
+	for _, exit := range c.bundle.VoluntaryExits {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Broadcasting exit for validator %d\n", exit.Message.ValidatorIndex)
+		}
+		if err := c.consensusClient.(consensusclient.VoluntaryExitSubmitter).SubmitVoluntaryExit(ctx, exit); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to submit exit for validator %d", exit.Message.ValidatorIndex))
+		}
+	}
+
+	if len(c.bundle.BLSToExecutionChanges) > 0 {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Broadcasting %d credentials change(s)\n", len(c.bundle.BLSToExecutionChanges))
+		}
+		submitter := c.consensusClient.(consensusclient.BLSToExecutionChangesSubmitter)
+		if err := submitter.SubmitBLSToExecutionChanges(ctx, c.bundle.BLSToExecutionChanges); err != nil {
+			return errors.Wrap(err, "failed to submit credentials changes")
+		}
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	// Ensure timeout is at least the minimum.
+	if c.timeout < minTimeout {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Increasing timeout to %v\n", minTimeout)
+		}
+		c.timeout = minTimeout
+	}
+
+	// Connect to the consensus node.
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlinebroadcast
+
+import (
+	"context"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestValidateOperationsDeposits(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		forkVersion []byte
+		err         string
+	}{
+		{
+			name:        "Good",
+			forkVersion: []byte{0x00, 0x00, 0x10, 0x20},
+		},
+		{
+			name:        "WrongForkVersion",
+			forkVersion: []byte{0x00, 0x00, 0x00, 0x00},
+			err:         "deposit for 0x01 is for fork version 0x00000000, but the chain has fork version 0x00001020",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				chainInfo: &beacon.ChainInfo{
+					GenesisForkVersion: phase0.Version{0x00, 0x00, 0x10, 0x20},
+				},
+				bundle: &beacon.SignedBundle{
+					Version: beacon.SignedBundleVersion,
+					Deposits: []*util.DepositInfo{
+						{
+							PublicKey:   []byte{0x01},
+							ForkVersion: test.forkVersion,
+						},
+					},
+				},
+			}
+			err := c.validateOperations(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlineprepare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// minTimeout is the minimum timeout for this command.
+// It needs to be set here as we want timeouts to be low in general, but this can be pulling
+// a lot of data for an unsophisticated audience so it's easier to set a higher timeout..
+var minTimeout = 5 * time.Minute
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Populating chain info from beacon node\n")
+	}
+	var err error
+	c.chainInfo, err = beacon.ObtainChainInfoFromNode(ctx, c.consensusClient, c.chainTime)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(c.chainInfo)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate chain info JSON")
+	}
+	if err := os.WriteFile(c.chainInfoFile, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed write chain info JSON")
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	// Ensure timeout is at least the minimum.
+	if c.timeout < minTimeout {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Increasing timeout to %v\n", minTimeout)
+		}
+		c.timeout = minTimeout
+	}
+
+	// Connect to the consensus node.
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
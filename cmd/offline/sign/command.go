@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlinesign
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/util"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Input.
+	chainInfoFile string
+	manifestFile  string
+	bundleFile    string
+	mnemonic      string
+	passphrases   []string
+
+	// Information required to generate the operations.
+	chainInfo *beacon.ChainInfo
+	manifest  *manifest
+
+	// Output.
+	bundle *beacon.SignedBundle
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:         viper.GetBool("quiet"),
+		verbose:       viper.GetBool("verbose"),
+		debug:         viper.GetBool("debug"),
+		chainInfoFile: viper.GetString("chain-info"),
+		manifestFile:  viper.GetString("manifest"),
+		bundleFile:    viper.GetString("bundle"),
+		mnemonic:      viper.GetString("mnemonic"),
+		passphrases:   util.GetPassphrases(),
+		bundle: &beacon.SignedBundle{
+			Version: beacon.SignedBundleVersion,
+		},
+	}
+
+	if c.chainInfoFile == "" {
+		return nil, errors.New("chain info file is required")
+	}
+	if c.manifestFile == "" {
+		return nil, errors.New("manifest is required")
+	}
+	if c.bundleFile == "" {
+		return nil, errors.New("bundle file is required")
+	}
+
+	return c, nil
+}
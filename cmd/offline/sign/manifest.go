@@ -0,0 +1,50 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlinesign
+
+const (
+	operationTypeExit                 = "exit"
+	operationTypeBLSToExecutionChange = "bls_to_execution_change"
+	operationTypeDeposit              = "deposit"
+)
+
+// manifest is the list of operations to sign.
+type manifest struct {
+	Operations []*manifestOperation `json:"operations"`
+}
+
+// manifestOperation is a single operation to sign.
+// The key used to sign the operation is supplied by one of Account, Path or PrivateKey.
+type manifestOperation struct {
+	Type string `json:"type"`
+
+	// Key.
+	Account    string `json:"account,omitempty"`
+	Path       string `json:"path,omitempty"`
+	PrivateKey string `json:"private_key,omitempty"`
+
+	// Validator is the validator to which the operation applies, as an index or public key.
+	// It is optional for exits, where it defaults to the validator of the signing key.
+	Validator string `json:"validator,omitempty"`
+
+	// Epoch is the epoch at which the exit takes place; defaults to the chain information epoch.
+	Epoch string `json:"epoch,omitempty"`
+
+	// WithdrawalAddress is the address for credentials changes and deposits.
+	WithdrawalAddress string `json:"withdrawal_address,omitempty"`
+	// Compounding creates compounding withdrawal credentials for deposits.
+	Compounding bool `json:"compounding,omitempty"`
+	// Amount is the amount of the deposit; defaults to 32 Ether.
+	Amount string `json:"amount,omitempty"`
+}
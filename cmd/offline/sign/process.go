@@ -0,0 +1,342 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlinesign
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// defaultDepositAmount is the amount of a deposit if not otherwise specified.
+const defaultDepositAmount = phase0.Gwei(32000000000)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.obtainChainInfo(ctx); err != nil {
+		return err
+	}
+
+	if err := c.obtainManifest(ctx); err != nil {
+		return err
+	}
+
+	if err := c.signOperations(ctx); err != nil {
+		return err
+	}
+
+	// Ensure that the bundle we have created is valid before writing it.
+	if err := c.bundle.Verify(ctx, c.chainInfo); err != nil {
+		return errors.Wrap(err, "signed bundle failed verification")
+	}
+
+	data, err := json.Marshal(c.bundle)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate signed bundle JSON")
+	}
+	if err := os.WriteFile(c.bundleFile, data, 0o600); err != nil {
+		return errors.Wrap(err, "failed to write signed bundle")
+	}
+
+	return nil
+}
+
+func (c *command) obtainChainInfo(_ context.Context) error {
+	data, err := os.ReadFile(c.chainInfoFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read chain info file")
+	}
+	c.chainInfo = &beacon.ChainInfo{}
+	if err := json.Unmarshal(data, c.chainInfo); err != nil {
+		return errors.Wrap(err, "chain info file invalid")
+	}
+
+	return nil
+}
+
+func (c *command) obtainManifest(_ context.Context) error {
+	data, err := os.ReadFile(c.manifestFile)
+	if err != nil {
+		return errors.Wrap(err, "failed to read manifest")
+	}
+	c.manifest = &manifest{}
+	if err := json.Unmarshal(data, c.manifest); err != nil {
+		return errors.Wrap(err, "manifest invalid")
+	}
+	if len(c.manifest.Operations) == 0 {
+		return errors.New("manifest contains no operations")
+	}
+
+	return nil
+}
+
+func (c *command) signOperations(ctx context.Context) error {
+	for i, op := range c.manifest.Operations {
+		account, err := c.operationAccount(ctx, op)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("operation %d", i))
+		}
+
+		switch op.Type {
+		case operationTypeExit:
+			err = c.signExit(ctx, op, account)
+		case operationTypeBLSToExecutionChange:
+			err = c.signBLSToExecutionChange(ctx, op, account)
+		case operationTypeDeposit:
+			err = c.signDeposit(ctx, op, account)
+		default:
+			err = fmt.Errorf("unknown operation type %q", op.Type)
+		}
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("operation %d", i))
+		}
+	}
+
+	return nil
+}
+
+// operationAccount obtains the account with which to sign the operation.
+func (c *command) operationAccount(ctx context.Context, op *manifestOperation) (e2wtypes.Account, error) {
+	switch {
+	case op.PrivateKey != "":
+		return util.ParseAccount(ctx, op.PrivateKey, nil, true)
+	case op.Path != "":
+		if c.mnemonic == "" {
+			return nil, errors.New("path supplied but no mnemonic")
+		}
+		return util.ParseAccount(ctx, c.mnemonic, []string{op.Path}, true)
+	case op.Account != "":
+		return util.ParseAccount(ctx, op.Account, c.passphrases, true)
+	default:
+		return nil, errors.New("no account, path or private key supplied")
+	}
+}
+
+func (c *command) signExit(ctx context.Context, op *manifestOperation, account e2wtypes.Account) error {
+	pubkey, err := util.BestPublicKey(account)
+	if err != nil {
+		return err
+	}
+
+	validator := op.Validator
+	if validator == "" {
+		validator = fmt.Sprintf("%#x", pubkey.Marshal())
+	}
+	validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, validator)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(validatorInfo.Pubkey[:], pubkey.Marshal()) {
+		return fmt.Errorf("key does not match validator %d", validatorInfo.Index)
+	}
+	switch validatorInfo.State {
+	case apiv1.ValidatorStateActiveOngoing, apiv1.ValidatorStatePendingInitialized, apiv1.ValidatorStatePendingQueued:
+		// Good.
+	default:
+		return fmt.Errorf("validator is in state %v, not suitable to generate an exit", validatorInfo.State)
+	}
+
+	epoch := c.chainInfo.Epoch
+	if op.Epoch != "" {
+		tmp, err := strconv.ParseUint(op.Epoch, 10, 64)
+		if err != nil {
+			return errors.Wrap(err, "invalid epoch")
+		}
+		epoch = phase0.Epoch(tmp)
+	}
+
+	domain, err := c.chainInfo.VoluntaryExitDomain()
+	if err != nil {
+		return err
+	}
+
+	exit := &phase0.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: validatorInfo.Index,
+	}
+	root, err := exit.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for exit operation")
+	}
+	signature, err := signing.SignRoot(ctx, account, nil, root, domain)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign exit operation")
+	}
+
+	c.bundle.VoluntaryExits = append(c.bundle.VoluntaryExits, &phase0.SignedVoluntaryExit{
+		Message:   exit,
+		Signature: signature,
+	})
+
+	return nil
+}
+
+func (c *command) signBLSToExecutionChange(ctx context.Context, op *manifestOperation, account e2wtypes.Account) error {
+	if op.Validator == "" {
+		return errors.New("no validator supplied")
+	}
+	validatorInfo, err := c.chainInfo.FetchValidatorInfo(ctx, op.Validator)
+	if err != nil {
+		return err
+	}
+	if validatorInfo.WithdrawalCredentials[0] != 0x00 {
+		return fmt.Errorf("validator %d does not have BLS withdrawal credentials", validatorInfo.Index)
+	}
+
+	pubkey, err := util.BestPublicKey(account)
+	if err != nil {
+		return err
+	}
+	withdrawalCredentials := ethutil.SHA256(pubkey.Marshal())
+	if !bytes.Equal(withdrawalCredentials[1:], validatorInfo.WithdrawalCredentials[1:]) {
+		return fmt.Errorf("key is not the withdrawal key for validator %d", validatorInfo.Index)
+	}
+
+	address, err := parseAddress(op.WithdrawalAddress)
+	if err != nil {
+		return err
+	}
+
+	domain, err := c.chainInfo.BLSToExecutionChangeDomain()
+	if err != nil {
+		return err
+	}
+
+	change := &capella.BLSToExecutionChange{
+		ValidatorIndex:     validatorInfo.Index,
+		ToExecutionAddress: address,
+	}
+	copy(change.FromBLSPubkey[:], pubkey.Marshal())
+	root, err := change.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate root for credentials change operation")
+	}
+	signature, err := signing.SignRoot(ctx, account, nil, root, domain)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign credentials change operation")
+	}
+
+	c.bundle.BLSToExecutionChanges = append(c.bundle.BLSToExecutionChanges, &capella.SignedBLSToExecutionChange{
+		Message:   change,
+		Signature: signature,
+	})
+
+	return nil
+}
+
+func (c *command) signDeposit(ctx context.Context, op *manifestOperation, account e2wtypes.Account) error {
+	pubkey, err := util.BestPublicKey(account)
+	if err != nil {
+		return err
+	}
+
+	address, err := parseAddress(op.WithdrawalAddress)
+	if err != nil {
+		return err
+	}
+	withdrawalCredentials := make([]byte, 32)
+	if op.Compounding {
+		withdrawalCredentials[0] = 0x02
+	} else {
+		withdrawalCredentials[0] = 0x01
+	}
+	copy(withdrawalCredentials[12:], address[:])
+
+	amount := defaultDepositAmount
+	if op.Amount != "" {
+		tmp, err := string2eth.StringToGWei(op.Amount)
+		if err != nil {
+			return errors.Wrap(err, "invalid amount")
+		}
+		amount = phase0.Gwei(tmp)
+	}
+
+	deposit := &phase0.DepositData{
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+	}
+	copy(deposit.PublicKey[:], pubkey.Marshal())
+
+	root, err := (&phase0.DepositMessage{
+		PublicKey:             deposit.PublicKey,
+		WithdrawalCredentials: deposit.WithdrawalCredentials,
+		Amount:                deposit.Amount,
+	}).HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate deposit message root")
+	}
+	deposit.Signature, err = signing.SignRoot(ctx, account, nil, root, c.chainInfo.DepositDomain())
+	if err != nil {
+		return errors.Wrap(err, "failed to sign deposit message")
+	}
+	dataRoot, err := deposit.HashTreeRoot()
+	if err != nil {
+		return errors.Wrap(err, "failed to generate deposit data root")
+	}
+
+	// Store the deposit in the same form as "validator depositdata".
+	depositInfo := &util.DepositInfo{
+		Account:               op.Account,
+		PublicKey:             deposit.PublicKey[:],
+		WithdrawalCredentials: deposit.WithdrawalCredentials,
+		Signature:             deposit.Signature[:],
+		DepositDataRoot:       dataRoot[:],
+		DepositMessageRoot:    root[:],
+		ForkVersion:           c.chainInfo.GenesisForkVersion[:],
+		Amount:                uint64(deposit.Amount),
+		Version:               3,
+	}
+	if op.Account != "" {
+		depositInfo.Name = fmt.Sprintf("Deposit for %s", op.Account)
+	}
+	c.bundle.Deposits = append(c.bundle.Deposits, depositInfo)
+
+	return nil
+}
+
+func parseAddress(input string) (bellatrix.ExecutionAddress, error) {
+	address := bellatrix.ExecutionAddress{}
+	if input == "" {
+		return address, errors.New("no withdrawal address supplied")
+	}
+	if !strings.HasPrefix(input, "0x") {
+		return address, fmt.Errorf("withdrawal address %s does not contain a 0x prefix", input)
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return address, errors.Wrap(err, "invalid withdrawal address")
+	}
+	if len(data) != bellatrix.ExecutionAddressLength {
+		return address, errors.New("withdrawal address must be exactly 20 bytes in length")
+	}
+	copy(address[:], data)
+
+	return address, nil
+}
@@ -0,0 +1,169 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offlinesign
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestSignOperations(t *testing.T) {
+	ctx := context.Background()
+
+	require.NoError(t, e2types.InitBLS())
+
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 3,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:                 0,
+				Pubkey:                phase0.BLSPubKey{0xb3, 0x84, 0xf7, 0x67, 0xd9, 0x64, 0xe1, 0x00, 0xc8, 0xa9, 0xb2, 0x10, 0x18, 0xd0, 0x8c, 0x25, 0xff, 0xeb, 0xae, 0x26, 0x8b, 0x3a, 0xb6, 0xd6, 0x10, 0x35, 0x38, 0x97, 0x54, 0x19, 0x71, 0x72, 0x6d, 0xbf, 0xc3, 0xc7, 0x46, 0x38, 0x84, 0xc6, 0x8a, 0x53, 0x15, 0x15, 0xaa, 0xb9, 0x4c, 0x87},
+				State:                 apiv1.ValidatorStateActiveOngoing,
+				WithdrawalCredentials: []byte{0x00, 0x8b, 0xa1, 0xcc, 0x4b, 0x09, 0x1b, 0x91, 0xc1, 0x20, 0x2b, 0xba, 0x3f, 0x50, 0x80, 0x75, 0xd6, 0xff, 0x56, 0x5c, 0x77, 0xe5, 0x59, 0xf0, 0x80, 0x3c, 0x07, 0x92, 0xe0, 0x30, 0x2b, 0xf1},
+			},
+			{
+				Index:                 1,
+				Pubkey:                phase0.BLSPubKey{0xb3, 0xd8, 0x9e, 0x2f, 0x29, 0xc7, 0x12, 0xc6, 0xa9, 0xf8, 0xe5, 0xa2, 0x69, 0xb9, 0x76, 0x17, 0xc4, 0xa9, 0x4d, 0xd6, 0xf6, 0x66, 0x2a, 0xb3, 0xb0, 0x7c, 0xe9, 0xe5, 0x43, 0x45, 0x73, 0xf1, 0x5b, 0x5c, 0x98, 0x8c, 0xd1, 0x4b, 0xbd, 0x58, 0x04, 0xf7, 0x71, 0x56, 0xa8, 0xaf, 0x1c, 0xfa},
+				State:                 apiv1.ValidatorStateActiveExiting,
+				WithdrawalCredentials: []byte{0x00, 0x78, 0x6c, 0xb0, 0x2e, 0xd2, 0x8e, 0x5f, 0xbb, 0x1f, 0x7f, 0x9e, 0x93, 0x1a, 0x2b, 0x72, 0x69, 0x29, 0x06, 0xe6, 0xb1, 0x2c, 0xe4, 0x64, 0x39, 0x75, 0xe3, 0x2b, 0x51, 0x76, 0x91, 0xf2},
+			},
+		},
+		Epoch:                          100,
+		GenesisForkVersion:             phase0.Version{0x00, 0x00, 0x00, 0x00},
+		ExitForkVersion:                phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CurrentForkVersion:             phase0.Version{0x04, 0x00, 0x00, 0x00},
+		BLSToExecutionChangeDomainType: phase0.DomainType{0x0a, 0x00, 0x00, 0x00},
+		VoluntaryExitDomainType:        phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+	}
+
+	tests := []struct {
+		name       string
+		operations []*manifestOperation
+		exits      int
+		changes    int
+		deposits   int
+		err        string
+	}{
+		{
+			name: "UnknownType",
+			operations: []*manifestOperation{
+				{Type: "unknown", Path: "m/12381/3600/0/0/0"},
+			},
+			err: `operation 0: unknown operation type "unknown"`,
+		},
+		{
+			name: "NoKey",
+			operations: []*manifestOperation{
+				{Type: operationTypeExit},
+			},
+			err: "operation 0: no account, path or private key supplied",
+		},
+		{
+			name: "ExitWrongKey",
+			operations: []*manifestOperation{
+				{Type: operationTypeExit, Path: "m/12381/3600/0/0/0", Validator: "1"},
+			},
+			err: "operation 0: key does not match validator 1",
+		},
+		{
+			name: "ExitExiting",
+			operations: []*manifestOperation{
+				{Type: operationTypeExit, Path: "m/12381/3600/1/0/0"},
+			},
+			err: "operation 0: validator is in state active_exiting, not suitable to generate an exit",
+		},
+		{
+			name: "ChangeWrongKey",
+			operations: []*manifestOperation{
+				{Type: operationTypeBLSToExecutionChange, Path: "m/12381/3600/1/0", Validator: "0", WithdrawalAddress: "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"},
+			},
+			err: "operation 0: key is not the withdrawal key for validator 0",
+		},
+		{
+			name: "DepositAddressMissing",
+			operations: []*manifestOperation{
+				{Type: operationTypeDeposit, Path: "m/12381/3600/2/0/0"},
+			},
+			err: "operation 0: no withdrawal address supplied",
+		},
+		{
+			name: "Mixed",
+			operations: []*manifestOperation{
+				{Type: operationTypeExit, Path: "m/12381/3600/0/0/0"},
+				{Type: operationTypeBLSToExecutionChange, Path: "m/12381/3600/0/0", Validator: "0", WithdrawalAddress: "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15"},
+				{Type: operationTypeDeposit, Path: "m/12381/3600/2/0/0", WithdrawalAddress: "0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15", Amount: "1 Ether"},
+			},
+			exits:    1,
+			changes:  1,
+			deposits: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &command{
+				mnemonic:  mnemonic,
+				chainInfo: chainInfo,
+				manifest: &manifest{
+					Operations: test.operations,
+				},
+				bundle: &beacon.SignedBundle{
+					Version: beacon.SignedBundleVersion,
+				},
+			}
+			err := c.signOperations(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, c.bundle.VoluntaryExits, test.exits)
+			require.Len(t, c.bundle.BLSToExecutionChanges, test.changes)
+			require.Len(t, c.bundle.Deposits, test.deposits)
+			require.NoError(t, c.bundle.Verify(ctx, chainInfo))
+
+			// Ensure the bundle survives a round trip.
+			data, err := json.Marshal(c.bundle)
+			require.NoError(t, err)
+			bundle := &beacon.SignedBundle{}
+			require.NoError(t, json.Unmarshal(data, bundle))
+			require.Equal(t, c.bundle, bundle)
+
+			// Ensure deposits are stored in the same form as deposit data.
+			if len(bundle.Deposits) > 0 {
+				require.Contains(t, string(data), fmt.Sprintf(`"fork_version":"%#x"`, chainInfo.GenesisForkVersion))
+				require.Contains(t, string(data), `"deposit_data_root":"0x`)
+				bundle.Deposits[0].DepositDataRoot[0]++
+				require.EqualError(t, bundle.Verify(ctx, chainInfo), fmt.Sprintf("deposit for %#x has incorrect deposit data root", bundle.Deposits[0].PublicKey))
+				bundle.Deposits[0].DepositDataRoot[0]--
+			}
+
+			// Ensure that a tampered bundle fails verification.
+			if len(bundle.VoluntaryExits) > 0 {
+				bundle.VoluntaryExits[0].Message.Epoch++
+				require.EqualError(t, bundle.Verify(ctx, chainInfo), "exit for validator 0: signature does not verify")
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	offlinebroadcast "github.com/wealdtech/ethdo/cmd/offline/broadcast"
+)
+
+var offlineBroadcastCmd = &cobra.Command{
+	Use:   "broadcast",
+	Short: "Broadcast a signed bundle of operations",
+	Long: `Verify and broadcast a signed bundle of operations created by "ethdo offline sign".  For example:
+
+    ethdo offline broadcast --bundle=signed-bundle.json
+
+All operations are checked against the current state of the chain before any are broadcast.  Deposits are verified but must be submitted to the deposit contract separately.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := offlinebroadcast.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	offlineCmd.AddCommand(offlineBroadcastCmd)
+	offlineFlags(offlineBroadcastCmd)
+	offlineBroadcastCmd.Flags().String("bundle", "signed-bundle.json", "the file from which to read the signed bundle")
+}
+
+func offlineBroadcastBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("bundle", cmd.Flags().Lookup("bundle")); err != nil {
+		panic(err)
+	}
+}
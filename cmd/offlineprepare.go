@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	offlineprepare "github.com/wealdtech/ethdo/cmd/offline/prepare"
+)
+
+var offlinePrepareCmd = &cobra.Command{
+	Use:   "prepare",
+	Short: "Obtain chain information for signing operations offline",
+	Long: `Obtain the chain information required to sign operations on an airgapped system.  For example:
+
+    ethdo offline prepare --chain-info=offline-preparation.json
+
+The chain information file should be copied to the airgapped system and used with "ethdo offline sign".`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := offlineprepare.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	offlineCmd.AddCommand(offlinePrepareCmd)
+	offlineFlags(offlinePrepareCmd)
+	offlinePrepareCmd.Flags().String("chain-info", "offline-preparation.json", "the file to which to write chain information")
+}
+
+func offlinePrepareBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("chain-info", cmd.Flags().Lookup("chain-info")); err != nil {
+		panic(err)
+	}
+}
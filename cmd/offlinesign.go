@@ -0,0 +1,65 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	offlinesign "github.com/wealdtech/ethdo/cmd/offline/sign"
+)
+
+var offlineSignCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Sign a manifest of operations offline",
+	Long: `Sign a manifest of exits, credentials changes and deposits without access to a beacon node.  For example:
+
+    ethdo offline sign --chain-info=offline-preparation.json --manifest=manifest.json --mnemonic="..."
+
+Each operation in the manifest obtains its key from an account, a path (in conjunction with --mnemonic) or a private key.  The signed operations are written to the bundle file, which can be broadcast with "ethdo offline broadcast".`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := offlinesign.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	offlineCmd.AddCommand(offlineSignCmd)
+	offlineFlags(offlineSignCmd)
+	offlineSignCmd.Flags().String("chain-info", "offline-preparation.json", "the file from which to read chain information")
+	offlineSignCmd.Flags().String("manifest", "", "the file from which to read the manifest of operations to sign")
+	offlineSignCmd.Flags().String("bundle", "signed-bundle.json", "the file to which to write the signed bundle")
+}
+
+func offlineSignBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("chain-info", cmd.Flags().Lookup("chain-info")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("manifest", cmd.Flags().Lookup("manifest")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("bundle", cmd.Flags().Lookup("bundle")); err != nil {
+		panic(err)
+	}
+}
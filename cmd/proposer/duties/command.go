@@ -38,15 +38,22 @@ type command struct {
 	// Operation.
 	epoch      string
 	slot       string
+	lookahead  bool
+	validators []string
 	jsonOutput bool
+	icsOutput  bool
 
 	// Data access.
-	eth2Client             eth2client.Service
-	chainTime              chaintime.Service
-	proposerDutiesProvider eth2client.ProposerDutiesProvider
+	eth2Client                  eth2client.Service
+	chainTime                   chaintime.Service
+	proposerDutiesProvider      eth2client.ProposerDutiesProvider
+	syncCommitteeDutiesProvider eth2client.SyncCommitteeDutiesProvider
+	validatorsProvider          eth2client.ValidatorsProvider
 
 	// Results.
-	results *results
+	results          *results
+	lookaheadResults *lookaheadResults
+	generated        time.Time
 }
 
 type results struct {
@@ -64,7 +71,10 @@ func newCommand(_ context.Context) (*command, error) {
 		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
 		epoch:                    viper.GetString("epoch"),
 		slot:                     viper.GetString("slot"),
+		lookahead:                viper.GetBool("lookahead"),
+		validators:               viper.GetStringSlice("validators"),
 		jsonOutput:               viper.GetBool("json"),
+		icsOutput:                viper.GetBool("ics"),
 		results:                  &results{},
 	}
 
@@ -73,5 +83,24 @@ func newCommand(_ context.Context) (*command, error) {
 		return nil, errors.New("timeout is required")
 	}
 
+	if c.lookahead {
+		if c.epoch != "" || c.slot != "" {
+			return nil, errors.New("cannot specify epoch or slot with lookahead")
+		}
+		if len(c.validators) == 0 {
+			return nil, errors.New("validators are required for lookahead")
+		}
+	} else if len(c.validators) > 0 {
+		return nil, errors.New("validators are only available with lookahead")
+	}
+	if c.icsOutput {
+		if !c.lookahead {
+			return nil, errors.New("iCalendar output is only available with lookahead")
+		}
+		if c.jsonOutput {
+			return nil, errors.New("cannot specify both JSON and iCalendar output")
+		}
+	}
+
 	return c, nil
 }
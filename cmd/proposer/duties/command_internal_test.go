@@ -52,6 +52,66 @@ func TestInput(t *testing.T) {
 				"epoch":      "-1",
 			},
 		},
+		{
+			name: "LookaheadWithEpoch",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"lookahead":  true,
+				"validators": []string{"1"},
+				"epoch":      "-1",
+			},
+			err: "cannot specify epoch or slot with lookahead",
+		},
+		{
+			name: "LookaheadNoValidators",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"lookahead":  true,
+			},
+			err: "validators are required for lookahead",
+		},
+		{
+			name: "ValidatorsWithoutLookahead",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"validators": []string{"1"},
+			},
+			err: "validators are only available with lookahead",
+		},
+		{
+			name: "ICSWithoutLookahead",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"ics":        true,
+			},
+			err: "iCalendar output is only available with lookahead",
+		},
+		{
+			name: "ICSAndJSON",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"lookahead":  true,
+				"validators": []string{"1"},
+				"ics":        true,
+				"json":       true,
+			},
+			err: "cannot specify both JSON and iCalendar output",
+		},
+		{
+			name: "GoodLookahead",
+			vars: map[string]interface{}{
+				"timeout":    "5s",
+				"connection": os.Getenv("ETHDO_TEST_CONNECTION"),
+				"lookahead":  true,
+				"validators": []string{"1", "2"},
+				"ics":        true,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,127 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerduties
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// icsTimeFormat is the format of UTC date-times in iCalendar.
+const icsTimeFormat = "20060102T150405Z"
+
+// icsMaxLineLen is the maximum length of an iCalendar content line in octets, excluding the line break.
+const icsMaxLineLen = 75
+
+// outputLookaheadICS outputs the lookahead duties as iCalendar (RFC 5545) events.
+func (c *command) outputLookaheadICS(_ context.Context) (string, error) {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Weald Technology//ethdo//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+	}
+
+	stamp := c.generated.UTC().Format(icsTimeFormat)
+	for _, proposal := range c.lookaheadResults.Proposals {
+		lines = append(lines, icsEvent(
+			fmt.Sprintf("proposal-%d-%d@ethdo", proposal.Slot, proposal.Validator),
+			stamp,
+			proposal.Start,
+			proposal.End,
+			fmt.Sprintf("Validator %d block proposal", proposal.Validator),
+			fmt.Sprintf("Validator %d proposes the block for slot %d in epoch %d.", proposal.Validator, proposal.Slot, proposal.Epoch),
+		)...)
+	}
+	for _, syncCommittee := range c.lookaheadResults.SyncCommittees {
+		validators := make([]string, len(syncCommittee.Validators))
+		for i := range syncCommittee.Validators {
+			validators[i] = fmt.Sprintf("%d", syncCommittee.Validators[i])
+		}
+		lines = append(lines, icsEvent(
+			fmt.Sprintf("sync-committee-%d-%s@ethdo", syncCommittee.Period, strings.Join(validators, "-")),
+			stamp,
+			syncCommittee.Start,
+			syncCommittee.End,
+			fmt.Sprintf("Sync committee period %d", syncCommittee.Period),
+			fmt.Sprintf("Validators %s are members of the sync committee for period %d, epochs %d to %d.",
+				strings.Join(validators, ", "),
+				syncCommittee.Period,
+				syncCommittee.FromEpoch,
+				syncCommittee.ToEpoch,
+			),
+		)...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	builder := strings.Builder{}
+	for _, line := range lines {
+		builder.WriteString(icsFold(line))
+		builder.WriteString("\r\n")
+	}
+
+	// Every content line, including the last, ends with CRLF.
+	return builder.String(), nil
+}
+
+// icsEvent returns the content lines of an iCalendar event.
+func icsEvent(uid string, stamp string, start time.Time, end time.Time, summary string, description string) []string {
+	return []string{
+		"BEGIN:VEVENT",
+		"UID:" + uid,
+		"DTSTAMP:" + stamp,
+		"DTSTART:" + start.UTC().Format(icsTimeFormat),
+		"DTEND:" + end.UTC().Format(icsTimeFormat),
+		"SUMMARY:" + icsEscape(summary),
+		"DESCRIPTION:" + icsEscape(description),
+		"TRANSP:OPAQUE",
+		"END:VEVENT",
+	}
+}
+
+// icsEscape escapes text for use as an iCalendar property value.
+func icsEscape(input string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\n", "\\n",
+	).Replace(input)
+}
+
+// icsFold folds a content line so that no line exceeds the maximum length;
+// continuation lines start with a single space.
+func icsFold(line string) string {
+	if len(line) <= icsMaxLineLen {
+		return line
+	}
+
+	builder := strings.Builder{}
+	lineLen := 0
+	for _, r := range line {
+		runeLen := len(string(r))
+		if lineLen+runeLen > icsMaxLineLen {
+			builder.WriteString("\r\n ")
+			// The leading space counts towards the length of the continuation line.
+			lineLen = 1
+		}
+		builder.WriteRune(r)
+		lineLen += runeLen
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,125 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerduties
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputLookaheadICS(t *testing.T) {
+	genesis := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := &command{
+		generated: genesis.Add(time.Hour),
+		lookaheadResults: &lookaheadResults{
+			Proposals: []*proposalDuty{
+				{
+					Slot:      330,
+					Epoch:     10,
+					Validator: 5,
+					Start:     genesis.Add(330 * 12 * time.Second),
+					End:       genesis.Add(331 * 12 * time.Second),
+				},
+			},
+			SyncCommittees: []*syncCommitteeDuty{
+				{
+					Period:     1,
+					FromEpoch:  256,
+					ToEpoch:    511,
+					Validators: []phase0.ValidatorIndex{5, 12},
+					Start:      genesis.Add(256 * 32 * 12 * time.Second),
+					End:        genesis.Add(512 * 32 * 12 * time.Second),
+				},
+			},
+		},
+	}
+
+	res, err := c.outputLookaheadICS(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Weald Technology//ethdo//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:proposal-330-5@ethdo",
+		"DTSTAMP:20240101T010000Z",
+		"DTSTART:20240101T010600Z",
+		"DTEND:20240101T010612Z",
+		"SUMMARY:Validator 5 block proposal",
+		"DESCRIPTION:Validator 5 proposes the block for slot 330 in epoch 10.",
+		"TRANSP:OPAQUE",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:sync-committee-1-5-12@ethdo",
+		"DTSTAMP:20240101T010000Z",
+		"DTSTART:20240102T031824Z",
+		"DTEND:20240103T063648Z",
+		"SUMMARY:Sync committee period 1",
+		"DESCRIPTION:Validators 5\\, 12 are members of the sync committee for period ",
+		" 1\\, epochs 256 to 511.",
+		"TRANSP:OPAQUE",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")+"\r\n", res)
+}
+
+func TestICSFold(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "Short",
+			input: "SUMMARY:short",
+		},
+		{
+			name:  "Exact",
+			input: "DESCRIPTION:" + strings.Repeat("a", icsMaxLineLen-len("DESCRIPTION:")),
+		},
+		{
+			name:  "Long",
+			input: "DESCRIPTION:" + strings.Repeat("a", 200),
+		},
+		{
+			name:  "Multibyte",
+			input: "DESCRIPTION:" + strings.Repeat("Ξ", 100),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := icsFold(test.input)
+			lines := strings.Split(res, "\r\n")
+			for i, line := range lines {
+				require.LessOrEqual(t, len(line), icsMaxLineLen)
+				if i > 0 {
+					require.True(t, strings.HasPrefix(line, " "))
+				}
+			}
+			// Unfolding returns the original line.
+			require.Equal(t, test.input, strings.ReplaceAll(res, "\r\n ", ""))
+		})
+	}
+}
+
+func TestICSEscape(t *testing.T) {
+	require.Equal(t, `a\\b\;c\,d\ne`, icsEscape("a\\b;c,d\ne"))
+}
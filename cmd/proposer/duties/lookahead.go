@@ -0,0 +1,137 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proposerduties
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// lookaheadResults contains the upcoming duties of a set of validators.
+type lookaheadResults struct {
+	Proposals      []*proposalDuty      `json:"proposals"`
+	SyncCommittees []*syncCommitteeDuty `json:"sync_committees"`
+}
+
+// proposalDuty is a block proposal by a validator.
+type proposalDuty struct {
+	Slot      phase0.Slot           `json:"slot"`
+	Epoch     phase0.Epoch          `json:"epoch"`
+	Validator phase0.ValidatorIndex `json:"validator_index"`
+	Start     time.Time             `json:"start"`
+	End       time.Time             `json:"end"`
+}
+
+// syncCommitteeDuty is the membership of validators in a sync committee.
+type syncCommitteeDuty struct {
+	Period     uint64                  `json:"period"`
+	FromEpoch  phase0.Epoch            `json:"from_epoch"`
+	ToEpoch    phase0.Epoch            `json:"to_epoch"`
+	Validators []phase0.ValidatorIndex `json:"validators"`
+	Start      time.Time               `json:"start"`
+	End        time.Time               `json:"end"`
+}
+
+// processLookahead obtains the proposal duties of the validators for the current and
+// next epoch, and their sync committee duties for the current and next period.
+// These are the furthest ahead that the beacon node API provides duties.
+func (c *command) processLookahead(ctx context.Context) error {
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+	if len(validators) == 0 {
+		return errors.New("no validators found")
+	}
+	indices := make([]phase0.ValidatorIndex, len(validators))
+	for i := range validators {
+		indices[i] = validators[i].Index
+	}
+	sort.Slice(indices, func(i int, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	c.generated = time.Now()
+	c.lookaheadResults = &lookaheadResults{
+		Proposals:      make([]*proposalDuty, 0),
+		SyncCommittees: make([]*syncCommitteeDuty, 0),
+	}
+
+	currentEpoch := c.chainTime.CurrentEpoch()
+	for epoch := currentEpoch; epoch <= currentEpoch+1; epoch++ {
+		response, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+			Epoch:   epoch,
+			Indices: indices,
+		})
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain proposer duties for epoch %d", epoch))
+		}
+		for _, duty := range response.Data {
+			c.lookaheadResults.Proposals = append(c.lookaheadResults.Proposals, &proposalDuty{
+				Slot:      duty.Slot,
+				Epoch:     epoch,
+				Validator: duty.ValidatorIndex,
+				Start:     c.chainTime.StartOfSlot(duty.Slot),
+				End:       c.chainTime.StartOfSlot(duty.Slot + 1),
+			})
+		}
+	}
+	sort.Slice(c.lookaheadResults.Proposals, func(i int, j int) bool {
+		return c.lookaheadResults.Proposals[i].Slot < c.lookaheadResults.Proposals[j].Slot
+	})
+
+	currentPeriod := c.chainTime.CurrentSyncCommitteePeriod()
+	for period := currentPeriod; period <= currentPeriod+1; period++ {
+		if period < c.chainTime.AltairInitialSyncCommitteePeriod() {
+			// No sync committees before Altair.
+			continue
+		}
+		fromEpoch := c.chainTime.FirstEpochOfSyncPeriod(period)
+		toEpoch := c.chainTime.FirstEpochOfSyncPeriod(period+1) - 1
+		response, err := c.syncCommitteeDutiesProvider.SyncCommitteeDuties(ctx, &api.SyncCommitteeDutiesOpts{
+			Epoch:   fromEpoch,
+			Indices: indices,
+		})
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee duties for period %d", period))
+		}
+		if len(response.Data) == 0 {
+			continue
+		}
+		duty := &syncCommitteeDuty{
+			Period:     period,
+			FromEpoch:  fromEpoch,
+			ToEpoch:    toEpoch,
+			Validators: make([]phase0.ValidatorIndex, 0, len(response.Data)),
+			Start:      c.chainTime.StartOfEpoch(fromEpoch),
+			End:        c.chainTime.StartOfEpoch(toEpoch + 1),
+		}
+		for _, syncCommitteeDuty := range response.Data {
+			duty.Validators = append(duty.Validators, syncCommitteeDuty.ValidatorIndex)
+		}
+		sort.Slice(duty.Validators, func(i int, j int) bool {
+			return duty.Validators[i] < duty.Validators[j]
+		})
+		c.lookaheadResults.SyncCommittees = append(c.lookaheadResults.SyncCommittees, duty)
+	}
+
+	return nil
+}
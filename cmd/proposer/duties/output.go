@@ -25,6 +25,17 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
+	if c.lookaheadResults != nil {
+		switch {
+		case c.jsonOutput:
+			return c.outputLookaheadJSON(ctx)
+		case c.icsOutput:
+			return c.outputLookaheadICS(ctx)
+		default:
+			return c.outputLookaheadTxt(ctx)
+		}
+	}
+
 	if c.jsonOutput {
 		return c.outputJSON(ctx)
 	}
@@ -73,3 +84,48 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 
 	return strings.TrimSuffix(builder.String(), "\n"), nil
 }
+
+func (c *command) outputLookaheadJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.lookaheadResults)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *command) outputLookaheadTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	if len(c.lookaheadResults.Proposals) == 0 {
+		builder.WriteString("No proposals\n")
+	} else {
+		builder.WriteString("Proposals:\n")
+		for _, proposal := range c.lookaheadResults.Proposals {
+			builder.WriteString(fmt.Sprintf("  Slot %d (%s): validator %d\n",
+				proposal.Slot,
+				proposal.Start.Format("2006-01-02 15:04:05"),
+				proposal.Validator,
+			))
+		}
+	}
+
+	if len(c.lookaheadResults.SyncCommittees) == 0 {
+		builder.WriteString("No sync committees\n")
+	} else {
+		builder.WriteString("Sync committees:\n")
+		for _, syncCommittee := range c.lookaheadResults.SyncCommittees {
+			validators := make([]string, len(syncCommittee.Validators))
+			for i := range syncCommittee.Validators {
+				validators[i] = fmt.Sprintf("%d", syncCommittee.Validators[i])
+			}
+			builder.WriteString(fmt.Sprintf("  Period %d (%s - %s): validators %s\n",
+				syncCommittee.Period,
+				syncCommittee.Start.Format("2006-01-02 15:04:05"),
+				syncCommittee.End.Format("2006-01-02 15:04:05"),
+				strings.Join(validators, ", "),
+			))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
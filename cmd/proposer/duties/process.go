@@ -30,6 +30,10 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
+	if c.lookahead {
+		return c.processLookahead(ctx)
+	}
+
 	if c.slot != "" {
 		return c.processSlot(ctx)
 	}
@@ -110,6 +114,14 @@ func (c *command) setup(ctx context.Context) error {
 	if !isProvider {
 		return errors.New("connection does not provide proposer duties")
 	}
+	c.syncCommitteeDutiesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteeDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committee duties")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
 
 	return nil
 }
@@ -28,6 +28,12 @@ var proposerDutiesCmd = &cobra.Command{
 
     ethdo proposer duties --epoch=12345
 
+Alternatively, the upcoming proposal duties of a set of validators for the current and next epoch, along with their sync committee duties for the current and next sync committee period, can be obtained.  For example:
+
+    ethdo proposer duties --lookahead --validators=1,2,3
+
+The lookahead can be output as iCalendar events with --ics, or as JSON with --json.
+
 In quiet mode this will return 0 if duties can be obtained, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := proposerduties.Run(cmd)
@@ -38,7 +44,12 @@ In quiet mode this will return 0 if duties can be obtained, otherwise 1.`,
 			return nil
 		}
 		if res != "" {
-			fmt.Println(res)
+			if viper.GetBool("ics") {
+				// iCalendar output already ends with CRLF.
+				fmt.Print(res)
+			} else {
+				fmt.Println(res)
+			}
 		}
 		return nil
 	},
@@ -49,6 +60,9 @@ func init() {
 	proposerFlags(proposerDutiesCmd)
 	proposerDutiesCmd.Flags().String("epoch", "", "the epoch for which to fetch duties")
 	proposerDutiesCmd.Flags().String("slot", "", "the slot for which to fetch duties")
+	proposerDutiesCmd.Flags().Bool("lookahead", false, "fetch upcoming proposal and sync committee duties for validators")
+	proposerDutiesCmd.Flags().StringSlice("validators", nil, "the list of validators for which to fetch upcoming duties")
+	proposerDutiesCmd.Flags().Bool("ics", false, "output upcoming duties as iCalendar events")
 }
 
 func proposerDutiesBindings(cmd *cobra.Command) {
@@ -58,4 +72,13 @@ func proposerDutiesBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("slot", cmd.Flags().Lookup("slot")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("lookahead", cmd.Flags().Lookup("lookahead")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("ics", cmd.Flags().Lookup("ics")); err != nil {
+		panic(err)
+	}
 }
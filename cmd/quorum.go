@@ -0,0 +1,49 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// quorumClients connects to each of the beacon nodes in the connection individually,
+// for cross-checking responses when a quorum is requested.
+func quorumClients(ctx context.Context) []eth2client.Service {
+	clients, err := util.ConnectToBeaconNodes(ctx, &util.ConnectOpts{
+		Address:       viper.GetString("connection"),
+		Timeout:       viper.GetDuration("timeout"),
+		AllowInsecure: viper.GetBool("allow-insecure-connections"),
+		LogFallback:   !viper.GetBool("quiet"),
+		Quorum:        viper.GetInt("quorum"),
+	})
+	errCheck(err, "Failed to connect to Ethereum 2 beacon nodes")
+
+	return clients
+}
+
+// reportDisagreements reports beacon nodes that disagreed with the quorum.
+func reportDisagreements(disagreements []*util.QuorumDisagreement) {
+	if viper.GetBool("quiet") {
+		return
+	}
+	for _, disagreement := range disagreements {
+		fmt.Fprintf(os.Stderr, "Beacon node disagrees with quorum: %s\n", disagreement.String())
+	}
+}
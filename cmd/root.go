@@ -45,42 +45,64 @@ var RootCmd = &cobra.Command{
 
 // bindings are the command-specific bindings.
 var bindings = map[string]func(cmd *cobra.Command){
-	"account/create":     accountCreateBindings,
-	"account/derive":     accountDeriveBindings,
-	"account/import":     accountImportBindings,
-	"attester/duties":    attesterDutiesBindings,
-	"attester/inclusion": attesterInclusionBindings,
-	"block/analyze":      blockAnalyzeBindings,
-	"block/info":         blockInfoBindings,
-	"chain/eth1votes":    chainEth1VotesBindings,
-	"chain/info":         chainInfoBindings,
-	"chain/queues":       chainQueuesBindings,
-	"chain/spec":         chainSpecBindings,
-	"chain/time":         chainTimeBindings,
+	"account/create":            accountCreateBindings,
+	"account/derive":            accountDeriveBindings,
+	"account/dkg/contribute":    accountDKGContributeBindings,
+	"account/dkg/finalize":      accountDKGFinalizeBindings,
+	"account/dkg/init":          accountDKGInitBindings,
+	"account/dkg/register":      accountDKGRegisterBindings,
+	"account/import":            accountImportBindings,
+	"account/passphrase/change": accountPassphraseChangeBindings,
+	"account/scan":              accountScanBindings,
+	"attester/duties":           attesterDutiesBindings,
+	"attester/inclusion":        attesterInclusionBindings,
+	"block/analyze":             blockAnalyzeBindings,
+	"block/info":                blockInfoBindings,
+	"chain/eth1votes":           chainEth1VotesBindings,
+	"chain/info":                chainInfoBindings,
+	"chain/queues":              chainQueuesBindings,
+	"chain/spec":                chainSpecBindings,
+	"chain/time":                chainTimeBindings,
 	"chain/verify/signedcontributionandproof": chainVerifySignedContributionAndProofBindings,
-	"epoch/summary":             epochSummaryBindings,
-	"exit/verify":               exitVerifyBindings,
-	"node/events":               nodeEventsBindings,
-	"proposer/duties":           proposerDutiesBindings,
-	"slot/time":                 slotTimeBindings,
-	"synccommittee/inclusion":   synccommitteeInclusionBindings,
-	"synccommittee/members":     synccommitteeMembersBindings,
-	"validator/credentials/get": validatorCredentialsGetBindings,
-	"validator/credentials/set": validatorCredentialsSetBindings,
-	"validator/depositdata":     validatorDepositdataBindings,
-	"validator/duties":          validatorDutiesBindings,
-	"validator/exit":            validatorExitBindings,
-	"validator/info":            validatorInfoBindings,
-	"validator/keycheck":        validatorKeycheckBindings,
-	"validator/summary":         validatorSummaryBindings,
-	"validator/yield":           validatorYieldBindings,
-	"validator/expectation":     validatorExpectationBindings,
-	"validator/withdrawal":      validatorWithdrawalBindings,
-	"wallet/batch":              walletBatchBindings,
-	"wallet/create":             walletCreateBindings,
-	"wallet/import":             walletImportBindings,
-	"wallet/sharedexport":       walletSharedExportBindings,
-	"wallet/sharedimport":       walletSharedImportBindings,
+	"epoch/summary":                epochSummaryBindings,
+	"exit/verify":                  exitVerifyBindings,
+	"mnemonic/recover":             mnemonicRecoverBindings,
+	"mnemonic/share":               mnemonicShareBindings,
+	"monitor":                      monitorBindings,
+	"node/events":                  nodeEventsBindings,
+	"offline/broadcast":            offlineBroadcastBindings,
+	"offline/prepare":              offlinePrepareBindings,
+	"offline/sign":                 offlineSignBindings,
+	"proposer/duties":              proposerDutiesBindings,
+	"slashingprotection/inspect":   slashingprotectionInspectBindings,
+	"slashingprotection/merge":     slashingprotectionMergeBindings,
+	"slashingprotection/minify":    slashingprotectionMinifyBindings,
+	"slashingprotection/validate":  slashingprotectionValidateBindings,
+	"slot/time":                    slotTimeBindings,
+	"synccommittee/inclusion":      synccommitteeInclusionBindings,
+	"synccommittee/members":        synccommitteeMembersBindings,
+	"synccommittee/summary":        synccommitteeSummaryBindings,
+	"validator/consolidate":        validatorConsolidateBindings,
+	"validator/credentials/get":    validatorCredentialsGetBindings,
+	"validator/credentials/set":    validatorCredentialsSetBindings,
+	"validator/depositdata":        validatorDepositdataBindings,
+	"validator/duties":             validatorDutiesBindings,
+	"validator/exit":               validatorExitBindings,
+	"validator/info":               validatorInfoBindings,
+	"validator/keycheck":           validatorKeycheckBindings,
+	"validator/rewards":            validatorRewardsBindings,
+	"validator/summary":            validatorSummaryBindings,
+	"validator/yield":              validatorYieldBindings,
+	"validator/expectation":        validatorExpectationBindings,
+	"validator/withdrawal":         validatorWithdrawalBindings,
+	"validator/withdrawal-request": validatorWithdrawalRequestBindings,
+	"wallet/batch":                 walletBatchBindings,
+	"wallet/create":                walletCreateBindings,
+	"wallet/export":                walletExportBindings,
+	"wallet/import":                walletImportBindings,
+	"wallet/rekey":                 walletRekeyBindings,
+	"wallet/sharedexport":          walletSharedExportBindings,
+	"wallet/sharedimport":          walletSharedImportBindings,
 }
 
 func persistentPreRunE(cmd *cobra.Command, _ []string) error {
@@ -220,10 +242,18 @@ func addPersistentFlags() {
 	if err := viper.BindPFlag("debug", RootCmd.PersistentFlags().Lookup("debug")); err != nil {
 		panic(err)
 	}
-	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint")
+	RootCmd.PersistentFlags().String("connection", "", "URL to an Ethereum 2 node's REST API endpoint; multiple comma-separated URLs will fail over between nodes")
 	if err := viper.BindPFlag("connection", RootCmd.PersistentFlags().Lookup("connection")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().Int("quorum", 0, "the number of beacon nodes in --connection that must agree on data for commands that support cross-checking")
+	if err := viper.BindPFlag("quorum", RootCmd.PersistentFlags().Lookup("quorum")); err != nil {
+		panic(err)
+	}
+	RootCmd.PersistentFlags().String("cache-dir", "", "directory in which to cache finalized chain data for analysis commands; no cache is used if not supplied")
+	if err := viper.BindPFlag("cache-dir", RootCmd.PersistentFlags().Lookup("cache-dir")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Duration("timeout", 30*time.Second, "the time after which a network request will be considered failed.  Increase this if you are running on an error-prone, high-latency or low-bandwidth connection")
 	if err := viper.BindPFlag("timeout", RootCmd.PersistentFlags().Lookup("timeout")); err != nil {
 		panic(err)
@@ -244,6 +274,10 @@ func addPersistentFlags() {
 	if err := viper.BindPFlag("server-ca-cert", RootCmd.PersistentFlags().Lookup("server-ca-cert")); err != nil {
 		panic(err)
 	}
+	RootCmd.PersistentFlags().String("signer-url", "", "URL of a Web3Signer-compatible remote signer, for commands that support remote signing")
+	if err := viper.BindPFlag("signer-url", RootCmd.PersistentFlags().Lookup("signer-url")); err != nil {
+		panic(err)
+	}
 	RootCmd.PersistentFlags().Bool("allow-weak-passphrases", false, "allow passphrases that use common words, are short, or generally considered weak")
 	if err := viper.BindPFlag("allow-weak-passphrases", RootCmd.PersistentFlags().Lookup("allow-weak-passphrases")); err != nil {
 		panic(err)
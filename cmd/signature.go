@@ -14,9 +14,13 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/signing"
 )
 
 // signatureCmd represents the signature command.
@@ -32,8 +36,10 @@ func init() {
 }
 
 var (
-	dataFlag   *pflag.Flag
-	domainFlag *pflag.Flag
+	dataFlag      *pflag.Flag
+	domainFlag    *pflag.Flag
+	typeFlag      *pflag.Flag
+	chainInfoFlag *pflag.Flag
 )
 
 func signatureFlags(cmd *cobra.Command) {
@@ -53,3 +59,21 @@ func signatureFlags(cmd *cobra.Command) {
 		cmd.Flags().AddFlag(domainFlag)
 	}
 }
+
+func signatureTypedFlags(cmd *cobra.Command) {
+	if typeFlag == nil {
+		cmd.Flags().String("type", "", fmt.Sprintf("the type of the object supplied in --data (%s)", strings.Join(signing.ObjectTypes(), ", ")))
+		typeFlag = cmd.Flags().Lookup("type")
+		if err := viper.BindPFlag("signature-type", typeFlag); err != nil {
+			panic(err)
+		}
+		cmd.Flags().String("chain-info", "", "the file from which to read chain information for typed objects (if not supplied, obtained from the beacon node)")
+		chainInfoFlag = cmd.Flags().Lookup("chain-info")
+		if err := viper.BindPFlag("signature-chain-info", chainInfoFlag); err != nil {
+			panic(err)
+		}
+	} else {
+		cmd.Flags().AddFlag(typeFlag)
+		cmd.Flags().AddFlag(chainInfoFlag)
+	}
+}
@@ -15,12 +15,18 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
+	"github.com/attestantio/go-eth2-client/spec/capella"
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/signing/web3signer"
 	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-bytesutil"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
@@ -30,16 +36,32 @@ import (
 // signatureSignCmd represents the signature sign command.
 var signatureSignCmd = &cobra.Command{
 	Use:   "sign",
-	Short: "Sign a 32-byte piece of data",
+	Short: "Sign a 32-byte piece of data or a beacon object",
 	Long: `Sign presented data.  For example:
 
     ethdo signature sign --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --account="Personal wallet/Operations" --passphrase="my account passphrase"
 
+Beacon objects can be signed by supplying their type and JSON, in which case the root and domain are calculated and the signed object is output.  For example:
+
+    ethdo signature sign --type=voluntary_exit --data='{"epoch":"194048","validator_index":"12345"}' --account="Validators/1" --passphrase="my account passphrase"
+
+The chain information used to calculate the domain is obtained from the beacon node, or from the file supplied with --chain-info.
+
+Voluntary exits and credentials changes can also be signed by a Web3Signer-compatible remote signer holding the key, by supplying its URL with --signer-url and the public key with --account.
+
 In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
+		if viper.GetString("signature-type") != "" {
+			signed, err := signatureSignTyped(ctx)
+			errCheck(err, "Failed to sign")
+			outputIf(!viper.GetBool("quiet"), signed)
+			os.Exit(_exitSuccess)
+		}
+
+		assert(viper.GetString("signer-url") == "", "--signer-url requires --type")
 		assert(viper.GetString("signature-data") != "", "--data is required")
 		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
 		errCheck(err, "Failed to parse data")
@@ -53,13 +75,7 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 		}
 		outputIf(viper.GetBool("debug"), fmt.Sprintf("Domain is %#x", domain))
 
-		var account e2wtypes.Account
-		switch {
-		case viper.GetString("account") != "":
-			account, err = util.ParseAccount(ctx, viper.GetString("account"), util.GetPassphrases(), true)
-		case viper.GetString("private-key") != "":
-			account, err = util.ParseAccount(ctx, viper.GetString("private-key"), nil, true)
-		}
+		account, err := signatureSignAccount(ctx)
 		errCheck(err, "Failed to obtain account")
 
 		var specDomain spec.Domain
@@ -75,7 +91,124 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 	},
 }
 
+// signatureSignAccount obtains the account with which to sign.
+func signatureSignAccount(ctx context.Context) (e2wtypes.Account, error) {
+	switch {
+	case viper.GetString("account") != "":
+		return util.ParseAccount(ctx, viper.GetString("account"), util.GetPassphrases(), true)
+	case viper.GetString("private-key") != "":
+		return util.ParseAccount(ctx, viper.GetString("private-key"), nil, true)
+	default:
+		return nil, errors.New("--account or --private-key is required")
+	}
+}
+
+// signatureSignTyped signs a typed object, returning the JSON of the signed object.
+func signatureSignTyped(ctx context.Context) (string, error) {
+	if domainFlag.Changed {
+		return "", errors.New("--domain cannot be used with --type")
+	}
+
+	data, err := signatureTypedData()
+	if err != nil {
+		return "", err
+	}
+	obj, err := signing.ParseTypedObject(viper.GetString("signature-type"), data)
+	if err != nil {
+		return "", err
+	}
+
+	chainInfo, err := signatureChainInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	root, domain, err := signing.TypedObjectRootAndDomain(viper.GetString("signature-type"), obj, chainInfo)
+	if err != nil {
+		return "", err
+	}
+	outputIf(viper.GetBool("debug"), fmt.Sprintf("Root is %#x, domain is %#x", root, domain))
+
+	var signature spec.BLSSignature
+	if viper.GetString("signer-url") != "" {
+		signature, err = signatureSignRemote(ctx, viper.GetString("signature-type"), obj, chainInfo, domain)
+	} else {
+		var account e2wtypes.Account
+		account, err = signatureSignAccount(ctx)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to obtain account")
+		}
+		signature, err = signing.SignRoot(ctx, account, nil, root, domain)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	res, err := json.Marshal(&signing.SignedTypedObject{
+		Message:   obj,
+		Signature: signature,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate JSON")
+	}
+
+	return string(res), nil
+}
+
+// signatureSignRemote signs a typed object with a remote signer.
+func signatureSignRemote(ctx context.Context,
+	name string,
+	obj signing.TypedObject,
+	chainInfo *beacon.ChainInfo,
+	domain spec.Domain,
+) (
+	spec.BLSSignature,
+	error,
+) {
+	if viper.GetString("account") == "" {
+		return spec.BLSSignature{}, errors.New("--account is required with --signer-url")
+	}
+	account, err := util.ParseAccount(ctx, viper.GetString("account"), nil, false)
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to obtain account")
+	}
+	pubKey, err := util.BestPublicKey(account)
+	if err != nil {
+		return spec.BLSSignature{}, err
+	}
+	blsPubKey := spec.BLSPubKey{}
+	copy(blsPubKey[:], pubKey.Marshal())
+
+	signer, err := web3signer.New(
+		web3signer.WithURL(viper.GetString("signer-url")),
+		web3signer.WithTimeout(viper.GetDuration("timeout")),
+	)
+	if err != nil {
+		return spec.BLSSignature{}, errors.Wrap(err, "failed to set up remote signer")
+	}
+
+	switch o := obj.(type) {
+	case *spec.VoluntaryExit:
+		return signer.SignVoluntaryExit(ctx, blsPubKey, signatureForkInfo(chainInfo, chainInfo.ExitForkVersion), o, domain)
+	case *capella.BLSToExecutionChange:
+		return signer.SignBLSToExecutionChange(ctx, blsPubKey, signatureForkInfo(chainInfo, chainInfo.GenesisForkVersion), o, domain)
+	default:
+		return spec.BLSSignature{}, fmt.Errorf("remote signer cannot sign objects of type %s", name)
+	}
+}
+
+// signatureForkInfo creates the fork information for a remote signer at the given fork version.
+func signatureForkInfo(chainInfo *beacon.ChainInfo, forkVersion spec.Version) *web3signer.ForkInfo {
+	return &web3signer.ForkInfo{
+		Fork: &spec.Fork{
+			PreviousVersion: forkVersion,
+			CurrentVersion:  forkVersion,
+		},
+		GenesisValidatorsRoot: chainInfo.GenesisValidatorsRoot,
+	}
+}
+
 func init() {
 	signatureCmd.AddCommand(signatureSignCmd)
 	signatureFlags(signatureSignCmd)
+	signatureTypedFlags(signatureSignCmd)
 }
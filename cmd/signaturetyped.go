@@ -0,0 +1,83 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// signatureTypedData obtains the JSON for a typed object, which is supplied either directly or as a filename.
+func signatureTypedData() ([]byte, error) {
+	input := viper.GetString("signature-data")
+	if input == "" {
+		return nil, errors.New("--data is required")
+	}
+	if strings.HasPrefix(strings.TrimSpace(input), "{") {
+		return []byte(input), nil
+	}
+
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read data file")
+	}
+
+	return data, nil
+}
+
+// signatureChainInfo obtains the chain information required to calculate signature domains
+// for typed objects, either from a file or from the beacon node.
+func signatureChainInfo(ctx context.Context) (*beacon.ChainInfo, error) {
+	if viper.GetString("signature-chain-info") != "" {
+		data, err := os.ReadFile(viper.GetString("signature-chain-info"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read chain info file")
+		}
+		chainInfo := &beacon.ChainInfo{}
+		if err := json.Unmarshal(data, chainInfo); err != nil {
+			return nil, errors.Wrap(err, "chain info file invalid")
+		}
+
+		return chainInfo, nil
+	}
+
+	consensusClient, err := util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       viper.GetString("connection"),
+		Timeout:       viper.GetDuration("timeout"),
+		AllowInsecure: viper.GetBool("allow-insecure-connections"),
+		LogFallback:   !viper.GetBool("quiet"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return beacon.ObtainSigningInfoFromNode(ctx, consensusClient, chainTime)
+}
@@ -19,8 +19,10 @@ import (
 	"os"
 
 	spec "github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/signing"
 	"github.com/wealdtech/ethdo/util"
 	"github.com/wealdtech/go-bytesutil"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
@@ -40,11 +42,25 @@ var signatureVerifyCmd = &cobra.Command{
 
     ethdo signature verify --data=0x5f24e819400c6a8ee2bfc014343cd971b7eb707320025a7bcd83e621e26c35b7 --signature=0x8888... --account="Personal wallet/Operations"
 
+Signed beacon objects, as output by "ethdo signature sign --type", can be verified by supplying their type.  For example:
+
+    ethdo signature verify --type=voluntary_exit --data='{"message":{"epoch":"194048","validator_index":"12345"},"signature":"0x8888..."}' --public-key=0xa9ca...
+
+If the data contains only the object then the signature must be supplied with --signature.
+
 In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("timeout"))
 		defer cancel()
 
+		if viper.GetString("signature-type") != "" {
+			verified, err := signatureVerifyTyped(ctx)
+			errCheck(err, "Failed to verify data")
+			assert(verified, "Failed to verify")
+			outputIf(viper.GetBool("verbose"), "Verified")
+			os.Exit(_exitSuccess)
+		}
+
 		assert(viper.GetString("signature-data") != "", "--data is required")
 		data, err := bytesutil.FromHexString(viper.GetString("signature-data"))
 		errCheck(err, "Failed to parse data")
@@ -63,15 +79,7 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 			assert(len(domain) == 32, "Domain data invalid")
 		}
 
-		var account e2wtypes.Account
-		switch {
-		case viper.GetString("account") != "":
-			account, err = util.ParseAccount(ctx, viper.GetString("account"), nil, false)
-		case viper.GetString("private-key") != "":
-			account, err = util.ParseAccount(ctx, viper.GetString("private-key"), nil, false)
-		case viper.GetString("public-key") != "":
-			account, err = util.ParseAccount(ctx, viper.GetString("public-key"), nil, false)
-		}
+		account, err := signatureVerifyAccount(ctx)
 		errCheck(err, "Failed to obtain account")
 		outputIf(viper.GetBool("debug"), fmt.Sprintf("Public key is %#x", account.PublicKey().Marshal()))
 
@@ -88,9 +96,82 @@ In quiet mode this will return 0 if the data can be signed, otherwise 1.`,
 	},
 }
 
+// signatureVerifyAccount obtains the account against which to verify.
+func signatureVerifyAccount(ctx context.Context) (e2wtypes.Account, error) {
+	switch {
+	case viper.GetString("account") != "":
+		return util.ParseAccount(ctx, viper.GetString("account"), nil, false)
+	case viper.GetString("private-key") != "":
+		return util.ParseAccount(ctx, viper.GetString("private-key"), nil, false)
+	case viper.GetString("public-key") != "":
+		return util.ParseAccount(ctx, viper.GetString("public-key"), nil, false)
+	default:
+		return nil, errors.New("--account, --private-key or --public-key is required")
+	}
+}
+
+// signatureVerifyTyped verifies a signed typed object.
+func signatureVerifyTyped(ctx context.Context) (bool, error) {
+	if domainFlag.Changed {
+		return false, errors.New("--domain cannot be used with --type")
+	}
+
+	data, err := signatureTypedData()
+	if err != nil {
+		return false, err
+	}
+	var signed *signing.SignedTypedObject
+	if signatureVerifySignature != "" {
+		// Data is the unsigned object, with the signature supplied separately.
+		obj, err := signing.ParseTypedObject(viper.GetString("signature-type"), data)
+		if err != nil {
+			return false, err
+		}
+		signatureBytes, err := bytesutil.FromHexString(signatureVerifySignature)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to parse signature")
+		}
+		if len(signatureBytes) != spec.SignatureLength {
+			return false, errors.New("incorrect length for signature")
+		}
+		signed = &signing.SignedTypedObject{
+			Message: obj,
+		}
+		copy(signed.Signature[:], signatureBytes)
+	} else {
+		signed, err = signing.ParseSignedTypedObject(viper.GetString("signature-type"), data)
+		if err != nil {
+			return false, err
+		}
+	}
+	// Copy the signature, as the BLS library cannot accept memory that is part of a larger structure.
+	signature, err := e2types.BLSSignatureFromBytes(append([]byte{}, signed.Signature[:]...))
+	if err != nil {
+		return false, errors.Wrap(err, "invalid signature")
+	}
+
+	chainInfo, err := signatureChainInfo(ctx)
+	if err != nil {
+		return false, err
+	}
+	root, domain, err := signing.TypedObjectRootAndDomain(viper.GetString("signature-type"), signed.Message, chainInfo)
+	if err != nil {
+		return false, err
+	}
+	outputIf(viper.GetBool("debug"), fmt.Sprintf("Root is %#x, domain is %#x", root, domain))
+
+	account, err := signatureVerifyAccount(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to obtain account")
+	}
+
+	return util.VerifyRoot(account, root, domain, signature)
+}
+
 func init() {
 	signatureCmd.AddCommand(signatureVerifyCmd)
 	signatureFlags(signatureVerifyCmd)
+	signatureTypedFlags(signatureVerifyCmd)
 	signatureVerifyCmd.Flags().StringVar(&signatureVerifySignature, "signature", "", "the signature to verify")
 	signatureVerifyCmd.Flags().StringVar(&signatureVerifySigner, "signer", "", "the public key of the signer (only if --account is not supplied)")
 }
@@ -0,0 +1,109 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectioninspect
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/wealdtech/ethdo/slashingprotection"
+)
+
+type jsonOutput struct {
+	GenesisValidatorsRoot string `json:"genesis_validators_root"`
+	Validators            int    `json:"validators"`
+	SignedBlocks          int    `json:"signed_blocks"`
+	SignedAttestations    int    `json:"signed_attestations"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(&jsonOutput{
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", c.interchange.GenesisValidatorsRoot),
+		Validators:            c.validators,
+		SignedBlocks:          c.signedBlocks,
+		SignedAttestations:    c.signedAttestations,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Genesis validators root: %#x\n", c.interchange.GenesisValidatorsRoot))
+	builder.WriteString(fmt.Sprintf("Validators: %d\n", c.validators))
+	builder.WriteString(fmt.Sprintf("Signed blocks: %d\n", c.signedBlocks))
+	builder.WriteString(fmt.Sprintf("Signed attestations: %d\n", c.signedAttestations))
+
+	if c.verbose {
+		for _, validator := range c.interchange.Data {
+			builder.WriteString(fmt.Sprintf("%#x:\n", validator.PubKey))
+			builder.WriteString(fmt.Sprintf("  Signed blocks: %d\n", len(validator.SignedBlocks)))
+			if slot, exists := latestSlot(validator); exists {
+				builder.WriteString(fmt.Sprintf("  Latest signed slot: %d\n", slot))
+			}
+			builder.WriteString(fmt.Sprintf("  Signed attestations: %d\n", len(validator.SignedAttestations)))
+			if source, target, exists := latestEpochs(validator); exists {
+				builder.WriteString(fmt.Sprintf("  Highest source epoch: %d\n", source))
+				builder.WriteString(fmt.Sprintf("  Highest target epoch: %d\n", target))
+			}
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func latestSlot(validator *slashingprotection.Validator) (phase0.Slot, bool) {
+	slot := phase0.Slot(0)
+	for _, block := range validator.SignedBlocks {
+		if block.Slot > slot {
+			slot = block.Slot
+		}
+	}
+
+	return slot, len(validator.SignedBlocks) > 0
+}
+
+func latestEpochs(validator *slashingprotection.Validator) (phase0.Epoch, phase0.Epoch, bool) {
+	source := phase0.Epoch(0)
+	target := phase0.Epoch(0)
+	for _, attestation := range validator.SignedAttestations {
+		if attestation.SourceEpoch > source {
+			source = attestation.SourceEpoch
+		}
+		if attestation.TargetEpoch > target {
+			target = attestation.TargetEpoch
+		}
+	}
+
+	return source, target, len(validator.SignedAttestations) > 0
+}
@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type jsonOutput struct {
+	Valid  bool     `json:"valid"`
+	Issues []string `json:"issues"`
+}
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.json {
+		return c.outputJSON(ctx)
+	}
+
+	return c.outputText(ctx)
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	output := &jsonOutput{
+		Valid:  len(c.issues) == 0,
+		Issues: make([]string, 0, len(c.issues)),
+	}
+	for _, issue := range c.issues {
+		output.Issues = append(output.Issues, issue.String())
+	}
+	data, err := json.Marshal(output)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputText(_ context.Context) (string, error) {
+	if len(c.issues) == 0 {
+		return "Interchange is valid", nil
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("Interchange has %d issue(s):\n", len(c.issues)))
+	for _, issue := range c.issues {
+		builder.WriteString(fmt.Sprintf("  %s\n", issue.String()))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
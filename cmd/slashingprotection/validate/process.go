@@ -0,0 +1,149 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionvalidate
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/slashingprotection"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	var err error
+	c.interchange, err = slashingprotection.ReadFile(c.file)
+	if err != nil {
+		return err
+	}
+
+	c.issues = slashingprotection.Check(c.interchange)
+
+	if c.offline {
+		return nil
+	}
+
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	genesisResponse, err := c.eth2Client.(eth2client.GenesisProvider).Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain genesis")
+	}
+
+	pubKeys := make([]phase0.BLSPubKey, 0, len(c.interchange.Data))
+	for _, validator := range c.interchange.Data {
+		pubKeys = append(pubKeys, validator.PubKey)
+	}
+	knownPubKeys := make(map[phase0.BLSPubKey]bool)
+	if len(pubKeys) > 0 {
+		validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+			State:   "head",
+			PubKeys: pubKeys,
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain validators")
+		}
+		for _, validator := range validatorsResponse.Data {
+			if validator.Validator != nil {
+				knownPubKeys[validator.Validator.PublicKey] = true
+			}
+		}
+	}
+
+	c.issues = append(c.issues, checkChain(c.interchange, genesisResponse.Data.GenesisValidatorsRoot, knownPubKeys, c.chainTime.CurrentSlot(), c.chainTime.CurrentEpoch())...)
+
+	return nil
+}
+
+// checkChain checks an interchange against the state of the chain.
+func checkChain(interchange *slashingprotection.Interchange,
+	genesisValidatorsRoot phase0.Root,
+	knownPubKeys map[phase0.BLSPubKey]bool,
+	currentSlot phase0.Slot,
+	currentEpoch phase0.Epoch,
+) []*slashingprotection.Issue {
+	issues := make([]*slashingprotection.Issue, 0)
+
+	if interchange.GenesisValidatorsRoot != genesisValidatorsRoot {
+		issues = append(issues, &slashingprotection.Issue{
+			Message: fmt.Sprintf("interchange has genesis validators root %#x; chain has %#x", interchange.GenesisValidatorsRoot, genesisValidatorsRoot),
+		})
+	}
+
+	for _, validator := range interchange.Data {
+		pubKey := validator.PubKey
+		if !knownPubKeys[pubKey] {
+			issues = append(issues, &slashingprotection.Issue{
+				PubKey:  &pubKey,
+				Message: "validator not known to the chain",
+			})
+		}
+		for _, block := range validator.SignedBlocks {
+			if block.Slot > currentSlot {
+				issues = append(issues, &slashingprotection.Issue{
+					PubKey:  &pubKey,
+					Message: fmt.Sprintf("block signed for future slot %d", block.Slot),
+				})
+			}
+		}
+		for _, attestation := range validator.SignedAttestations {
+			if attestation.TargetEpoch > currentEpoch {
+				issues = append(issues, &slashingprotection.Issue{
+					PubKey:  &pubKey,
+					Message: fmt.Sprintf("attestation signed for future target epoch %d", attestation.TargetEpoch),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validator information")
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotectionvalidate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/slashingprotection"
+	"github.com/wealdtech/ethdo/testutil"
+)
+
+const interchangeData = `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"},"data":[{"pubkey":"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c","signed_blocks":[{"slot":"100"},{"slot":"100","signing_root":"0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"}],"signed_attestations":[{"source_epoch":"2","target_epoch":"3"},{"source_epoch":"3","target_epoch":"10"}]}]}`
+
+func TestProcessOffline(t *testing.T) {
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "interchange.json")
+	require.NoError(t, os.WriteFile(file, []byte(interchangeData), 0o600))
+
+	c := &command{
+		offline: true,
+		file:    file,
+	}
+	require.NoError(t, c.process(ctx))
+	require.Len(t, c.issues, 1)
+	require.Equal(t, "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: multiple blocks signed for slot 100", c.issues[0].String())
+}
+
+func TestCheckChain(t *testing.T) {
+	interchange := &slashingprotection.Interchange{}
+	require.NoError(t, interchange.UnmarshalJSON([]byte(interchangeData)))
+	pubKey := interchange.Data[0].PubKey
+	genesisValidatorsRoot := phase0.Root(testutil.HexToBytes("0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"))
+
+	tests := []struct {
+		name                  string
+		genesisValidatorsRoot phase0.Root
+		knownPubKeys          map[phase0.BLSPubKey]bool
+		currentSlot           phase0.Slot
+		currentEpoch          phase0.Epoch
+		issues                []string
+	}{
+		{
+			name:                  "Good",
+			genesisValidatorsRoot: genesisValidatorsRoot,
+			knownPubKeys:          map[phase0.BLSPubKey]bool{pubKey: true},
+			currentSlot:           1000,
+			currentEpoch:          31,
+			issues:                []string{},
+		},
+		{
+			name:         "WrongChain",
+			knownPubKeys: map[phase0.BLSPubKey]bool{pubKey: true},
+			currentSlot:  1000,
+			currentEpoch: 31,
+			issues: []string{
+				"interchange has genesis validators root 0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673; chain has 0x0000000000000000000000000000000000000000000000000000000000000000",
+			},
+		},
+		{
+			name:                  "UnknownValidator",
+			genesisValidatorsRoot: genesisValidatorsRoot,
+			knownPubKeys:          map[phase0.BLSPubKey]bool{},
+			currentSlot:           1000,
+			currentEpoch:          31,
+			issues: []string{
+				"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: validator not known to the chain",
+			},
+		},
+		{
+			name:                  "Future",
+			genesisValidatorsRoot: genesisValidatorsRoot,
+			knownPubKeys:          map[phase0.BLSPubKey]bool{pubKey: true},
+			currentSlot:           99,
+			currentEpoch:          9,
+			issues: []string{
+				"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: block signed for future slot 100",
+				"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: block signed for future slot 100",
+				"0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c: attestation signed for future target epoch 10",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issues := checkChain(interchange, test.genesisValidatorsRoot, test.knownPubKeys, test.currentSlot, test.currentEpoch)
+			res := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				res = append(res, issue.String())
+			}
+			require.Equal(t, test.issues, res)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slashingprotectioninspect "github.com/wealdtech/ethdo/cmd/slashingprotection/inspect"
+)
+
+var slashingprotectionInspectCmd = &cobra.Command{
+	Use:   "inspect",
+	Short: "Inspect a slashing protection interchange file",
+	Long: `Inspect an EIP-3076 slashing protection interchange file.  For example:
+
+    ethdo slashingprotection inspect --file=interchange.json
+
+In quiet mode this will return 0 if the file can be read, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slashingprotectioninspect.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingprotectionCmd.AddCommand(slashingprotectionInspectCmd)
+	slashingprotectionFlags(slashingprotectionInspectCmd)
+	slashingprotectionInspectCmd.Flags().String("file", "", "path to the interchange file")
+}
+
+func slashingprotectionInspectBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
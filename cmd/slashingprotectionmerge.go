@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slashingprotectionmerge "github.com/wealdtech/ethdo/cmd/slashingprotection/merge"
+)
+
+var slashingprotectionMergeCmd = &cobra.Command{
+	Use:   "merge",
+	Short: "Merge slashing protection interchange files",
+	Long: `Merge EIP-3076 slashing protection interchange files, for example those exported from multiple validator clients.  For example:
+
+    ethdo slashingprotection merge --file=client1.json --file=client2.json >merged.json
+
+All files must be for the same chain.  In quiet mode this will return 0 if the files can be merged, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slashingprotectionmerge.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingprotectionCmd.AddCommand(slashingprotectionMergeCmd)
+	slashingprotectionFlags(slashingprotectionMergeCmd)
+	slashingprotectionMergeCmd.Flags().StringSlice("file", nil, "path to an interchange file (can be supplied multiple times)")
+}
+
+func slashingprotectionMergeBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
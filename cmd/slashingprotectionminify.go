@@ -0,0 +1,57 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slashingprotectionminify "github.com/wealdtech/ethdo/cmd/slashingprotection/minify"
+)
+
+var slashingprotectionMinifyCmd = &cobra.Command{
+	Use:   "minify",
+	Short: "Minify slashing protection interchange files",
+	Long: `Create a minimal EIP-3076 slashing protection interchange file, containing only the highest signed slot and highest signed source and target epochs for each validator.  For example:
+
+    ethdo slashingprotection minify --file=interchange.json >minified.json
+
+Multiple files can be supplied, in which case they are merged before minifying.  In quiet mode this will return 0 if the file can be minified, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slashingprotectionminify.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingprotectionCmd.AddCommand(slashingprotectionMinifyCmd)
+	slashingprotectionFlags(slashingprotectionMinifyCmd)
+	slashingprotectionMinifyCmd.Flags().StringSlice("file", nil, "path to an interchange file (can be supplied multiple times)")
+}
+
+func slashingprotectionMinifyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	slashingprotectionvalidate "github.com/wealdtech/ethdo/cmd/slashingprotection/validate"
+)
+
+var slashingprotectionValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate a slashing protection interchange file",
+	Long: `Validate an EIP-3076 slashing protection interchange file.  For example:
+
+    ethdo slashingprotection validate --file=interchange.json
+
+The file is checked for slashable entries and, unless --offline is supplied, against the chain for the genesis validators root, unknown validators and entries in the future.
+
+In quiet mode this will return 0 if the file is valid, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := slashingprotectionvalidate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	slashingprotectionCmd.AddCommand(slashingprotectionValidateCmd)
+	slashingprotectionFlags(slashingprotectionValidateCmd)
+	slashingprotectionValidateCmd.Flags().String("file", "", "path to the interchange file")
+	slashingprotectionValidateCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to check the file against the chain")
+}
+
+func slashingprotectionValidateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("file", cmd.Flags().Lookup("file")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", cmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+}
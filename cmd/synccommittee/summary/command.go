@@ -0,0 +1,86 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccommitteesummary
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Chain data cache.
+	cacheDir string
+
+	// Operation.
+	period     string
+	threshold  float64
+	jsonOutput bool
+	csvOutput  bool
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	specProvider           eth2client.SpecProvider
+	blocksProvider         eth2client.SignedBeaconBlockProvider
+	syncCommitteesProvider eth2client.SyncCommitteesProvider
+	validatorsProvider     eth2client.ValidatorsProvider
+
+	// Results.
+	summary *periodSummary
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:   viper.GetBool("quiet"),
+		verbose: viper.GetBool("verbose"),
+		debug:   viper.GetBool("debug"),
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+	c.cacheDir = viper.GetString("cache-dir")
+
+	c.period = viper.GetString("period")
+	c.threshold = viper.GetFloat64("threshold")
+	if c.threshold < 0 || c.threshold > 1 {
+		return nil, errors.New("threshold must be between 0 and 1")
+	}
+	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+	if c.jsonOutput && c.csvOutput {
+		return nil, errors.New("cannot specify both JSON and CSV output")
+	}
+
+	return c, nil
+}
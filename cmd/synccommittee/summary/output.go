@@ -0,0 +1,151 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccommitteesummary
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	switch {
+	case c.jsonOutput:
+		return c.outputJSON(ctx)
+	case c.csvOutput:
+		return c.outputCSV(ctx)
+	default:
+		return c.outputTxt(ctx)
+	}
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.summary)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+	writer := csv.NewWriter(&builder)
+
+	if err := writer.Write([]string{
+		"validator_index",
+		"positions",
+		"expected",
+		"included",
+		"missed",
+		"participation_rate",
+		"rewards_lost",
+		"below_threshold",
+		"missed_slots",
+	}); err != nil {
+		return "", err
+	}
+	for _, member := range c.summary.Members {
+		positions := make([]string, len(member.Positions))
+		for i := range member.Positions {
+			positions[i] = fmt.Sprintf("%d", member.Positions[i])
+		}
+		if err := writer.Write([]string{
+			fmt.Sprintf("%d", member.Validator),
+			strings.Join(positions, " "),
+			fmt.Sprintf("%d", member.Expected),
+			fmt.Sprintf("%d", member.Included),
+			fmt.Sprintf("%d", member.Missed),
+			fmt.Sprintf("%.4f", member.ParticipationRate),
+			fmt.Sprintf("%d", member.RewardsLost),
+			fmt.Sprintf("%t", member.BelowThreshold),
+			slotsString(member.MissedSlots, " "),
+		}); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Period %d (slots %d-%d):\n", c.summary.Period, c.summary.FromSlot, c.summary.ToSlot))
+	builder.WriteString(fmt.Sprintf("  Blocks: %d\n", c.summary.Blocks))
+	builder.WriteString(fmt.Sprintf("  Empty slots: %d\n", len(c.summary.EmptySlots)))
+	if c.verbose && len(c.summary.EmptySlots) > 0 {
+		builder.WriteString(fmt.Sprintf("    %s\n", slotsString(c.summary.EmptySlots, ", ")))
+	}
+	builder.WriteString(fmt.Sprintf("  Participation: %.2f%%\n", 100*c.summary.ParticipationRate))
+	builder.WriteString(fmt.Sprintf("  Estimated participant reward per block: %d Gwei\n", c.summary.ParticipantReward))
+
+	flagged := make([]*memberPerformance, 0)
+	for _, member := range c.summary.Members {
+		if member.BelowThreshold {
+			flagged = append(flagged, member)
+		}
+	}
+	if len(flagged) == 0 {
+		builder.WriteString(fmt.Sprintf("  No members below threshold of %.2f%%\n", 100*c.summary.Threshold))
+	} else {
+		builder.WriteString(fmt.Sprintf("  Members below threshold of %.2f%%:\n", 100*c.summary.Threshold))
+		for _, member := range flagged {
+			memberTxt(&builder, member, c.verbose)
+		}
+	}
+
+	if c.verbose {
+		builder.WriteString("  Members:\n")
+		for _, member := range c.summary.Members {
+			memberTxt(&builder, member, c.verbose)
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func memberTxt(builder *strings.Builder, member *memberPerformance, verbose bool) {
+	builder.WriteString(fmt.Sprintf("    Validator %d: included %d/%d (%.2f%%), estimated rewards lost %d Gwei\n",
+		member.Validator,
+		member.Included,
+		member.Expected,
+		100*member.ParticipationRate,
+		member.RewardsLost,
+	))
+	if verbose && len(member.MissedSlots) > 0 {
+		builder.WriteString(fmt.Sprintf("      Missed slots: %s\n", slotsString(member.MissedSlots, ", ")))
+	}
+}
+
+// slotsString returns the slots as a string with the given separator.
+func slotsString(slots []phase0.Slot, separator string) string {
+	res := make([]string, len(slots))
+	for i := range slots {
+		res[i] = fmt.Sprintf("%d", slots[i])
+	}
+
+	return strings.Join(res, separator)
+}
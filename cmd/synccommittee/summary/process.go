@@ -0,0 +1,250 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccommitteesummary
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	diskchaincache "github.com/wealdtech/ethdo/services/chaincache/disk"
+	"github.com/wealdtech/ethdo/services/chaintime"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	// Obtain information we need to process.
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	period, err := parsePeriod(c.chainTime, c.period)
+	if err != nil {
+		return err
+	}
+
+	firstEpoch := c.chainTime.FirstEpochOfSyncPeriod(period)
+	firstSlot := c.chainTime.FirstSlotOfEpoch(firstEpoch)
+	lastSlot := c.chainTime.FirstSlotOfEpoch(c.chainTime.FirstEpochOfSyncPeriod(period+1)) - 1
+	if lastSlot > c.chainTime.CurrentSlot() {
+		lastSlot = c.chainTime.CurrentSlot()
+	}
+
+	// The head state only holds the current and next sync committees, so
+	// earlier committees are obtained from the state at the start of their period.
+	stateID := "head"
+	if period < c.chainTime.CurrentSyncCommitteePeriod() {
+		stateID = fmt.Sprintf("%d", firstSlot)
+	}
+	syncCommitteeResponse, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{
+		State: stateID,
+		Epoch: &firstEpoch,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee information")
+	}
+	if syncCommitteeResponse.Data == nil {
+		return errors.New("no sync committee returned")
+	}
+
+	c.summary = newPeriodSummary(period, syncCommitteeResponse.Data.Validators, c.threshold)
+	c.summary.FromSlot = firstSlot
+	c.summary.ToSlot = lastSlot
+
+	for slot := firstSlot; slot <= lastSlot; slot++ {
+		if c.debug {
+			fmt.Printf("Processing slot %d\n", slot)
+		}
+		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				c.summary.addEmptySlot(slot)
+				continue
+			}
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		if blockResponse.Data == nil {
+			c.summary.addEmptySlot(slot)
+			continue
+		}
+		aggregate, err := blockResponse.Data.SyncAggregate()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain sync aggregate for slot %d", slot))
+		}
+		c.summary.addBlock(slot, aggregate)
+	}
+
+	participantReward, err := c.participantReward(ctx)
+	if err != nil {
+		return err
+	}
+	c.summary.finalize(participantReward)
+
+	return nil
+}
+
+// parsePeriod parses input to calculate the desired sync committee period.
+func parsePeriod(chainTime chaintime.Service, periodStr string) (uint64, error) {
+	currentPeriod := chainTime.CurrentSyncCommitteePeriod()
+	var period uint64
+	switch periodStr {
+	case "", "current", "-0":
+		period = currentPeriod
+	case "last":
+		if currentPeriod == 0 {
+			return 0, errors.New("no previous period")
+		}
+		period = currentPeriod - 1
+	default:
+		val, err := strconv.ParseInt(periodStr, 10, 64)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to parse period")
+		}
+		if val >= 0 {
+			period = uint64(val)
+		} else {
+			if uint64(-val) > currentPeriod {
+				return 0, fmt.Errorf("period %s is before genesis", periodStr)
+			}
+			period = currentPeriod - uint64(-val)
+		}
+	}
+
+	if period > currentPeriod {
+		return 0, fmt.Errorf("period %d is in the future", period)
+	}
+	if period < chainTime.AltairInitialSyncCommitteePeriod() {
+		return 0, fmt.Errorf("period %d is before sync committees were introduced", period)
+	}
+
+	return period, nil
+}
+
+// participantReward estimates the reward for a sync committee member contributing to a
+// single block, based on the current total active balance.
+func (c *command) participantReward(ctx context.Context) (phase0.Gwei, error) {
+	specResponse, err := c.specProvider.Spec(ctx, &api.SpecOpts{})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain spec")
+	}
+
+	params := &rewardParams{}
+	for _, param := range []struct {
+		name         string
+		defaultValue uint64
+		value        *uint64
+	}{
+		{name: "EFFECTIVE_BALANCE_INCREMENT", defaultValue: 1000000000, value: &params.effectiveBalanceIncrement},
+		{name: "BASE_REWARD_FACTOR", defaultValue: 64, value: &params.baseRewardFactor},
+		{name: "SYNC_REWARD_WEIGHT", defaultValue: 2, value: &params.syncRewardWeight},
+		{name: "WEIGHT_DENOMINATOR", defaultValue: 64, value: &params.weightDenominator},
+		{name: "SLOTS_PER_EPOCH", defaultValue: 32, value: &params.slotsPerEpoch},
+		{name: "SYNC_COMMITTEE_SIZE", defaultValue: 512, value: &params.syncCommitteeSize},
+	} {
+		tmp, exists := specResponse.Data[param.name]
+		if !exists {
+			// Use the default value from the Altair spec.
+			tmp = param.defaultValue
+		}
+		var ok bool
+		*param.value, ok = tmp.(uint64)
+		if !ok {
+			return 0, fmt.Errorf("%s of unexpected type", param.name)
+		}
+	}
+
+	epoch := c.chainTime.CurrentEpoch()
+	validatorsResponse, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+		State: "head",
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to obtain validators")
+	}
+	totalActiveBalance := phase0.Gwei(0)
+	for _, validator := range validatorsResponse.Data {
+		if validator.Validator.ActivationEpoch <= epoch &&
+			validator.Validator.ExitEpoch > epoch {
+			totalActiveBalance += validator.Validator.EffectiveBalance
+		}
+	}
+	if c.debug {
+		fmt.Printf("Total active balance: %d\n", totalActiveBalance)
+	}
+
+	return calcParticipantReward(totalActiveBalance, params), nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.specProvider, isProvider = c.eth2Client.(eth2client.SpecProvider)
+	if !isProvider {
+		return errors.New("connection does not provide spec information")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committee duties")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+
+	if c.cacheDir != "" {
+		// Use the on-disk cache for historical chain data.
+		cache, err := diskchaincache.New(ctx,
+			diskchaincache.WithBaseDir(c.cacheDir),
+			diskchaincache.WithClient(c.eth2Client),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to set up chain data cache")
+		}
+		c.blocksProvider = cache
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccommitteesummary
+
+import (
+	"math"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// periodSummary contains the performance of a sync committee over a sync committee period.
+type periodSummary struct {
+	Period            uint64               `json:"period"`
+	FromSlot          phase0.Slot          `json:"from_slot"`
+	ToSlot            phase0.Slot          `json:"to_slot"`
+	Blocks            int                  `json:"blocks"`
+	EmptySlots        []phase0.Slot        `json:"empty_slots"`
+	ParticipationRate float64              `json:"participation_rate"`
+	ParticipantReward phase0.Gwei          `json:"participant_reward"`
+	Threshold         float64              `json:"threshold"`
+	Members           []*memberPerformance `json:"members"`
+
+	members map[phase0.ValidatorIndex]*memberPerformance
+}
+
+// memberPerformance contains the performance of a single member of a sync committee.
+// A validator can hold more than one position in a sync committee, in which case it
+// is expected to contribute once per position in each block.
+type memberPerformance struct {
+	Validator         phase0.ValidatorIndex `json:"validator_index"`
+	Positions         []uint64              `json:"positions"`
+	Expected          int                   `json:"expected"`
+	Included          int                   `json:"included"`
+	Missed            int                   `json:"missed"`
+	MissedSlots       []phase0.Slot         `json:"missed_slots"`
+	ParticipationRate float64               `json:"participation_rate"`
+	RewardsLost       phase0.Gwei           `json:"rewards_lost"`
+	BelowThreshold    bool                  `json:"below_threshold"`
+}
+
+func newPeriodSummary(period uint64, committee []phase0.ValidatorIndex, threshold float64) *periodSummary {
+	s := &periodSummary{
+		Period:     period,
+		EmptySlots: make([]phase0.Slot, 0),
+		Threshold:  threshold,
+		members:    make(map[phase0.ValidatorIndex]*memberPerformance),
+	}
+
+	for i, validatorIndex := range committee {
+		member, exists := s.members[validatorIndex]
+		if !exists {
+			member = &memberPerformance{
+				Validator:   validatorIndex,
+				Positions:   make([]uint64, 0, 1),
+				MissedSlots: make([]phase0.Slot, 0),
+			}
+			s.members[validatorIndex] = member
+		}
+		member.Positions = append(member.Positions, uint64(i))
+	}
+
+	return s
+}
+
+// addBlock adds the sync aggregate of the block at the given slot to the summary.
+func (s *periodSummary) addBlock(slot phase0.Slot, aggregate *altair.SyncAggregate) {
+	s.Blocks++
+	for _, member := range s.members {
+		missed := false
+		for _, position := range member.Positions {
+			member.Expected++
+			if aggregate.SyncCommitteeBits.BitAt(position) {
+				member.Included++
+			} else {
+				member.Missed++
+				missed = true
+			}
+		}
+		if missed {
+			member.MissedSlots = append(member.MissedSlots, slot)
+		}
+	}
+}
+
+// addEmptySlot adds a slot without a block to the summary.
+// Members are not expected to contribute to slots without blocks.
+func (s *periodSummary) addEmptySlot(slot phase0.Slot) {
+	s.EmptySlots = append(s.EmptySlots, slot)
+}
+
+// finalize calculates the rates and rewards lost, and orders the members in the summary.
+func (s *periodSummary) finalize(participantReward phase0.Gwei) {
+	s.ParticipantReward = participantReward
+	s.Members = make([]*memberPerformance, 0, len(s.members))
+	expected := 0
+	included := 0
+	for _, member := range s.members {
+		if member.Expected > 0 {
+			member.ParticipationRate = float64(member.Included) / float64(member.Expected)
+			member.BelowThreshold = member.ParticipationRate < s.Threshold
+		}
+		// A missed contribution forgoes the participant reward and incurs a penalty of the same amount.
+		member.RewardsLost = 2 * participantReward * phase0.Gwei(member.Missed)
+		expected += member.Expected
+		included += member.Included
+		s.Members = append(s.Members, member)
+	}
+	if expected > 0 {
+		s.ParticipationRate = float64(included) / float64(expected)
+	}
+
+	sort.Slice(s.Members, func(i int, j int) bool {
+		return s.Members[i].Validator < s.Members[j].Validator
+	})
+}
+
+// rewardParams are the spec parameters used to calculate sync committee rewards.
+type rewardParams struct {
+	effectiveBalanceIncrement uint64
+	baseRewardFactor          uint64
+	syncRewardWeight          uint64
+	weightDenominator         uint64
+	slotsPerEpoch             uint64
+	syncCommitteeSize         uint64
+}
+
+// calcParticipantReward calculates the reward for a sync committee member contributing to a
+// single block, as per process_sync_aggregate in the Altair specification.
+func calcParticipantReward(totalActiveBalance phase0.Gwei, params *rewardParams) phase0.Gwei {
+	total := uint64(totalActiveBalance)
+	if total < params.effectiveBalanceIncrement {
+		total = params.effectiveBalanceIncrement
+	}
+	baseRewardPerIncrement := params.effectiveBalanceIncrement * params.baseRewardFactor / integerSquareRoot(total)
+	totalBaseRewards := baseRewardPerIncrement * (total / params.effectiveBalanceIncrement)
+	maxParticipantRewards := totalBaseRewards * params.syncRewardWeight / params.weightDenominator / params.slotsPerEpoch
+
+	return phase0.Gwei(maxParticipantRewards / params.syncCommitteeSize)
+}
+
+// integerSquareRoot returns the largest integer whose square does not exceed n.
+func integerSquareRoot(n uint64) uint64 {
+	x := uint64(math.Sqrt(float64(n)))
+	// Correct for floating point inaccuracy, avoiding overflow.
+	for x > 0 && x > n/x {
+		x--
+	}
+	for x+1 <= n/(x+1) {
+		x++
+	}
+
+	return x
+}
@@ -0,0 +1,138 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synccommitteesummary
+
+import (
+	"math"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummary(t *testing.T) {
+	// Validator 3 holds two positions in the committee.
+	committee := make([]phase0.ValidatorIndex, 512)
+	for i := range committee {
+		committee[i] = phase0.ValidatorIndex(i)
+	}
+	committee[511] = 3
+
+	summary := newPeriodSummary(10, committee, 0.9)
+
+	for slot := phase0.Slot(100); slot < 110; slot++ {
+		if slot == 105 {
+			summary.addEmptySlot(slot)
+			continue
+		}
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < 512; i++ {
+			bits.SetBitAt(i, true)
+		}
+		// Validator 1 misses every other block.
+		if slot%2 == 0 {
+			bits.SetBitAt(1, false)
+		}
+		// Validator 2 misses a single block.
+		if slot == 101 {
+			bits.SetBitAt(2, false)
+		}
+		// Validator 3 misses a block in one of its positions.
+		if slot == 102 {
+			bits.SetBitAt(511, false)
+		}
+		summary.addBlock(slot, &altair.SyncAggregate{SyncCommitteeBits: bits})
+	}
+	summary.finalize(1000)
+
+	require.Equal(t, 9, summary.Blocks)
+	require.Equal(t, []phase0.Slot{105}, summary.EmptySlots)
+	require.Len(t, summary.Members, 511)
+	for i := 1; i < len(summary.Members); i++ {
+		require.Less(t, summary.Members[i-1].Validator, summary.Members[i].Validator)
+	}
+
+	member := summary.Members[1]
+	require.Equal(t, phase0.ValidatorIndex(1), member.Validator)
+	require.Equal(t, 9, member.Expected)
+	require.Equal(t, 5, member.Missed)
+	require.Equal(t, []phase0.Slot{100, 102, 104, 106, 108}, member.MissedSlots)
+	require.Equal(t, phase0.Gwei(10000), member.RewardsLost)
+	require.True(t, member.BelowThreshold)
+
+	member = summary.Members[2]
+	require.Equal(t, []phase0.Slot{101}, member.MissedSlots)
+	require.InDelta(t, 8.0/9.0, member.ParticipationRate, 1e-9)
+	require.True(t, member.BelowThreshold)
+
+	member = summary.Members[3]
+	require.Equal(t, []uint64{3, 511}, member.Positions)
+	require.Equal(t, 18, member.Expected)
+	require.Equal(t, 17, member.Included)
+	require.Equal(t, []phase0.Slot{102}, member.MissedSlots)
+	require.Equal(t, phase0.Gwei(2000), member.RewardsLost)
+	require.False(t, member.BelowThreshold)
+
+	member = summary.Members[0]
+	require.Equal(t, 1.0, member.ParticipationRate)
+	require.Empty(t, member.MissedSlots)
+	require.Equal(t, phase0.Gwei(0), member.RewardsLost)
+
+	require.InDelta(t, float64(512*9-7)/float64(512*9), summary.ParticipationRate, 1e-9)
+}
+
+func TestCalcParticipantReward(t *testing.T) {
+	params := &rewardParams{
+		effectiveBalanceIncrement: 1000000000,
+		baseRewardFactor:          64,
+		syncRewardWeight:          2,
+		weightDenominator:         64,
+		slotsPerEpoch:             32,
+		syncCommitteeSize:         512,
+	}
+
+	tests := []struct {
+		name               string
+		totalActiveBalance phase0.Gwei
+		expected           phase0.Gwei
+	}{
+		{
+			// Total balance is never considered less than a single increment.
+			name:               "Zero",
+			totalActiveBalance: 0,
+			expected:           3,
+		},
+		{
+			name:               "Million",
+			totalActiveBalance: 32000000000 * 1000000,
+			expected:           21789,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, calcParticipantReward(test.totalActiveBalance, params))
+		})
+	}
+}
+
+func TestIntegerSquareRoot(t *testing.T) {
+	require.Equal(t, uint64(0), integerSquareRoot(0))
+	require.Equal(t, uint64(1), integerSquareRoot(3))
+	require.Equal(t, uint64(2), integerSquareRoot(4))
+	require.Equal(t, uint64(178885438), integerSquareRoot(32000000000000000))
+	require.Equal(t, uint64(math.MaxUint32), integerSquareRoot(math.MaxUint64))
+}
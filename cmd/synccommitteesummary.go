@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	synccommitteesummary "github.com/wealdtech/ethdo/cmd/synccommittee/summary"
+)
+
+var synccommitteeSummaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Obtain a performance summary of a sync committee over a period",
+	Long: `Obtain a performance summary of a sync committee over a sync committee period.  For example:
+
+    ethdo synccommittee summary --period=123
+
+period can be a specific period, 'current' for the current period, 'last' for the previous period, or a negative number relative to the current period.  If the period is in progress only the slots up to the current slot are summarized.
+
+Members whose participation is below the threshold, supplied as a fraction with --threshold, are flagged.  Rewards lost are estimated from the current total active balance.
+
+The summary can be output as CSV with --csv, or as JSON with --json.
+
+In quiet mode this will return 0 if the summary is obtained, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := synccommitteesummary.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	synccommitteeCmd.AddCommand(synccommitteeSummaryCmd)
+	synccommitteeFlags(synccommitteeSummaryCmd)
+	synccommitteeSummaryCmd.Flags().String("period", "", "the sync committee period for which to obtain a summary ('current', 'last', or a number)")
+	synccommitteeSummaryCmd.Flags().Float64("threshold", 0.9, "the participation rate below which members are flagged")
+	synccommitteeSummaryCmd.Flags().Bool("csv", false, "output the summary as CSV")
+}
+
+func synccommitteeSummaryBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("period", cmd.Flags().Lookup("period")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("threshold", cmd.Flags().Lookup("threshold")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
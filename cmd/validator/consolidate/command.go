@@ -0,0 +1,105 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+	offline bool
+	json    bool
+
+	// Input.
+	sourceValidators    []string
+	targetValidator     string
+	feeStr              string
+	executionConnection string
+	prepareOffline      bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Information required to generate the requests.
+	chainInfo *beacon.ChainInfo
+	fee       *big.Int
+
+	// Processing.
+	consensusClient consensusclient.Service
+	chainTime       chaintime.Service
+
+	// Output.
+	requests []*consolidationRequest
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:                    viper.GetBool("quiet"),
+		verbose:                  viper.GetBool("verbose"),
+		debug:                    viper.GetBool("debug"),
+		offline:                  viper.GetBool("offline"),
+		json:                     viper.GetBool("json"),
+		timeout:                  viper.GetDuration("timeout"),
+		connection:               viper.GetString("connection"),
+		allowInsecureConnections: viper.GetBool("allow-insecure-connections"),
+		prepareOffline:           viper.GetBool("prepare-offline"),
+		sourceValidators:         viper.GetStringSlice("source-validator"),
+		targetValidator:          viper.GetString("target-validator"),
+		feeStr:                   viper.GetString("fee"),
+		executionConnection:      viper.GetString("execution-connection"),
+		requests:                 make([]*consolidationRequest, 0),
+	}
+
+	// Timeout is required.
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	// We are generating information for offline use, we don't need any information
+	// related to the validators.
+	if c.prepareOffline {
+		return c, nil
+	}
+
+	if len(c.sourceValidators) == 0 {
+		return nil, errors.New("source validator is required")
+	}
+	if c.targetValidator == "" {
+		return nil, errors.New("target validator is required")
+	}
+	if c.offline && c.executionConnection != "" {
+		return nil, errors.New("cannot use an execution connection when offline")
+	}
+	if c.executionConnection != "" && c.feeStr != "" {
+		return nil, errors.New("cannot specify both fee and execution connection")
+	}
+	if c.executionConnection == "" && c.feeStr == "" {
+		return nil, errors.New("execution connection or fee is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.prepareOffline {
+		return fmt.Sprintf("%s generated", offlinePreparationFilename), nil
+	}
+
+	if c.json {
+		var data []byte
+		var err error
+		if len(c.requests) == 1 {
+			data, err = json.Marshal(c.requests[0])
+		} else {
+			data, err = json.Marshal(c.requests)
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal consolidation requests")
+		}
+
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+	for i, request := range c.requests {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		if request.SourceIndex == request.TargetIndex {
+			builder.WriteString(fmt.Sprintf("Switch validator %d to compounding withdrawal credentials\n", request.SourceIndex))
+		} else {
+			builder.WriteString(fmt.Sprintf("Consolidate validator %d in to validator %d\n", request.SourceIndex, request.TargetIndex))
+		}
+		if c.verbose {
+			builder.WriteString(fmt.Sprintf("Source public key: %#x\n", request.SourcePubkey))
+			builder.WriteString(fmt.Sprintf("Target public key: %#x\n", request.TargetPubkey))
+		}
+		builder.WriteString(fmt.Sprintf("From: %s\n", addressBytesToEIP55(request.From[:])))
+		builder.WriteString(fmt.Sprintf("To: %s\n", addressBytesToEIP55(request.To[:])))
+		builder.WriteString(fmt.Sprintf("Value: %s\n", string2eth.WeiToString(request.Value, true)))
+		builder.WriteString(fmt.Sprintf("Data: %#x\n", request.Data()))
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
@@ -0,0 +1,251 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// minTimeout is the minimum timeout for this command.
+// It needs to be set here as we want timeouts to be low in general, but this can be pulling
+// a lot of data for an unsophisticated audience so it's easier to set a higher timeout..
+var minTimeout = 5 * time.Minute
+
+var offlinePreparationFilename = "offline-preparation.json"
+
+// consolidationRequestContractAddress is the address of the EIP-7251 consolidation request predeploy.
+var consolidationRequestContractAddress = bellatrix.ExecutionAddress{
+	0x00, 0x00, 0xbb, 0xdd, 0xc7, 0xce, 0x48, 0x86, 0x42, 0xfb,
+	0x57, 0x9f, 0x8b, 0x00, 0xf3, 0xa5, 0x90, 0x00, 0x72, 0x51,
+}
+
+const (
+	// blsWithdrawalPrefix is the prefix for BLS withdrawal credentials.
+	blsWithdrawalPrefix = 0x00
+	// ethWithdrawalPrefix is the prefix for execution address withdrawal credentials.
+	ethWithdrawalPrefix = 0x01
+	// compoundingWithdrawalPrefix is the prefix for compounding withdrawal credentials.
+	compoundingWithdrawalPrefix = 0x02
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.obtainChainInfo(ctx); err != nil {
+		return err
+	}
+
+	if c.prepareOffline {
+		return c.writeChainInfoToFile(ctx)
+	}
+
+	if err := c.obtainFee(ctx); err != nil {
+		return err
+	}
+
+	return c.generateRequests(ctx)
+}
+
+// obtainFee obtains the fee for each request.  The current fee from the contract
+// is used if an execution connection is available, otherwise the explicit fee.
+// The consolidation fee rises with the number of pending requests, so an
+// explicit fee may be insufficient and cause the transaction to revert.
+func (c *command) obtainFee(ctx context.Context) error {
+	if c.executionConnection != "" {
+		var err error
+		c.fee, err = util.ObtainExecutionRequestFee(ctx, c.executionConnection, c.timeout, consolidationRequestContractAddress[:])
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain consolidation request fee")
+		}
+
+		return nil
+	}
+
+	var err error
+	c.fee, err = string2eth.StringToWei(c.feeStr)
+	if err != nil {
+		return errors.Wrap(err, "invalid fee")
+	}
+	if c.fee.Sign() <= 0 {
+		return errors.New("fee must be greater than 0")
+	}
+	if !c.quiet {
+		fmt.Fprintf(os.Stderr, "Fee has not been checked against the current consolidation request fee and may be insufficient; supply --execution-connection to use the current fee\n")
+	}
+
+	return nil
+}
+
+func (c *command) generateRequests(ctx context.Context) error {
+	targetInfo, err := c.chainInfo.FetchValidatorInfo(ctx, c.targetValidator)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain target validator")
+	}
+
+	for _, sourceValidator := range c.sourceValidators {
+		sourceInfo, err := c.chainInfo.FetchValidatorInfo(ctx, sourceValidator)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain source validator %s", sourceValidator))
+		}
+
+		request, err := c.generateRequest(ctx, sourceInfo, targetInfo)
+		if err != nil {
+			return err
+		}
+		c.requests = append(c.requests, request)
+	}
+
+	return nil
+}
+
+func (c *command) generateRequest(_ context.Context,
+	source *beacon.ValidatorInfo,
+	target *beacon.ValidatorInfo,
+) (
+	*consolidationRequest,
+	error,
+) {
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Source validator: %v\n", source)
+		fmt.Fprintf(os.Stderr, "Target validator: %v\n", target)
+	}
+
+	if err := checkValidatorState(source); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("source validator %d not suitable", source.Index))
+	}
+	if err := checkValidatorState(target); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("target validator %d not suitable", target.Index))
+	}
+
+	switch source.WithdrawalCredentials[0] {
+	case ethWithdrawalPrefix, compoundingWithdrawalPrefix:
+		// Good.
+	case blsWithdrawalPrefix:
+		return nil, fmt.Errorf("source validator %d has BLS withdrawal credentials; change them to an execution address first", source.Index)
+	default:
+		return nil, fmt.Errorf("source validator %d has unknown withdrawal credentials %#x", source.Index, source.WithdrawalCredentials)
+	}
+
+	if source.Index == target.Index {
+		// This is a request to switch the validator to compounding credentials.
+		if source.WithdrawalCredentials[0] != ethWithdrawalPrefix {
+			return nil, fmt.Errorf("validator %d does not have execution address (0x01) withdrawal credentials to switch", source.Index)
+		}
+	} else if target.WithdrawalCredentials[0] != compoundingWithdrawalPrefix {
+		return nil, fmt.Errorf("target validator %d does not have compounding (0x02) withdrawal credentials; use it as both source and target to switch it first", target.Index)
+	}
+
+	if !bytes.Equal(source.WithdrawalCredentials[12:], target.WithdrawalCredentials[12:]) {
+		return nil, fmt.Errorf("source validator %d withdrawal address %s does not match target validator %d withdrawal address %s",
+			source.Index,
+			addressBytesToEIP55(source.WithdrawalCredentials[12:]),
+			target.Index,
+			addressBytesToEIP55(target.WithdrawalCredentials[12:]),
+		)
+	}
+
+	request := &consolidationRequest{
+		SourceIndex:  source.Index,
+		SourcePubkey: source.Pubkey,
+		TargetIndex:  target.Index,
+		TargetPubkey: target.Pubkey,
+		To:           consolidationRequestContractAddress,
+		Value:        c.fee,
+	}
+	copy(request.From[:], source.WithdrawalCredentials[12:])
+
+	return request, nil
+}
+
+// checkValidatorState ensures that the validator is in a state where it can be consolidated.
+func checkValidatorState(validator *beacon.ValidatorInfo) error {
+	if validator.State != apiv1.ValidatorStateActiveOngoing {
+		return fmt.Errorf("validator is in state %v, not suitable for consolidation", validator.State)
+	}
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.offline {
+		return nil
+	}
+
+	// Ensure timeout is at least the minimum.
+	if c.timeout < minTimeout {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Increasing timeout to %v\n", minTimeout)
+		}
+		c.timeout = minTimeout
+	}
+
+	// Connect to the consensus node.
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
+
+// addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
+func addressBytesToEIP55(address []byte) string {
+	bytes := []byte(hex.EncodeToString(address))
+	hash := ethutil.Keccak256(bytes)
+	for i := 0; i < len(bytes); i++ {
+		hashByte := hash[i/2]
+		if i%2 == 0 {
+			hashByte >>= 4
+		} else {
+			hashByte &= 0xf
+		}
+		if bytes[i] > '9' && hashByte > 7 {
+			bytes[i] -= 32
+		}
+	}
+
+	return fmt.Sprintf("0x%s", string(bytes))
+}
@@ -0,0 +1,245 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+func TestGenerateRequests(t *testing.T) {
+	ctx := context.Background()
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 3,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:                 0,
+				Pubkey:                phase0.BLSPubKey{0x01},
+				State:                 apiv1.ValidatorStateActiveOngoing,
+				WithdrawalCredentials: []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15},
+			},
+			{
+				Index:                 1,
+				Pubkey:                phase0.BLSPubKey{0x02},
+				State:                 apiv1.ValidatorStateActiveOngoing,
+				WithdrawalCredentials: []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15},
+			},
+			{
+				Index:                 2,
+				Pubkey:                phase0.BLSPubKey{0x03},
+				State:                 apiv1.ValidatorStateActiveOngoing,
+				WithdrawalCredentials: []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11},
+			},
+			{
+				Index:                 3,
+				Pubkey:                phase0.BLSPubKey{0x04},
+				State:                 apiv1.ValidatorStateActiveOngoing,
+				WithdrawalCredentials: []byte{0x00, 0x8b, 0xa1, 0xcc, 0x4b, 0x09, 0x1b, 0x91, 0xc1, 0x20, 0x2b, 0xba, 0x3f, 0x50, 0x80, 0x75, 0xd6, 0xff, 0x56, 0x5c, 0x77, 0xe5, 0x59, 0xf0, 0x80, 0x3c, 0x07, 0x92, 0xe0, 0x30, 0x2b, 0xf1},
+			},
+			{
+				Index:                 4,
+				Pubkey:                phase0.BLSPubKey{0x05},
+				State:                 apiv1.ValidatorStateActiveExiting,
+				WithdrawalCredentials: []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15},
+			},
+		},
+		Epoch: 1,
+	}
+
+	tests := []struct {
+		name     string
+		command  *command
+		expected string
+		err      string
+	}{
+		{
+			name: "TargetUnknown",
+			command: &command{
+				sourceValidators: []string{"0"},
+				targetValidator:  "99",
+				chainInfo:        chainInfo,
+				fee:              big.NewInt(1),
+			},
+			err: "failed to obtain target validator: unknown validator",
+		},
+		{
+			name: "SourceUnknown",
+			command: &command{
+				sourceValidators: []string{"99"},
+				targetValidator:  "1",
+				chainInfo:        chainInfo,
+				fee:              big.NewInt(1),
+			},
+			err: "failed to obtain source validator 99: unknown validator",
+		},
+		{
+			name: "SourceBLS",
+			command: &command{
+				sourceValidators: []string{"3"},
+				targetValidator:  "1",
+				chainInfo:        chainInfo,
+				fee:              big.NewInt(1),
+			},
+			err: "source validator 3 has BLS withdrawal credentials; change them to an execution address first",
+		},
+		{
+			name: "SourceExiting",
+			command: &command{
+				sourceValidators: []string{"4"},
+				targetValidator:  "1",
+				chainInfo:        chainInfo,
+				fee:              big.NewInt(1),
+			},
+			err: "source validator 4 not suitable: validator is in state active_exiting, not suitable for consolidation",
+		},
+		{
+			name: "TargetNotCompounding",
+			command: &command{
+				sourceValidators: []string{"1"},
+				targetValidator:  "0",
+				chainInfo:        chainInfo,
+				fee:              big.NewInt(1),
+			},
+			err: "target validator 0 does not have compounding (0x02) withdrawal credentials; use it as both source and target to switch it first",
+		},
+		{
+			name: "AddressMismatch",
+			command: &command{
+				sourceValidators: []string{"0"},
+				targetValidator:  "2",
+				chainInfo:        chainInfo,
+				fee:              big.NewInt(1),
+			},
+			err: "source validator 0 withdrawal address 0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15 does not match target validator 2 withdrawal address 0x1111111111111111111111111111111111111111",
+		},
+		{
+			name: "SwitchCompounding",
+			command: &command{
+				sourceValidators: []string{"1"},
+				targetValidator:  "1",
+				chainInfo:        chainInfo,
+				fee:              big.NewInt(1),
+			},
+			err: "validator 1 does not have execution address (0x01) withdrawal credentials to switch",
+		},
+		{
+			name: "Switch",
+			command: &command{
+				sourceValidators: []string{"0"},
+				targetValidator:  "0",
+				chainInfo:        chainInfo,
+				fee:              big.NewInt(1),
+			},
+			expected: `{"from":"0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15","to":"0x0000BBdDc7CE488642fb579F8B00f3a590007251","value":"0x1","data":"0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}`,
+		},
+		{
+			name: "Good",
+			command: &command{
+				sourceValidators: []string{"0"},
+				targetValidator:  "1",
+				chainInfo:        chainInfo,
+				fee:              big.NewInt(2),
+			},
+			expected: `{"from":"0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15","to":"0x0000BBdDc7CE488642fb579F8B00f3a590007251","value":"0x2","data":"0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.generateRequests(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Len(t, test.command.requests, 1)
+				data, err := json.Marshal(test.command.requests[0])
+				require.NoError(t, err)
+				require.Equal(t, test.expected, string(data))
+			}
+		})
+	}
+}
+
+func TestObtainFee(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000011"}`)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		command  *command
+		expected *big.Int
+		err      string
+	}{
+		{
+			name: "ExecutionConnection",
+			command: &command{
+				executionConnection: server.URL,
+				timeout:             time.Second,
+			},
+			expected: big.NewInt(17),
+		},
+		{
+			name: "Fee",
+			command: &command{
+				feeStr: "2 wei",
+				quiet:  true,
+			},
+			expected: big.NewInt(2),
+		},
+		{
+			name: "FeeInvalid",
+			command: &command{
+				feeStr: "bad",
+				quiet:  true,
+			},
+			err: "invalid fee: failed to parse numeric value of  bad",
+		},
+		{
+			name: "FeeZero",
+			command: &command{
+				feeStr: "0 wei",
+				quiet:  true,
+			},
+			err: "fee must be greater than 0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.obtainFee(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expected, test.command.fee)
+		})
+	}
+}
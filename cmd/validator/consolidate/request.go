@@ -0,0 +1,63 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorconsolidate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// consolidationRequest is an execution layer transaction that requests a consolidation.
+type consolidationRequest struct {
+	SourceIndex  phase0.ValidatorIndex
+	SourcePubkey phase0.BLSPubKey
+	TargetIndex  phase0.ValidatorIndex
+	TargetPubkey phase0.BLSPubKey
+	From         bellatrix.ExecutionAddress
+	To           bellatrix.ExecutionAddress
+	Value        *big.Int
+}
+
+// consolidationRequestJSON is the JSON representation of a consolidation request,
+// laid out as the parameters of an eth_sendTransaction call.
+type consolidationRequestJSON struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value"`
+	Data  string `json:"data"`
+}
+
+// Data provides the calldata for the request, which is the source public key
+// followed by the target public key.
+func (r *consolidationRequest) Data() []byte {
+	data := make([]byte, 0, 2*phase0.PublicKeyLength)
+	data = append(data, r.SourcePubkey[:]...)
+	data = append(data, r.TargetPubkey[:]...)
+
+	return data
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *consolidationRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&consolidationRequestJSON{
+		From:  addressBytesToEIP55(r.From[:]),
+		To:    addressBytesToEIP55(r.To[:]),
+		Value: fmt.Sprintf("%#x", r.Value),
+		Data:  fmt.Sprintf("%#x", r.Data()),
+	})
+}
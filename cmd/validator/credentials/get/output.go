@@ -29,23 +29,48 @@ func (c *command) output(_ context.Context) (string, error) {
 
 	builder := strings.Builder{}
 
-	switch c.validatorInfo.Validator.WithdrawalCredentials[0] {
+	withdrawalCredentials := c.validatorInfo.Validator.WithdrawalCredentials
+	switch withdrawalCredentials[0] {
 	case 0:
 		builder.WriteString("BLS credentials: ")
-		builder.WriteString(fmt.Sprintf("%#x", c.validatorInfo.Validator.WithdrawalCredentials))
+		builder.WriteString(fmt.Sprintf("%#x", withdrawalCredentials))
 	case 1:
 		builder.WriteString("Ethereum execution address: ")
-		builder.WriteString(addressBytesToEIP55(c.validatorInfo.Validator.WithdrawalCredentials[12:]))
-		if c.verbose {
+		builder.WriteString(addressBytesToEIP55(withdrawalCredentials[12:]))
+	case 2:
+		builder.WriteString("Compounding Ethereum execution address: ")
+		builder.WriteString(addressBytesToEIP55(withdrawalCredentials[12:]))
+	default:
+		builder.WriteString("Unknown credentials: ")
+		builder.WriteString(fmt.Sprintf("%#x", withdrawalCredentials))
+	}
+	if c.verbose {
+		builder.WriteString("\n")
+		builder.WriteString(fmt.Sprintf("Withdrawal credentials type: 0x%02x (%s)", withdrawalCredentials[0], credentialsType(withdrawalCredentials[0])))
+		if withdrawalCredentials[0] != 0 {
 			builder.WriteString("\n")
 			builder.WriteString("Withdrawal credentials: ")
-			builder.WriteString(fmt.Sprintf("%#x", c.validatorInfo.Validator.WithdrawalCredentials))
+			builder.WriteString(fmt.Sprintf("%#x", withdrawalCredentials))
 		}
 	}
 
 	return builder.String(), nil
 }
 
+// credentialsType provides a description of the withdrawal credentials type given its prefix.
+func credentialsType(prefix byte) string {
+	switch prefix {
+	case 0:
+		return "BLS"
+	case 1:
+		return "execution"
+	case 2:
+		return "compounding"
+	default:
+		return "unknown"
+	}
+}
+
 // addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
 func addressBytesToEIP55(address []byte) string {
 	bytes := []byte(hex.EncodeToString(address))
@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/beacon"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/signing/web3signer"
 	"github.com/wealdtech/ethdo/util"
 )
 
@@ -49,6 +50,7 @@ type command struct {
 	prepareOffline        bool
 	signedOperationsInput string
 	maxDistance           uint64
+	signerURL             string
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -59,10 +61,12 @@ type command struct {
 	withdrawalAddress bellatrix.ExecutionAddress
 	chainInfo         *beacon.ChainInfo
 	domain            phase0.Domain
+	forkInfo          *web3signer.ForkInfo
 
 	// Processing.
 	consensusClient consensusclient.Service
 	chainTime       chaintime.Service
+	signer          *web3signer.Service
 
 	// Output.
 	signedOperations []*capella.SignedBLSToExecutionChange
@@ -92,6 +96,7 @@ func newCommand(_ context.Context) (*command, error) {
 		forkVersion:           viper.GetString("fork-version"),
 		genesisValidatorsRoot: viper.GetString("genesis-validators-root"),
 		maxDistance:           viper.GetUint64("max-distance"),
+		signerURL:             viper.GetString("signer-url"),
 	}
 
 	// Timeout is required.
@@ -105,6 +110,28 @@ func newCommand(_ context.Context) (*command, error) {
 		return c, nil
 	}
 
+	if c.signerURL != "" {
+		if c.mnemonic != "" || c.privateKey != "" {
+			return nil, errors.New("signer-url cannot be used with mnemonic or private key")
+		}
+		if c.validator == "" {
+			return nil, errors.New("validator is required with signer-url")
+		}
+		if c.withdrawalAccount == "" {
+			return nil, errors.New("withdrawal-account is required with signer-url")
+		}
+		var err error
+		c.signer, err = web3signer.New(
+			web3signer.WithURL(c.signerURL),
+			web3signer.WithTimeout(c.timeout),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set up remote signer")
+		}
+
+		return c, nil
+	}
+
 	if c.withdrawalAccount != "" && len(c.passphrases) == 0 {
 		return nil, errors.New("passphrase required with withdrawal-account")
 	}
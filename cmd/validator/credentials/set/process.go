@@ -33,6 +33,7 @@ import (
 	"github.com/wealdtech/ethdo/beacon"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/signing/web3signer"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	ethutil "github.com/wealdtech/go-eth2-util"
@@ -126,6 +127,11 @@ func (c *command) obtainOperations(ctx context.Context) error {
 		}
 	}
 
+	if c.signer != nil {
+		// Have a validator and a withdrawal key held by a remote signer.
+		return c.generateOperationFromRemoteSigner(ctx)
+	}
+
 	if c.account != "" {
 		switch {
 		case c.withdrawalAccount != "":
@@ -340,6 +346,47 @@ func (c *command) generateOperationsFromValidatorAndPrivateKey(ctx context.Conte
 	return c.generateOperationFromAccount(ctx, validatorInfo, withdrawalAccount)
 }
 
+func (c *command) generateOperationFromRemoteSigner(ctx context.Context) error {
+	validatorInfo, err := c.obtainValidatorInfoFromValidatorSpecifier(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The withdrawal key is held by the remote signer, so the withdrawal account must be its public key.
+	data, err := hex.DecodeString(strings.TrimPrefix(c.withdrawalAccount, "0x"))
+	if err != nil || len(data) != phase0.PublicKeyLength {
+		return errors.New("withdrawal account must be a public key when using a remote signer")
+	}
+	withdrawalPubkey := phase0.BLSPubKey{}
+	copy(withdrawalPubkey[:], data)
+
+	if err := c.parseWithdrawalAddress(ctx); err != nil {
+		return errors.Wrap(err, "invalid withdrawal address")
+	}
+
+	operation := &capella.BLSToExecutionChange{
+		ValidatorIndex:     validatorInfo.Index,
+		FromBLSPubkey:      withdrawalPubkey,
+		ToExecutionAddress: c.withdrawalAddress,
+	}
+
+	// Sign the operation.
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Signing credentials change with domain %#x by remote signer for public key %#x\n", c.domain, withdrawalPubkey)
+	}
+	signature, err := c.signer.SignBLSToExecutionChange(ctx, withdrawalPubkey, c.forkInfo, operation, c.domain)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign credentials change operation")
+	}
+
+	c.signedOperations = append(c.signedOperations, &capella.SignedBLSToExecutionChange{
+		Message:   operation,
+		Signature: signature,
+	})
+
+	return nil
+}
+
 func (c *command) obtainValidatorInfoFromValidatorSpecifier(ctx context.Context) (*beacon.ValidatorInfo, error) {
 	if numeric.MatchString(c.validator) {
 		// The validator specifier looks like an on-chain index.  Fetch directly from the
@@ -708,7 +755,12 @@ func (c *command) validateOperation(_ context.Context,
 }
 
 func (c *command) broadcastOperations(ctx context.Context) error {
-	return c.consensusClient.(consensusclient.BLSToExecutionChangesSubmitter).SubmitBLSToExecutionChanges(ctx, c.signedOperations)
+	submitter, isSubmitter := c.consensusClient.(consensusclient.BLSToExecutionChangesSubmitter)
+	if !isSubmitter {
+		return errors.New("connection does not support submitting credentials changes; use a single beacon node")
+	}
+
+	return submitter.SubmitBLSToExecutionChanges(ctx, c.signedOperations)
 }
 
 func (c *command) setup(ctx context.Context) error {
@@ -768,6 +820,13 @@ func (c *command) generateDomain(ctx context.Context) error {
 
 	copy(c.domain[:], c.chainInfo.BLSToExecutionChangeDomainType[:])
 	copy(c.domain[4:], root[:])
+	c.forkInfo = &web3signer.ForkInfo{
+		Fork: &phase0.Fork{
+			PreviousVersion: forkVersion,
+			CurrentVersion:  forkVersion,
+		},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Domain is %#x\n", c.domain)
 	}
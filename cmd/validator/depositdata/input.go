@@ -34,6 +34,7 @@ type dataIn struct {
 	withdrawalAccount string
 	withdrawalPubKey  string
 	withdrawalAddress string
+	compounding       bool
 	amount            spec.Gwei
 	validatorAccounts []e2wtypes.Account
 	forkVersion       *spec.Version
@@ -97,6 +98,10 @@ func input() (*dataIn, error) {
 	if withdrawalDetailsPresent > 1 {
 		return nil, errors.New("only one of withdrawal account, public key or address is allowed")
 	}
+	data.compounding = viper.GetBool("compounding")
+	if data.compounding && data.withdrawalAddress == "" {
+		return nil, errors.New("compounding withdrawal credentials require a withdrawal address")
+	}
 
 	if viper.GetString("depositvalue") == "" {
 		return nil, errors.New("deposit value is required")
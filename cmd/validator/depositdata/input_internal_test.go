@@ -180,6 +180,17 @@ func TestInput(t *testing.T) {
 			},
 			err: "only one of withdrawal account, public key or address is allowed",
 		},
+		{
+			name: "CompoundingWithoutAddress",
+			vars: map[string]interface{}{
+				"timeout":           "10s",
+				"validatoraccount":  "Test/Interop 0",
+				"withdrawalaccount": "Test/Interop 0",
+				"compounding":       true,
+				"depositvalue":      "32 Ether",
+			},
+			err: "compounding withdrawal credentials require a withdrawal address",
+		},
 		{
 			name: "DepositValueMissing",
 			vars: map[string]interface{}{
@@ -96,6 +96,7 @@ func process(data *dataIn) ([]*dataOut, error) {
 }
 
 // createWithdrawalCredentials creates withdrawal credentials given an account, public key or Ethereum 1 address.
+// Ethereum 1 addresses create compounding credentials if requested.
 func createWithdrawalCredentials(data *dataIn) ([]byte, error) {
 	var withdrawalCredentials []byte
 
@@ -145,7 +146,11 @@ func createWithdrawalCredentials(data *dataIn) ([]byte, error) {
 		withdrawalCredentials = make([]byte, 32)
 		copy(withdrawalCredentials[12:32], withdrawalAddressBytes)
 		// This is hard-coded, to allow deposit data to be generated without a connection to the beacon node.
-		withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+		if data.compounding {
+			withdrawalCredentials[0] = byte(2) // COMPOUNDING_WITHDRAWAL_PREFIX
+		} else {
+			withdrawalCredentials[0] = byte(1) // ETH1_ADDRESS_WITHDRAWAL_PREFIX
+		}
 	default:
 		return nil, errors.New("withdrawal account, public key or address is required")
 	}
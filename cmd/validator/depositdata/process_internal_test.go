@@ -123,6 +123,22 @@ func TestProcess(t *testing.T) {
 		signature3 = &tmp
 	}
 
+	var depositDataRoot4 *spec.Root
+	{
+		tmp := testutil.HexToRoot("0xfbd2036f3f245d3ebb5bdedec7fe19163dd59068ad57c47d8e439ee7c01e410c")
+		depositDataRoot4 = &tmp
+	}
+	var depositMessageRoot4 *spec.Root
+	{
+		tmp := testutil.HexToRoot("0x276c554a0f188fb5385e4631b68658e27b23ed3a933a12d9afe79d842d11d9df")
+		depositMessageRoot4 = &tmp
+	}
+	var signature4 *spec.BLSSignature
+	{
+		tmp := testutil.HexToSignature("0x837378196e360888cfc8b08088ed36df635eab0823e7795d6afca4dcc31b420eb0d91505436c31093a6184e3ad293f1e113c2e09534b61a0ca7d5b39953cda0825d5437df1e4e579ed9e00eb344aac3ddbff5420c80465166e9d7025c34368dd")
+		signature4 = &tmp
+	}
+
 	tests := []struct {
 		name   string
 		dataIn *dataIn
@@ -322,6 +338,32 @@ func TestProcess(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "WithdrawalAddressCompounding",
+			dataIn: &dataIn{
+				format:            "raw",
+				passphrases:       []string{"pass"},
+				withdrawalAddress: withdrawalAddress,
+				compounding:       true,
+				amount:            32000000000,
+				validatorAccounts: []e2wtypes.Account{interop0},
+				forkVersion:       forkVersion,
+				domain:            domain,
+			},
+			res: []*dataOut{
+				{
+					format:                "raw",
+					account:               "Test/Interop 0",
+					validatorPubKey:       validatorPubKey,
+					amount:                32000000000,
+					withdrawalCredentials: testutil.HexToBytes("0x02000000000000000000000030C99930617B7b793beaB603ecEB08691005f2E5"),
+					signature:             signature4,
+					forkVersion:           forkVersion,
+					depositDataRoot:       depositDataRoot4,
+					depositMessageRoot:    depositMessageRoot4,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
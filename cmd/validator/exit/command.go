@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/viper"
 	"github.com/wealdtech/ethdo/beacon"
 	"github.com/wealdtech/ethdo/services/chaintime"
+	"github.com/wealdtech/ethdo/signing/web3signer"
 	"github.com/wealdtech/ethdo/util"
 )
 
@@ -45,6 +46,7 @@ type command struct {
 	signedOperationsInput string
 	epoch                 string
 	maxDistance           uint64
+	signerURL             string
 
 	// Beacon node connection.
 	timeout                  time.Duration
@@ -54,10 +56,12 @@ type command struct {
 	// Information required to generate the operations.
 	chainInfo *beacon.ChainInfo
 	domain    phase0.Domain
+	forkInfo  *web3signer.ForkInfo
 
 	// Processing.
 	consensusClient consensusclient.Service
 	chainTime       chaintime.Service
+	signer          *web3signer.Service
 
 	// Output.
 	signedOperations []*phase0.SignedVoluntaryExit
@@ -84,6 +88,7 @@ func newCommand(_ context.Context) (*command, error) {
 		genesisValidatorsRoot:    viper.GetString("genesis-validators-root"),
 		epoch:                    viper.GetString("epoch"),
 		maxDistance:              viper.GetUint64("max-distance"),
+		signerURL:                viper.GetString("signer-url"),
 		signedOperations:         make([]*phase0.SignedVoluntaryExit, 0),
 	}
 
@@ -103,5 +108,22 @@ func newCommand(_ context.Context) (*command, error) {
 		return c, nil
 	}
 
+	if c.signerURL != "" {
+		if c.mnemonic != "" || c.privateKey != "" {
+			return nil, errors.New("signer-url cannot be used with mnemonic or private key")
+		}
+		if c.validator == "" {
+			return nil, errors.New("validator is required with signer-url")
+		}
+		var err error
+		c.signer, err = web3signer.New(
+			web3signer.WithURL(c.signerURL),
+			web3signer.WithTimeout(c.timeout),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to set up remote signer")
+		}
+	}
+
 	return c, nil
 }
@@ -33,6 +33,7 @@ import (
 	"github.com/wealdtech/ethdo/beacon"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/signing/web3signer"
 	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	ethutil "github.com/wealdtech/go-eth2-util"
@@ -125,6 +126,9 @@ func (c *command) obtainOperations(ctx context.Context) error {
 	}
 
 	if c.validator != "" {
+		if c.signer != nil {
+			return c.generateOperationFromRemoteSigner(ctx)
+		}
 		return c.generateOperationFromValidator(ctx)
 	}
 
@@ -275,6 +279,42 @@ func (c *command) generateOperationFromValidator(ctx context.Context) error {
 	return c.generateOperationFromAccount(ctx, validatorAccount)
 }
 
+func (c *command) generateOperationFromRemoteSigner(ctx context.Context) error {
+	info, err := c.chainInfo.FetchValidatorInfo(ctx, c.validator)
+	if err != nil {
+		return err
+	}
+
+	epoch, err := c.selectEpoch()
+	if err != nil {
+		return err
+	}
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Using %d for epoch\n", epoch)
+	}
+
+	operation := &phase0.VoluntaryExit{
+		Epoch:          epoch,
+		ValidatorIndex: info.Index,
+	}
+
+	// Sign the operation.
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Signing exit with domain %#x by remote signer for public key %#x\n", c.domain, info.Pubkey)
+	}
+	signature, err := c.signer.SignVoluntaryExit(ctx, info.Pubkey, c.forkInfo, operation, c.domain)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign exit operation")
+	}
+
+	c.signedOperations = append(c.signedOperations, &phase0.SignedVoluntaryExit{
+		Message:   operation,
+		Signature: signature,
+	})
+
+	return nil
+}
+
 func (c *command) obtainOperationsFromFileOrInput(ctx context.Context) error {
 	// Start off by attempting to use the provided signed operations.
 	if c.signedOperationsInput != "" {
@@ -636,6 +676,13 @@ func (c *command) generateDomain(ctx context.Context) error {
 
 	copy(c.domain[:], c.chainInfo.VoluntaryExitDomainType[:])
 	copy(c.domain[4:], root[:])
+	c.forkInfo = &web3signer.ForkInfo{
+		Fork: &phase0.Fork{
+			PreviousVersion: forkVersion,
+			CurrentVersion:  forkVersion,
+		},
+		GenesisValidatorsRoot: genesisValidatorsRoot,
+	}
 	if c.debug {
 		fmt.Fprintf(os.Stderr, "Domain is %#x\n", c.domain)
 	}
@@ -36,6 +36,17 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse withdrawal credentials")
 	}
+	if len(validatorWithdrawalCredentials) != 32 {
+		return nil, errors.New("withdrawal credentials must be 32 bytes")
+	}
+	switch validatorWithdrawalCredentials[0] {
+	case 0x00:
+		// BLS credentials; can be checked against a key.
+	case 0x01, 0x02:
+		return nil, fmt.Errorf("withdrawal credentials are for execution address %#x, not a BLS key", validatorWithdrawalCredentials[12:])
+	default:
+		return nil, fmt.Errorf("withdrawal credentials have unknown type 0x%02x", validatorWithdrawalCredentials[0])
+	}
 
 	match := false
 	path := ""
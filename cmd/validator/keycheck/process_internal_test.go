@@ -0,0 +1,93 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorkeycheck
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestProcess(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	tests := []struct {
+		name  string
+		data  *dataIn
+		match bool
+		path  string
+		err   string
+	}{
+		{
+			name: "Nil",
+			err:  "no data",
+		},
+		{
+			name: "WithdrawalCredentialsShort",
+			data: &dataIn{
+				withdrawalCredentials: "0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc",
+				mnemonic:              "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			},
+			err: "withdrawal credentials must be 32 bytes",
+		},
+		{
+			name: "WithdrawalCredentialsExecution",
+			data: &dataIn{
+				withdrawalCredentials: "0x0100000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+				mnemonic:              "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			},
+			err: "withdrawal credentials are for execution address 0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15, not a BLS key",
+		},
+		{
+			name: "WithdrawalCredentialsCompounding",
+			data: &dataIn{
+				withdrawalCredentials: "0x0200000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+				mnemonic:              "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			},
+			err: "withdrawal credentials are for execution address 0x8c1ff978036f2e9d7cc382eff7b4c8c53c22ac15, not a BLS key",
+		},
+		{
+			name: "WithdrawalCredentialsUnknown",
+			data: &dataIn{
+				withdrawalCredentials: "0x0300000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15",
+				mnemonic:              "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			},
+			err: "withdrawal credentials have unknown type 0x03",
+		},
+		{
+			name: "Good",
+			data: &dataIn{
+				withdrawalCredentials: "0x007e28dcf9029e8d92ca4b5d01c66c934e7f3110606f34ae3052cbf67bd3fc02",
+				mnemonic:              "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			},
+			match: true,
+			path:  "m/12381/3600/10/0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(context.Background(), test.data)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.match, res.match)
+				require.Equal(t, test.path, res.path)
+			}
+		})
+	}
+}
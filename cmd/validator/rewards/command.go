@@ -0,0 +1,145 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/services/chaintime"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	// Beacon node connection.
+	timeout                  time.Duration
+	connection               string
+	allowInsecureConnections bool
+
+	// Chain data cache.
+	cacheDir string
+
+	// Operation.
+	validators []string
+	fromEpoch  string
+	toEpoch    string
+	jsonOutput bool
+	csvOutput  bool
+
+	// Data access.
+	eth2Client             eth2client.Service
+	chainTime              chaintime.Service
+	proposerDutiesProvider eth2client.ProposerDutiesProvider
+	syncCommitteesProvider eth2client.SyncCommitteesProvider
+	validatorsProvider     eth2client.ValidatorsProvider
+	blocksProvider         eth2client.SignedBeaconBlockProvider
+
+	// Processing.
+	indices  []phase0.ValidatorIndex
+	rewards  map[phase0.ValidatorIndex]*validatorRewards
+	tracking map[phase0.ValidatorIndex]bool
+	pubKeys  map[phase0.BLSPubKey]phase0.ValidatorIndex
+
+	// Results.
+	results *rewardsReport
+}
+
+type rewardsReport struct {
+	FromEpoch phase0.Epoch `json:"from_epoch"`
+	ToEpoch   phase0.Epoch `json:"to_epoch"`
+	// EstimatedEpochs are the epochs for which the beacon node could not provide
+	// rewards, so they were estimated from changes in balances.
+	EstimatedEpochs []phase0.Epoch      `json:"estimated_epochs,omitempty"`
+	Validators      []*validatorRewards `json:"validators"`
+	Total           *validatorRewards   `json:"total"`
+}
+
+// validatorRewards contains the rewards for a validator, in Gwei.  Negative values are penalties.
+type validatorRewards struct {
+	Validator      phase0.ValidatorIndex `json:"validator_index"`
+	Source         int64                 `json:"source"`
+	Target         int64                 `json:"target"`
+	Head           int64                 `json:"head"`
+	InclusionDelay int64                 `json:"inclusion_delay"`
+	Inactivity     int64                 `json:"inactivity"`
+	Proposer       int64                 `json:"proposer"`
+	SyncCommittee  int64                 `json:"sync_committee"`
+	// BalanceChange is the change in balance for epochs where rewards were estimated.
+	BalanceChange int64 `json:"balance_change"`
+	Rewards       int64 `json:"rewards"`
+	Penalties     int64 `json:"penalties"`
+	Total         int64 `json:"total"`
+}
+
+// add adds an individual reward or penalty to the rewards.
+func (r *validatorRewards) add(component *int64, amount int64) {
+	*component += amount
+	if amount > 0 {
+		r.Rewards += amount
+	} else {
+		r.Penalties -= amount
+	}
+	r.Total += amount
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:    viper.GetBool("quiet"),
+		verbose:  viper.GetBool("verbose"),
+		debug:    viper.GetBool("debug"),
+		rewards:  make(map[phase0.ValidatorIndex]*validatorRewards),
+		tracking: make(map[phase0.ValidatorIndex]bool),
+		pubKeys:  make(map[phase0.BLSPubKey]phase0.ValidatorIndex),
+		results:  &rewardsReport{},
+	}
+
+	// Timeout.
+	if viper.GetDuration("timeout") == 0 {
+		return nil, errors.New("timeout is required")
+	}
+	c.timeout = viper.GetDuration("timeout")
+
+	c.connection = viper.GetString("connection")
+	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+	c.cacheDir = viper.GetString("cache-dir")
+
+	c.validators = viper.GetStringSlice("validators")
+	if len(c.validators) == 0 {
+		return nil, errors.New("validators are required")
+	}
+
+	c.fromEpoch = viper.GetString("from-epoch")
+	c.toEpoch = viper.GetString("to-epoch")
+	if c.fromEpoch == "" {
+		return nil, errors.New("from-epoch is required")
+	}
+	if c.toEpoch == "" {
+		c.toEpoch = c.fromEpoch
+	}
+
+	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+	if c.jsonOutput && c.csvOutput {
+		return nil, errors.New("cannot specify both JSON and CSV output")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,168 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+func (c *command) output(ctx context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	switch {
+	case c.jsonOutput:
+		return c.outputJSON(ctx)
+	case c.csvOutput:
+		return c.outputCSV(ctx)
+	default:
+		return c.outputTxt(ctx)
+	}
+}
+
+func (c *command) outputJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.results)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (c *command) outputCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+	writer := csv.NewWriter(&builder)
+
+	if err := writer.Write([]string{
+		"validator_index",
+		"source_gwei",
+		"target_gwei",
+		"head_gwei",
+		"inclusion_delay_gwei",
+		"inactivity_gwei",
+		"proposer_gwei",
+		"sync_committee_gwei",
+		"balance_change_gwei",
+		"rewards_gwei",
+		"penalties_gwei",
+		"total_gwei",
+		"total_eth",
+	}); err != nil {
+		return "", err
+	}
+	for _, rewards := range c.results.Validators {
+		if err := writer.Write(csvRecord(fmt.Sprintf("%d", rewards.Validator), rewards)); err != nil {
+			return "", err
+		}
+	}
+	if err := writer.Write(csvRecord("total", c.results.Total)); err != nil {
+		return "", err
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func csvRecord(name string, rewards *validatorRewards) []string {
+	return []string{
+		name,
+		fmt.Sprintf("%d", rewards.Source),
+		fmt.Sprintf("%d", rewards.Target),
+		fmt.Sprintf("%d", rewards.Head),
+		fmt.Sprintf("%d", rewards.InclusionDelay),
+		fmt.Sprintf("%d", rewards.Inactivity),
+		fmt.Sprintf("%d", rewards.Proposer),
+		fmt.Sprintf("%d", rewards.SyncCommittee),
+		fmt.Sprintf("%d", rewards.BalanceChange),
+		fmt.Sprintf("%d", rewards.Rewards),
+		fmt.Sprintf("%d", rewards.Penalties),
+		fmt.Sprintf("%d", rewards.Total),
+		gweiToETH(rewards.Total),
+	}
+}
+
+func (c *command) outputTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Epochs %d-%d:\n", c.results.FromEpoch, c.results.ToEpoch))
+	if len(c.results.EstimatedEpochs) > 0 {
+		epochs := make([]string, len(c.results.EstimatedEpochs))
+		for i, epoch := range c.results.EstimatedEpochs {
+			epochs[i] = fmt.Sprintf("%d", epoch)
+		}
+		builder.WriteString(fmt.Sprintf("  Rewards estimated from balance changes for epochs %s\n", strings.Join(epochs, ", ")))
+	}
+	for _, rewards := range c.results.Validators {
+		builder.WriteString(fmt.Sprintf("  Validator %d:\n", rewards.Validator))
+		c.outputRewardsTxt(&builder, rewards)
+	}
+	if len(c.results.Validators) > 1 {
+		builder.WriteString("  Total:\n")
+		c.outputRewardsTxt(&builder, c.results.Total)
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputRewardsTxt(builder *strings.Builder, rewards *validatorRewards) {
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("    Source: %s\n", formatGwei(rewards.Source)))
+		builder.WriteString(fmt.Sprintf("    Target: %s\n", formatGwei(rewards.Target)))
+		builder.WriteString(fmt.Sprintf("    Head: %s\n", formatGwei(rewards.Head)))
+		if rewards.InclusionDelay != 0 {
+			builder.WriteString(fmt.Sprintf("    Inclusion delay: %s\n", formatGwei(rewards.InclusionDelay)))
+		}
+		if rewards.Inactivity != 0 {
+			builder.WriteString(fmt.Sprintf("    Inactivity: %s\n", formatGwei(rewards.Inactivity)))
+		}
+		builder.WriteString(fmt.Sprintf("    Proposer: %s\n", formatGwei(rewards.Proposer)))
+		builder.WriteString(fmt.Sprintf("    Sync committee: %s\n", formatGwei(rewards.SyncCommittee)))
+		if rewards.BalanceChange != 0 {
+			builder.WriteString(fmt.Sprintf("    Balance change: %s\n", formatGwei(rewards.BalanceChange)))
+		}
+	} else {
+		builder.WriteString(fmt.Sprintf("    Attestations: %s\n", formatGwei(rewards.Source+rewards.Target+rewards.Head+rewards.InclusionDelay+rewards.Inactivity)))
+		if rewards.Proposer != 0 {
+			builder.WriteString(fmt.Sprintf("    Proposer: %s\n", formatGwei(rewards.Proposer)))
+		}
+		if rewards.SyncCommittee != 0 {
+			builder.WriteString(fmt.Sprintf("    Sync committee: %s\n", formatGwei(rewards.SyncCommittee)))
+		}
+		if rewards.BalanceChange != 0 {
+			builder.WriteString(fmt.Sprintf("    Balance change: %s\n", formatGwei(rewards.BalanceChange)))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("    Rewards: %s\n", formatGwei(rewards.Rewards)))
+	builder.WriteString(fmt.Sprintf("    Penalties: %s\n", formatGwei(rewards.Penalties)))
+	builder.WriteString(fmt.Sprintf("    Total: %s\n", formatGwei(rewards.Total)))
+}
+
+// formatGwei formats an amount in Gwei with its equivalent in ETH.
+func formatGwei(amount int64) string {
+	return fmt.Sprintf("%d Gwei (%s ETH)", amount, gweiToETH(amount))
+}
+
+func gweiToETH(amount int64) string {
+	return decimal.New(amount, -9).String()
+}
@@ -0,0 +1,43 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputCSV(t *testing.T) {
+	rewards := &validatorRewards{
+		Validator: 1,
+	}
+	rewards.add(&rewards.Source, 3000)
+	rewards.add(&rewards.Target, -5000)
+	rewards.add(&rewards.Proposer, 40000000)
+
+	c := &command{
+		csvOutput: true,
+		results: &rewardsReport{
+			Validators: []*validatorRewards{rewards},
+			Total:      rewards,
+		},
+	}
+	res, err := c.output(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, `validator_index,source_gwei,target_gwei,head_gwei,inclusion_delay_gwei,inactivity_gwei,proposer_gwei,sync_committee_gwei,balance_change_gwei,rewards_gwei,penalties_gwei,total_gwei,total_eth
+1,3000,-5000,0,0,0,40000000,0,0,40003000,5000,39998000,0.039998
+total,3000,-5000,0,0,0,40000000,0,0,40003000,5000,39998000,0.039998`, res)
+}
@@ -0,0 +1,356 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorrewards
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	diskchaincache "github.com/wealdtech/ethdo/services/chaincache/disk"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	var err error
+	c.results.FromEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	c.results.ToEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	if c.results.FromEpoch > c.results.ToEpoch {
+		return errors.New("from epoch cannot be after to epoch")
+	}
+	// Rewards for an epoch are only known once the following epoch has completed.
+	if c.results.ToEpoch+2 > c.chainTime.CurrentEpoch() {
+		return fmt.Errorf("rewards for epoch %d are not yet available", c.results.ToEpoch)
+	}
+
+	validators, err := util.ParseValidators(ctx, c.validatorsProvider, c.validators, "head")
+	if err != nil {
+		return errors.Wrap(err, "failed to parse validators")
+	}
+	for _, validator := range validators {
+		c.indices = append(c.indices, validator.Index)
+		c.tracking[validator.Index] = true
+		c.pubKeys[validator.Validator.PublicKey] = validator.Index
+		c.rewards[validator.Index] = &validatorRewards{
+			Validator: validator.Index,
+		}
+	}
+	sort.Slice(c.indices, func(i int, j int) bool {
+		return c.indices[i] < c.indices[j]
+	})
+
+	for epoch := c.results.FromEpoch; epoch <= c.results.ToEpoch; epoch++ {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Processing epoch %d\n", epoch)
+		}
+		if err := c.processEpoch(ctx, epoch); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to process epoch %d", epoch))
+		}
+	}
+
+	c.results.Validators = make([]*validatorRewards, 0, len(c.indices))
+	c.results.Total = &validatorRewards{}
+	for _, index := range c.indices {
+		rewards := c.rewards[index]
+		c.results.Validators = append(c.results.Validators, rewards)
+		c.results.Total.Source += rewards.Source
+		c.results.Total.Target += rewards.Target
+		c.results.Total.Head += rewards.Head
+		c.results.Total.InclusionDelay += rewards.InclusionDelay
+		c.results.Total.Inactivity += rewards.Inactivity
+		c.results.Total.Proposer += rewards.Proposer
+		c.results.Total.SyncCommittee += rewards.SyncCommittee
+		c.results.Total.BalanceChange += rewards.BalanceChange
+		c.results.Total.Rewards += rewards.Rewards
+		c.results.Total.Penalties += rewards.Penalties
+		c.results.Total.Total += rewards.Total
+	}
+
+	return nil
+}
+
+// processEpoch obtains the rewards for an epoch, falling back to balance changes
+// if the beacon node cannot provide them.
+func (c *command) processEpoch(ctx context.Context, epoch phase0.Epoch) error {
+	attestationRewards, err := util.ObtainAttestationRewards(ctx, c.eth2Client, epoch, c.indices)
+	if err != nil {
+		// Only fall back if the beacon node does not support the rewards API.
+		var apiErr *util.BeaconAPIError
+		if !errors.As(err, &apiErr) ||
+			(apiErr.StatusCode != http.StatusNotFound && apiErr.StatusCode != http.StatusNotImplemented) {
+			return errors.Wrap(err, "failed to obtain attestation rewards")
+		}
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Attestation rewards not available (%v); using balance changes\n", err)
+		}
+		c.results.EstimatedEpochs = append(c.results.EstimatedEpochs, epoch)
+
+		return c.processBalanceChanges(ctx, epoch)
+	}
+
+	for _, reward := range attestationRewards {
+		rewards, exists := c.rewards[reward.Validator]
+		if !exists {
+			continue
+		}
+		rewards.add(&rewards.Source, reward.Source)
+		rewards.add(&rewards.Target, reward.Target)
+		rewards.add(&rewards.Head, reward.Head)
+		rewards.add(&rewards.InclusionDelay, reward.InclusionDelay)
+		rewards.add(&rewards.Inactivity, reward.Inactivity)
+	}
+
+	if err := c.processProposerRewards(ctx, epoch); err != nil {
+		return err
+	}
+
+	return c.processSyncCommitteeRewards(ctx, epoch)
+}
+
+func (c *command) processProposerRewards(ctx context.Context, epoch phase0.Epoch) error {
+	response, err := c.proposerDutiesProvider.ProposerDuties(ctx, &api.ProposerDutiesOpts{
+		Epoch:   epoch,
+		Indices: c.indices,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain proposer duties")
+	}
+
+	for _, duty := range response.Data {
+		if !c.tracking[duty.ValidatorIndex] {
+			continue
+		}
+		reward, err := util.ObtainBlockRewards(ctx, c.eth2Client, fmt.Sprintf("%d", duty.Slot))
+		if err != nil {
+			var apiErr *util.BeaconAPIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// No block, so no reward.
+				continue
+			}
+
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block rewards for slot %d", duty.Slot))
+		}
+		rewards := c.rewards[duty.ValidatorIndex]
+		rewards.add(&rewards.Proposer, reward.Total)
+	}
+
+	return nil
+}
+
+func (c *command) processSyncCommitteeRewards(ctx context.Context, epoch phase0.Epoch) error {
+	if epoch < c.chainTime.AltairInitialEpoch() {
+		// No sync committees prior to Altair.
+		return nil
+	}
+
+	firstSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+	response, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{
+		State: fmt.Sprintf("%d", firstSlot),
+		Epoch: &epoch,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee")
+	}
+
+	members := make([]phase0.ValidatorIndex, 0)
+	included := make(map[phase0.ValidatorIndex]bool)
+	for _, index := range response.Data.Validators {
+		if c.tracking[index] && !included[index] {
+			members = append(members, index)
+			included[index] = true
+		}
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	for slot := firstSlot; slot < c.chainTime.FirstSlotOfEpoch(epoch+1); slot++ {
+		syncRewards, err := util.ObtainSyncCommitteeRewards(ctx, c.eth2Client, fmt.Sprintf("%d", slot), members)
+		if err != nil {
+			var apiErr *util.BeaconAPIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// No block, so no reward.
+				continue
+			}
+
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain sync committee rewards for slot %d", slot))
+		}
+		for _, reward := range syncRewards {
+			rewards, exists := c.rewards[reward.Validator]
+			if !exists {
+				continue
+			}
+			rewards.add(&rewards.SyncCommittee, reward.Reward)
+		}
+	}
+
+	return nil
+}
+
+// processBalanceChanges estimates the rewards for an epoch from the change in the
+// validators' balances across the epoch, adding back any withdrawals and removing any deposits.
+func (c *command) processBalanceChanges(ctx context.Context, epoch phase0.Epoch) error {
+	startSlot := c.chainTime.FirstSlotOfEpoch(epoch)
+	endSlot := c.chainTime.FirstSlotOfEpoch(epoch + 1)
+
+	startBalances, err := c.balances(ctx, startSlot)
+	if err != nil {
+		return err
+	}
+	endBalances, err := c.balances(ctx, endSlot)
+	if err != nil {
+		return err
+	}
+
+	withdrawn := make(map[phase0.ValidatorIndex]int64)
+	deposited := make(map[phase0.ValidatorIndex]int64)
+	for slot := startSlot + 1; slot <= endSlot; slot++ {
+		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+
+		deposits, err := blockResponse.Data.Deposits()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain deposits for slot %d", slot))
+		}
+		for _, deposit := range deposits {
+			if index, exists := c.pubKeys[deposit.Data.PublicKey]; exists {
+				deposited[index] += int64(deposit.Data.Amount)
+			}
+		}
+
+		if c.chainTime.SlotToEpoch(slot) < c.chainTime.CapellaInitialEpoch() {
+			continue
+		}
+		withdrawals, err := blockResponse.Data.Withdrawals()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain withdrawals for slot %d", slot))
+		}
+		for _, withdrawal := range withdrawals {
+			if c.tracking[withdrawal.ValidatorIndex] {
+				withdrawn[withdrawal.ValidatorIndex] += int64(withdrawal.Amount)
+			}
+		}
+	}
+
+	for _, index := range c.indices {
+		startBalance, exists := startBalances[index]
+		if !exists {
+			// Validator not yet on the chain.
+			continue
+		}
+		rewards := c.rewards[index]
+		rewards.add(&rewards.BalanceChange, endBalances[index]-startBalance+withdrawn[index]-deposited[index])
+	}
+
+	return nil
+}
+
+func (c *command) balances(ctx context.Context, slot phase0.Slot) (map[phase0.ValidatorIndex]int64, error) {
+	response, err := c.validatorsProvider.Validators(ctx, &api.ValidatorsOpts{
+		State:   fmt.Sprintf("%d", slot),
+		Indices: c.indices,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain validator balances at slot %d", slot))
+	}
+
+	res := make(map[phase0.ValidatorIndex]int64, len(response.Data))
+	for index, validator := range response.Data {
+		res[index] = int64(validator.Balance)
+	}
+
+	return res, nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	var err error
+
+	// Connect to the client.
+	c.eth2Client, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to beacon node")
+	}
+
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithSpecProvider(c.eth2Client.(eth2client.SpecProvider)),
+		standardchaintime.WithGenesisProvider(c.eth2Client.(eth2client.GenesisProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to set up chaintime service")
+	}
+
+	var isProvider bool
+	c.proposerDutiesProvider, isProvider = c.eth2Client.(eth2client.ProposerDutiesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide proposer duties")
+	}
+	c.syncCommitteesProvider, isProvider = c.eth2Client.(eth2client.SyncCommitteesProvider)
+	if !isProvider {
+		return errors.New("connection does not provide sync committee duties")
+	}
+	c.validatorsProvider, isProvider = c.eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return errors.New("connection does not provide validators")
+	}
+	c.blocksProvider, isProvider = c.eth2Client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return errors.New("connection does not provide signed beacon blocks")
+	}
+
+	if c.cacheDir != "" {
+		// Use the on-disk cache for historical chain data.
+		cache, err := diskchaincache.New(ctx,
+			diskchaincache.WithBaseDir(c.cacheDir),
+			diskchaincache.WithClient(c.eth2Client),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to set up chain data cache")
+		}
+		c.validatorsProvider = cache
+		c.blocksProvider = cache
+	}
+
+	return nil
+}
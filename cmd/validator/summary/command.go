@@ -35,10 +35,16 @@ type command struct {
 	connection               string
 	allowInsecureConnections bool
 
+	// Chain data cache.
+	cacheDir string
+
 	// Operation.
 	epoch      string
+	fromEpoch  string
+	toEpoch    string
 	validators []string
 	jsonOutput bool
+	csvOutput  bool
 
 	// Data access.
 	eth2Client                 eth2client.Service
@@ -56,6 +62,7 @@ type command struct {
 
 	// Results.
 	summary *validatorSummary
+	report  *validatorReport
 }
 
 type validatorSummary struct {
@@ -74,6 +81,8 @@ type validatorSummary struct {
 	Slots                      []*slot                      `json:"slots"`
 	Proposals                  []*epochProposal             `json:"-"`
 	SyncCommittee              []*epochSyncCommittee        `json:"-"`
+	// Attestations contains the included attestation for each validator.
+	Attestations map[phase0.ValidatorIndex]*validatorAttestation `json:"-"`
 }
 
 type slot struct {
@@ -98,12 +107,23 @@ type epochProposal struct {
 }
 
 type epochSyncCommittee struct {
-	Index  phase0.ValidatorIndex `json:"index"`
-	Missed int                   `json:"missed"`
+	Index    phase0.ValidatorIndex `json:"index"`
+	Expected int                   `json:"expected"`
+	Missed   int                   `json:"missed"`
+}
+
+type validatorAttestation struct {
+	InclusionDelay int
+	CorrectHead    bool
+	TimelyHead     bool
+	TimelySource   bool
+	CorrectTarget  bool
+	TimelyTarget   bool
 }
 
 type validatorFault struct {
 	Validator         phase0.ValidatorIndex   `json:"validator_index"`
+	Committee         phase0.CommitteeIndex   `json:"committee_index"`
 	AttestationData   *phase0.AttestationData `json:"attestation_data,omitempty"`
 	InclusionDistance int                     `json:"inclusion_delay"`
 }
@@ -131,10 +151,32 @@ func newCommand(_ context.Context) (*command, error) {
 
 	c.connection = viper.GetString("connection")
 	c.allowInsecureConnections = viper.GetBool("allow-insecure-connections")
+	c.cacheDir = viper.GetString("cache-dir")
 
 	c.epoch = viper.GetString("epoch")
+	c.fromEpoch = viper.GetString("from-epoch")
+	c.toEpoch = viper.GetString("to-epoch")
 	c.validators = viper.GetStringSlice("validators")
 	c.jsonOutput = viper.GetBool("json")
+	c.csvOutput = viper.GetBool("csv")
+
+	if c.fromEpoch != "" || c.toEpoch != "" {
+		if c.fromEpoch == "" || c.toEpoch == "" {
+			return nil, errors.New("both from-epoch and to-epoch are required for a report")
+		}
+		if c.epoch != "" {
+			return nil, errors.New("cannot specify epoch with from-epoch and to-epoch")
+		}
+		c.report = newValidatorReport()
+	}
+	if c.csvOutput {
+		if c.report == nil {
+			return nil, errors.New("CSV output is only available with from-epoch and to-epoch")
+		}
+		if c.jsonOutput {
+			return nil, errors.New("cannot specify both JSON and CSV output")
+		}
+	}
 
 	return c, nil
 }
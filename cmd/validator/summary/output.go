@@ -15,6 +15,7 @@ package validatorsummary
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -25,6 +26,17 @@ func (c *command) output(ctx context.Context) (string, error) {
 		return "", nil
 	}
 
+	if c.report != nil {
+		switch {
+		case c.jsonOutput:
+			return c.outputReportJSON(ctx)
+		case c.csvOutput:
+			return c.outputReportCSV(ctx)
+		default:
+			return c.outputReportTxt(ctx)
+		}
+	}
+
 	if c.jsonOutput {
 		return c.outputJSON(ctx)
 	}
@@ -54,31 +66,119 @@ func (c *command) outputTxt(_ context.Context) (string, error) {
 	if len(c.summary.IncorrectHeadValidators) > 0 {
 		builder.WriteString("  Incorrect head validators:\n")
 		for _, validator := range c.summary.IncorrectHeadValidators {
-			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d)\n", validator.Validator, validator.AttestationData.Slot, validator.AttestationData.Index))
+			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d)\n", validator.Validator, validator.AttestationData.Slot, validator.Committee))
 		}
 	}
 	if len(c.summary.UntimelyHeadValidators) > 0 {
 		builder.WriteString("  Untimely head validators:\n")
 		for _, validator := range c.summary.UntimelyHeadValidators {
-			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d, inclusion distance %d)\n", validator.Validator, validator.AttestationData.Slot, validator.AttestationData.Index, validator.InclusionDistance))
+			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d, inclusion distance %d)\n", validator.Validator, validator.AttestationData.Slot, validator.Committee, validator.InclusionDistance))
 		}
 	}
 	if len(c.summary.UntimelySourceValidators) > 0 {
 		builder.WriteString("  Untimely source validators:\n")
 		for _, validator := range c.summary.UntimelySourceValidators {
-			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d, inclusion distance %d)\n", validator.Validator, validator.AttestationData.Slot, validator.AttestationData.Index, validator.InclusionDistance))
+			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d, inclusion distance %d)\n", validator.Validator, validator.AttestationData.Slot, validator.Committee, validator.InclusionDistance))
 		}
 	}
 	if len(c.summary.IncorrectTargetValidators) > 0 {
 		builder.WriteString("  Incorrect target validators:\n")
 		for _, validator := range c.summary.IncorrectTargetValidators {
-			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d)\n", validator.Validator, validator.AttestationData.Slot, validator.AttestationData.Index))
+			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d)\n", validator.Validator, validator.AttestationData.Slot, validator.Committee))
 		}
 	}
 	if len(c.summary.UntimelyTargetValidators) > 0 {
 		builder.WriteString("  Untimely target validators:\n")
 		for _, validator := range c.summary.UntimelyTargetValidators {
-			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d, inclusion distance %d)\n", validator.Validator, validator.AttestationData.Slot, validator.AttestationData.Index, validator.InclusionDistance))
+			builder.WriteString(fmt.Sprintf("    %d (slot %d, committee %d, inclusion distance %d)\n", validator.Validator, validator.AttestationData.Slot, validator.Committee, validator.InclusionDistance))
+		}
+	}
+
+	return builder.String(), nil
+}
+
+func (c *command) outputReportJSON(_ context.Context) (string, error) {
+	data, err := json.Marshal(c.report)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *command) outputReportCSV(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+	writer := csv.NewWriter(&builder)
+
+	if err := writer.Write([]string{
+		"validator_index",
+		"active_epochs",
+		"attestations_expected",
+		"attestations_included",
+		"participation_rate",
+		"correct_head_rate",
+		"timely_head_rate",
+		"timely_source_rate",
+		"correct_target_rate",
+		"timely_target_rate",
+		"average_inclusion_delay",
+		"proposals_expected",
+		"proposals_missed",
+		"sync_committee_expected",
+		"sync_committee_missed",
+	}); err != nil {
+		return "", err
+	}
+	for _, performance := range c.report.Validators {
+		if err := writer.Write([]string{
+			fmt.Sprintf("%d", performance.Validator),
+			fmt.Sprintf("%d", performance.ActiveEpochs),
+			fmt.Sprintf("%d", performance.AttestationsExpected),
+			fmt.Sprintf("%d", performance.AttestationsIncluded),
+			fmt.Sprintf("%.4f", performance.ParticipationRate),
+			fmt.Sprintf("%.4f", performance.CorrectHeadRate),
+			fmt.Sprintf("%.4f", performance.TimelyHeadRate),
+			fmt.Sprintf("%.4f", performance.TimelySourceRate),
+			fmt.Sprintf("%.4f", performance.CorrectTargetRate),
+			fmt.Sprintf("%.4f", performance.TimelyTargetRate),
+			fmt.Sprintf("%.4f", performance.AverageInclusionDelay),
+			fmt.Sprintf("%d", performance.ProposalsExpected),
+			fmt.Sprintf("%d", performance.ProposalsMissed),
+			fmt.Sprintf("%d", performance.SyncCommitteeExpected),
+			fmt.Sprintf("%d", performance.SyncCommitteeMissed),
+		}); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+func (c *command) outputReportTxt(_ context.Context) (string, error) {
+	builder := strings.Builder{}
+
+	builder.WriteString(fmt.Sprintf("Epochs %d-%d:\n", c.report.FromEpoch, c.report.ToEpoch))
+	for _, performance := range c.report.Validators {
+		builder.WriteString(fmt.Sprintf("  Validator %d:\n", performance.Validator))
+		if performance.AttestationsExpected > 0 {
+			builder.WriteString(fmt.Sprintf("    Attestations included: %d/%d (%.2f%%)\n", performance.AttestationsIncluded, performance.AttestationsExpected, 100*performance.ParticipationRate))
+			builder.WriteString(fmt.Sprintf("    Correct head: %.2f%%\n", 100*performance.CorrectHeadRate))
+			builder.WriteString(fmt.Sprintf("    Timely head: %.2f%%\n", 100*performance.TimelyHeadRate))
+			builder.WriteString(fmt.Sprintf("    Timely source: %.2f%%\n", 100*performance.TimelySourceRate))
+			builder.WriteString(fmt.Sprintf("    Correct target: %.2f%%\n", 100*performance.CorrectTargetRate))
+			builder.WriteString(fmt.Sprintf("    Timely target: %.2f%%\n", 100*performance.TimelyTargetRate))
+			if performance.AttestationsIncluded > 0 {
+				builder.WriteString(fmt.Sprintf("    Average inclusion delay: %.2f\n", performance.AverageInclusionDelay))
+			}
+		}
+		if performance.ProposalsExpected > 0 {
+			builder.WriteString(fmt.Sprintf("    Proposals missed: %d/%d\n", performance.ProposalsMissed, performance.ProposalsExpected))
+		}
+		if performance.SyncCommitteeExpected > 0 {
+			builder.WriteString(fmt.Sprintf("    Sync committee messages missed: %d/%d\n", performance.SyncCommitteeMissed, performance.SyncCommitteeExpected))
 		}
 	}
 
@@ -22,8 +22,11 @@ import (
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
 	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/go-bitfield"
+	diskchaincache "github.com/wealdtech/ethdo/services/chaincache/disk"
 	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
 	"github.com/wealdtech/ethdo/util"
 )
@@ -39,10 +42,57 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	c.summary.Epoch, err = util.ParseEpoch(ctx, c.chainTime, c.epoch)
+	if c.report != nil {
+		return c.processRange(ctx)
+	}
+
+	epoch, err := util.ParseEpoch(ctx, c.chainTime, c.epoch)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse epoch")
 	}
+
+	return c.processEpoch(ctx, epoch)
+}
+
+// processRange processes each epoch in the range, aggregating the results in to the report.
+func (c *command) processRange(ctx context.Context) error {
+	var err error
+	c.report.FromEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.fromEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from epoch")
+	}
+	c.report.ToEpoch, err = util.ParseEpoch(ctx, c.chainTime, c.toEpoch)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to epoch")
+	}
+	if c.report.FromEpoch > c.report.ToEpoch {
+		return errors.New("from epoch cannot be after to epoch")
+	}
+
+	for epoch := c.report.FromEpoch; epoch <= c.report.ToEpoch; epoch++ {
+		if c.debug {
+			fmt.Printf("Processing epoch %d\n", epoch)
+		}
+		if err := c.processEpoch(ctx, epoch); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to process epoch %d", epoch))
+		}
+		if err := c.processSyncCommitteeDuties(ctx); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to process sync committee duties for epoch %d", epoch))
+		}
+		c.report.add(c.summary)
+	}
+	c.report.finalize()
+
+	return nil
+}
+
+// processEpoch processes a single epoch, placing the results in the summary.
+func (c *command) processEpoch(ctx context.Context, epoch phase0.Epoch) error {
+	var err error
+	c.summary = &validatorSummary{
+		Epoch:        epoch,
+		Attestations: make(map[phase0.ValidatorIndex]*validatorAttestation),
+	}
 	c.summary.FirstSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch)
 	c.summary.LastSlot = c.chainTime.FirstSlotOfEpoch(c.summary.Epoch+1) - 1
 	c.summary.Slots = make([]*slot, 1+int(c.summary.LastSlot)-int(c.summary.FirstSlot))
@@ -71,15 +121,7 @@ func (c *command) process(ctx context.Context) error {
 		return err
 	}
 
-	if err := c.processAttesterDuties(ctx); err != nil {
-		return err
-	}
-
-	// if err := c.processSyncCommitteeDuties(ctx); err != nil {
-	// 	return err
-	// }
-
-	return nil
+	return c.processAttesterDuties(ctx)
 }
 
 func (c *command) processProposerDuties(ctx context.Context) error {
@@ -96,16 +138,16 @@ func (c *command) processProposerDuties(ctx context.Context) error {
 		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
 			Block: fmt.Sprintf("%d", duty.Slot),
 		})
+		present := false
 		if err != nil {
 			var apiErr *api.Error
-			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
-				return nil
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+				return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", duty.Slot))
 			}
-
-			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", duty.Slot))
+			// No block, so a missed proposal.
+		} else {
+			present = blockResponse.Data != nil
 		}
-		block := blockResponse.Data
-		present := block != nil
 		c.summary.Proposals = append(c.summary.Proposals, &epochProposal{
 			Slot:     duty.Slot,
 			Proposer: duty.ValidatorIndex,
@@ -157,6 +199,8 @@ func (c *command) processAttesterDuties(ctx context.Context) error {
 
 	// Need a cache of beacon block headers to reduce lookup times.
 	headersCache := util.NewBeaconBlockHeaderCache(c.beaconBlockHeadersProvider)
+	// Need a cache of beacon committees to decode aggregation bits.
+	committeesCache := util.NewBeaconCommitteesCache(c.beaconCommitteesProvider)
 
 	// Need a map of duties to easily find the attestations we care about.
 	dutiesBySlot := make(map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty)
@@ -183,7 +227,7 @@ func (c *command) processAttesterDuties(ctx context.Context) error {
 	// Hunt through the blocks looking for attestations from the validators.
 	votes := make(map[phase0.ValidatorIndex]struct{})
 	for slot := firstSlot; slot <= lastSlot; slot++ {
-		if err := c.processAttesterDutiesSlot(ctx, slot, dutiesBySlot, votes, headersCache, activeValidatorIndices); err != nil {
+		if err := c.processAttesterDutiesSlot(ctx, slot, dutiesBySlot, votes, headersCache, committeesCache, activeValidatorIndices); err != nil {
 			return err
 		}
 	}
@@ -224,6 +268,7 @@ func (c *command) processAttesterDutiesSlot(ctx context.Context,
 	dutiesBySlot map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty,
 	votes map[phase0.ValidatorIndex]struct{},
 	headersCache *util.BeaconBlockHeaderCache,
+	committeesCache *util.BeaconCommitteesCache,
 	activeValidatorIndices []phase0.ValidatorIndex,
 ) error {
 	blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
@@ -243,157 +288,203 @@ func (c *command) processAttesterDutiesSlot(ctx context.Context,
 		return err
 	}
 	for _, attestation := range attestations {
-		if _, exists := dutiesBySlot[attestation.Data.Slot]; !exists {
+		data, err := attestation.Data()
+		if err != nil {
+			return err
+		}
+		if _, exists := dutiesBySlot[data.Slot]; !exists {
 			// We do not have any attestations for this slot.
 			continue
 		}
-		if _, exists := dutiesBySlot[attestation.Data.Slot][attestation.Data.Index]; !exists {
-			// We do not have any attestations for this committee.
-			continue
+		committeeBits, err := util.AttestationCommitteeBits(ctx, committeesCache, attestation)
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain attestation committee bits")
+		}
+		for committeeIndex, aggregationBits := range committeeBits {
+			if _, exists := dutiesBySlot[data.Slot][committeeIndex]; !exists {
+				// We do not have any attestations for this committee.
+				continue
+			}
+			if err := c.processAttestationDuties(ctx, slot, data, attestation, aggregationBits, dutiesBySlot[data.Slot][committeeIndex], votes, headersCache); err != nil {
+				return err
+			}
 		}
-		for _, duty := range dutiesBySlot[attestation.Data.Slot][attestation.Data.Index] {
-			if attestation.AggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
-				// Found it.
-				if _, exists := votes[duty.ValidatorIndex]; exists {
-					// Duplicate; ignore.
-					continue
-				}
-				votes[duty.ValidatorIndex] = struct{}{}
 
-				// Update the metrics for the attestation.
-				index := int(attestation.Data.Slot - c.chainTime.FirstSlotOfEpoch(c.summary.Epoch))
-				c.summary.Slots[index].Attestations.Included++
-				inclusionDelay := slot - duty.Slot
+		if len(votes) == len(activeValidatorIndices) {
+			// Found them all.
+			break
+		}
+	}
 
-				fault := &validatorFault{
-					Validator:         duty.ValidatorIndex,
-					AttestationData:   attestation.Data,
-					InclusionDistance: int(inclusionDelay),
-				}
+	return nil
+}
 
-				headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, attestation)
-				if err != nil {
-					return errors.Wrap(err, "failed to calculate if attestation had correct head vote")
-				}
-				if headCorrect {
-					c.summary.Slots[index].Attestations.CorrectHead++
-					if inclusionDelay == 1 {
-						c.summary.Slots[index].Attestations.TimelyHead++
-					} else {
-						c.summary.UntimelyHeadValidators = append(c.summary.UntimelyHeadValidators, fault)
-					}
-				} else {
-					c.summary.IncorrectHeadValidators = append(c.summary.IncorrectHeadValidators, fault)
-					if inclusionDelay > 1 {
-						c.summary.UntimelyHeadValidators = append(c.summary.UntimelyHeadValidators, fault)
-					}
-				}
+// processAttestationDuties processes the duties of a single committee covered by an attestation.
+func (c *command) processAttestationDuties(ctx context.Context,
+	slot phase0.Slot,
+	data *phase0.AttestationData,
+	attestation *spec.VersionedAttestation,
+	aggregationBits bitfield.Bitlist,
+	duties []*apiv1.AttesterDuty,
+	votes map[phase0.ValidatorIndex]struct{},
+	headersCache *util.BeaconBlockHeaderCache,
+) error {
+	for _, duty := range duties {
+		if aggregationBits.BitAt(duty.ValidatorCommitteeIndex) {
+			// Found it.
+			if _, exists := votes[duty.ValidatorIndex]; exists {
+				// Duplicate; ignore.
+				continue
+			}
+			votes[duty.ValidatorIndex] = struct{}{}
+
+			// Update the metrics for the attestation.
+			index := int(data.Slot - c.chainTime.FirstSlotOfEpoch(c.summary.Epoch))
+			c.summary.Slots[index].Attestations.Included++
+			inclusionDelay := slot - duty.Slot
+
+			fault := &validatorFault{
+				Validator:         duty.ValidatorIndex,
+				Committee:         duty.CommitteeIndex,
+				AttestationData:   data,
+				InclusionDistance: int(inclusionDelay),
+			}
+			record := &validatorAttestation{
+				InclusionDelay: int(inclusionDelay),
+			}
+			c.summary.Attestations[duty.ValidatorIndex] = record
 
-				if inclusionDelay <= 5 {
-					c.summary.Slots[index].Attestations.TimelySource++
+			headCorrect, err := util.AttestationHeadCorrect(ctx, headersCache, attestation)
+			if err != nil {
+				return errors.Wrap(err, "failed to calculate if attestation had correct head vote")
+			}
+			if headCorrect {
+				c.summary.Slots[index].Attestations.CorrectHead++
+				record.CorrectHead = true
+				if inclusionDelay == 1 {
+					c.summary.Slots[index].Attestations.TimelyHead++
+					record.TimelyHead = true
 				} else {
-					c.summary.UntimelySourceValidators = append(c.summary.UntimelySourceValidators, fault)
+					c.summary.UntimelyHeadValidators = append(c.summary.UntimelyHeadValidators, fault)
 				}
-
-				targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, attestation)
-				if err != nil {
-					return errors.Wrap(err, "failed to calculate if attestation had correct target vote")
+			} else {
+				c.summary.IncorrectHeadValidators = append(c.summary.IncorrectHeadValidators, fault)
+				if inclusionDelay > 1 {
+					c.summary.UntimelyHeadValidators = append(c.summary.UntimelyHeadValidators, fault)
 				}
-				if targetCorrect {
-					c.summary.Slots[index].Attestations.CorrectTarget++
-					if inclusionDelay <= 32 {
-						c.summary.Slots[index].Attestations.TimelyTarget++
-					} else {
-						c.summary.UntimelyTargetValidators = append(c.summary.UntimelyTargetValidators, fault)
-					}
+			}
+
+			if inclusionDelay <= 5 {
+				c.summary.Slots[index].Attestations.TimelySource++
+				record.TimelySource = true
+			} else {
+				c.summary.UntimelySourceValidators = append(c.summary.UntimelySourceValidators, fault)
+			}
+
+			targetCorrect, err := util.AttestationTargetCorrect(ctx, headersCache, c.chainTime, attestation)
+			if err != nil {
+				return errors.Wrap(err, "failed to calculate if attestation had correct target vote")
+			}
+			if targetCorrect {
+				c.summary.Slots[index].Attestations.CorrectTarget++
+				record.CorrectTarget = true
+				if inclusionDelay <= 32 {
+					c.summary.Slots[index].Attestations.TimelyTarget++
+					record.TimelyTarget = true
 				} else {
-					c.summary.IncorrectTargetValidators = append(c.summary.IncorrectTargetValidators, fault)
-					if inclusionDelay > 32 {
-						c.summary.UntimelyTargetValidators = append(c.summary.UntimelyTargetValidators, fault)
-					}
+					c.summary.UntimelyTargetValidators = append(c.summary.UntimelyTargetValidators, fault)
+				}
+			} else {
+				c.summary.IncorrectTargetValidators = append(c.summary.IncorrectTargetValidators, fault)
+				if inclusionDelay > 32 {
+					c.summary.UntimelyTargetValidators = append(c.summary.UntimelyTargetValidators, fault)
 				}
 			}
 		}
+	}
 
-		if len(votes) == len(activeValidatorIndices) {
-			// Found them all.
-			break
+	return nil
+}
+
+func (c *command) processSyncCommitteeDuties(ctx context.Context) error {
+	if c.summary.Epoch < c.chainTime.AltairInitialEpoch() {
+		// The epoch is pre-Altair.  No info but no error.
+		return nil
+	}
+
+	epoch := c.summary.Epoch
+	response, err := c.syncCommitteesProvider.SyncCommittee(ctx, &api.SyncCommitteeOpts{
+		State: fmt.Sprintf("%d", c.summary.FirstSlot),
+		Epoch: &epoch,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain sync committee")
+	}
+	committee := response.Data
+	if len(committee.Validators) == 0 {
+		return errors.New("empty sync committee")
+	}
+
+	// Only interested in the positions in the committee held by our validators.
+	positions := make(map[int]phase0.ValidatorIndex)
+	for i, index := range committee.Validators {
+		if _, exists := c.validatorsByIndex[index]; exists {
+			positions[i] = index
 		}
 	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	expected := make(map[phase0.ValidatorIndex]int)
+	missed := make(map[phase0.ValidatorIndex]int)
+	for slot := c.summary.FirstSlot; slot <= c.summary.LastSlot; slot++ {
+		blockResponse, err := c.blocksProvider.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{
+			Block: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			var apiErr *api.Error
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+				// If the block is missed we don't count the sync aggregate miss.
+				continue
+			}
+
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
+		}
+		aggregate, err := blockResponse.Data.SyncAggregate()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to obtain sync aggregate for slot %d", slot))
+		}
+		for position, index := range positions {
+			expected[index]++
+			if !aggregate.SyncCommitteeBits.BitAt(uint64(position)) {
+				missed[index]++
+			}
+		}
+	}
+
+	c.summary.SyncCommittee = make([]*epochSyncCommittee, 0, len(expected))
+	for index, count := range expected {
+		c.summary.SyncCommittee = append(c.summary.SyncCommittee, &epochSyncCommittee{
+			Index:    index,
+			Expected: count,
+			Missed:   missed[index],
+		})
+	}
+
+	sort.Slice(c.summary.SyncCommittee, func(i int, j int) bool {
+		missedDiff := c.summary.SyncCommittee[i].Missed - c.summary.SyncCommittee[j].Missed
+		if missedDiff != 0 {
+			// Actually want to order by missed descending, so invert the expected condition.
+			return missedDiff > 0
+		}
+		// Then order by validator index.
+		return c.summary.SyncCommittee[i].Index < c.summary.SyncCommittee[j].Index
+	})
 
 	return nil
 }
 
-// func (c *command) processSyncCommitteeDuties(ctx context.Context) error {
-// 	if c.summary.Epoch < c.chainTime.AltairInitialEpoch() {
-// 		// The epoch is pre-Altair.  No info but no error.
-// 		return nil
-// 	}
-//
-// 	committee, err := c.syncCommitteesProvider.SyncCommittee(ctx, fmt.Sprintf("%d", c.summary.FirstSlot))
-// 	if err != nil {
-// 		return errors.Wrap(err, "failed to obtain sync committee")
-// 	}
-// 	if len(committee.Validators) == 0 {
-// 		return errors.Wrap(err, "empty sync committee")
-// 	}
-//
-// 	missed := make(map[phase0.ValidatorIndex]int)
-// 	for _, index := range committee.Validators {
-// 		missed[index] = 0
-// 	}
-//
-// 	for slot := c.summary.FirstSlot; slot <= c.summary.LastSlot; slot++ {
-// 		block, err := c.blocksProvider.SignedBeaconBlock(ctx, fmt.Sprintf("%d", slot))
-// 		if err != nil {
-// 			return errors.Wrap(err, fmt.Sprintf("failed to obtain block for slot %d", slot))
-// 		}
-// 		if block == nil {
-// 			// If the block is missed we don't count the sync aggregate miss.
-// 			continue
-// 		}
-// 		var aggregate *altair.SyncAggregate
-// 		switch block.Version {
-// 		case spec.DataVersionPhase0:
-// 			// No sync committees in this fork.
-// 			return nil
-// 		case spec.DataVersionAltair:
-// 			aggregate = block.Altair.Message.Body.SyncAggregate
-// 		case spec.DataVersionBellatrix:
-// 			aggregate = block.Bellatrix.Message.Body.SyncAggregate
-// 		default:
-// 			return fmt.Errorf("unhandled block version %v", block.Version)
-// 		}
-// 		for i := uint64(0); i < aggregate.SyncCommitteeBits.Len(); i++ {
-// 			if !aggregate.SyncCommitteeBits.BitAt(i) {
-// 				missed[committee.Validators[int(i)]]++
-// 			}
-// 		}
-// 	}
-//
-// 	c.summary.SyncCommittee = make([]*epochSyncCommittee, 0, len(missed))
-// 	for index, count := range missed {
-// 		if count > 0 {
-// 			c.summary.SyncCommittee = append(c.summary.SyncCommittee, &epochSyncCommittee{
-// 				Index:  index,
-// 				Missed: count,
-// 			})
-// 		}
-// 	}
-//
-// 	sort.Slice(c.summary.SyncCommittee, func(i int, j int) bool {
-// 		missedDiff := c.summary.SyncCommittee[i].Missed - c.summary.SyncCommittee[j].Missed
-// 		if missedDiff != 0 {
-// 			// Actually want to order by missed descending, so invert the expected condition.
-// 			return missedDiff > 0
-// 		}
-// 		// Then order by validator index.
-// 		return c.summary.SyncCommittee[i].Index < c.summary.SyncCommittee[j].Index
-// 	})
-//
-// 	return nil
-// }
-
 func (c *command) setup(ctx context.Context) error {
 	var err error
 
@@ -446,5 +537,20 @@ func (c *command) setup(ctx context.Context) error {
 		return errors.New("connection does not provide beacon block headers")
 	}
 
+	if c.cacheDir != "" {
+		// Use the on-disk cache for historical chain data.
+		cache, err := diskchaincache.New(ctx,
+			diskchaincache.WithBaseDir(c.cacheDir),
+			diskchaincache.WithClient(c.eth2Client),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to set up chain data cache")
+		}
+		c.blocksProvider = cache
+		c.validatorsProvider = cache
+		c.beaconCommitteesProvider = cache
+		c.beaconBlockHeadersProvider = cache
+	}
+
 	return nil
 }
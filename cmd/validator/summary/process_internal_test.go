@@ -17,9 +17,20 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/testing/mock"
+	"github.com/wealdtech/ethdo/util"
 )
 
 func TestProcess(t *testing.T) {
@@ -60,3 +71,122 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+// blocksProvider provides a single block.
+type blocksProvider struct {
+	block *spec.VersionedSignedBeaconBlock
+}
+
+func (p *blocksProvider) SignedBeaconBlock(_ context.Context, _ *api.SignedBeaconBlockOpts) (*api.Response[*spec.VersionedSignedBeaconBlock], error) {
+	return &api.Response[*spec.VersionedSignedBeaconBlock]{
+		Data:     p.block,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+// headersProvider provides canonical headers with the given roots.
+type headersProvider struct {
+	roots map[string]phase0.Root
+}
+
+func (p *headersProvider) BeaconBlockHeader(_ context.Context, opts *api.BeaconBlockHeaderOpts) (*api.Response[*apiv1.BeaconBlockHeader], error) {
+	return &api.Response[*apiv1.BeaconBlockHeader]{
+		Data: &apiv1.BeaconBlockHeader{
+			Root:      p.roots[opts.Block],
+			Canonical: true,
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestProcessAttesterDutiesSlotElectra(t *testing.T) {
+	ctx := context.Background()
+
+	chainTime, err := standardchaintime.New(ctx,
+		standardchaintime.WithLogLevel(zerolog.Disabled),
+		standardchaintime.WithGenesisProvider(mock.NewGenesisProvider(time.Now().AddDate(0, 0, -1))),
+		standardchaintime.WithSpecProvider(mock.NewSpecProvider(12*time.Second, 32, 256)),
+	)
+	require.NoError(t, err)
+
+	headRoot := phase0.Root{0x01}
+	targetRoot := phase0.Root{0x02}
+
+	// Aggregate covering committees 0 and 2; committee 0 occupies bits 0-2 and committee 2 bits 3-4.
+	aggregationBits := bitfield.NewBitlist(5)
+	aggregationBits.SetBitAt(0, true)
+	aggregationBits.SetBitAt(4, true)
+	committeeBits := bitfield.NewBitvector64()
+	committeeBits.SetBitAt(0, true)
+	committeeBits.SetBitAt(2, true)
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersionElectra,
+		Electra: &electra.SignedBeaconBlock{
+			Message: &electra.BeaconBlock{
+				Slot: 101,
+				Body: &electra.BeaconBlockBody{
+					Attestations: []*electra.Attestation{
+						{
+							AggregationBits: aggregationBits,
+							CommitteeBits:   committeeBits,
+							Data: &phase0.AttestationData{
+								Slot:            100,
+								BeaconBlockRoot: headRoot,
+								Source:          &phase0.Checkpoint{},
+								Target: &phase0.Checkpoint{
+									Epoch: 3,
+									Root:  targetRoot,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cmd := &command{
+		chainTime:      chainTime,
+		blocksProvider: &blocksProvider{block: block},
+		summary: &validatorSummary{
+			Epoch:        3,
+			Attestations: make(map[phase0.ValidatorIndex]*validatorAttestation),
+			Slots:        make([]*slot, 32),
+		},
+	}
+	for i := range cmd.summary.Slots {
+		cmd.summary.Slots[i] = &slot{
+			Slot:         phase0.Slot(96 + i),
+			Attestations: &slotAttestations{},
+		}
+	}
+	headersCache := util.NewBeaconBlockHeaderCache(&headersProvider{
+		roots: map[string]phase0.Root{
+			"100": headRoot,
+			"96":  targetRoot,
+		},
+	})
+	committeesCache := util.NewBeaconCommitteesCache(mock.NewBeaconCommitteesProvider([]*apiv1.BeaconCommittee{
+		{Slot: 100, Index: 0, Validators: []phase0.ValidatorIndex{10, 11, 12}},
+		{Slot: 100, Index: 1, Validators: []phase0.ValidatorIndex{2, 1, 3}},
+		{Slot: 100, Index: 2, Validators: []phase0.ValidatorIndex{20, 21}},
+	}))
+	dutiesBySlot := map[phase0.Slot]map[phase0.CommitteeIndex][]*apiv1.AttesterDuty{
+		100: {
+			0: {{ValidatorIndex: 10, Slot: 100, CommitteeIndex: 0, ValidatorCommitteeIndex: 0}},
+			1: {{ValidatorIndex: 2, Slot: 100, CommitteeIndex: 1, ValidatorCommitteeIndex: 0}},
+			2: {{ValidatorIndex: 21, Slot: 100, CommitteeIndex: 2, ValidatorCommitteeIndex: 1}},
+		},
+	}
+
+	votes := make(map[phase0.ValidatorIndex]struct{})
+	require.NoError(t, cmd.processAttesterDutiesSlot(ctx, 101, dutiesBySlot, votes, headersCache, committeesCache, []phase0.ValidatorIndex{2, 10, 21}))
+
+	// Committee 1 is not covered by the aggregate, even though its bits overlap the aggregation bits.
+	require.Equal(t, map[phase0.ValidatorIndex]struct{}{10: {}, 21: {}}, votes)
+	require.Equal(t, 2, cmd.summary.Slots[4].Attestations.Included)
+	require.Equal(t, 2, cmd.summary.Slots[4].Attestations.TimelyHead)
+	require.Equal(t, 2, cmd.summary.Slots[4].Attestations.TimelyTarget)
+	require.True(t, cmd.summary.Attestations[21].CorrectHead)
+	require.Equal(t, 1, cmd.summary.Attestations[21].InclusionDelay)
+}
@@ -0,0 +1,148 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsummary
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// validatorReport contains per-validator aggregates over a range of epochs.
+type validatorReport struct {
+	FromEpoch  phase0.Epoch            `json:"from_epoch"`
+	ToEpoch    phase0.Epoch            `json:"to_epoch"`
+	Validators []*validatorPerformance `json:"validators"`
+
+	performances map[phase0.ValidatorIndex]*validatorPerformance
+}
+
+// validatorPerformance contains the aggregate performance of a single validator.
+type validatorPerformance struct {
+	Validator             phase0.ValidatorIndex `json:"validator_index"`
+	ActiveEpochs          int                   `json:"active_epochs"`
+	AttestationsExpected  int                   `json:"attestations_expected"`
+	AttestationsIncluded  int                   `json:"attestations_included"`
+	CorrectHead           int                   `json:"correct_head"`
+	TimelyHead            int                   `json:"timely_head"`
+	TimelySource          int                   `json:"timely_source"`
+	CorrectTarget         int                   `json:"correct_target"`
+	TimelyTarget          int                   `json:"timely_target"`
+	ParticipationRate     float64               `json:"participation_rate"`
+	CorrectHeadRate       float64               `json:"correct_head_rate"`
+	TimelyHeadRate        float64               `json:"timely_head_rate"`
+	TimelySourceRate      float64               `json:"timely_source_rate"`
+	CorrectTargetRate     float64               `json:"correct_target_rate"`
+	TimelyTargetRate      float64               `json:"timely_target_rate"`
+	AverageInclusionDelay float64               `json:"average_inclusion_delay"`
+	ProposalsExpected     int                   `json:"proposals_expected"`
+	ProposalsMissed       int                   `json:"proposals_missed"`
+	SyncCommitteeExpected int                   `json:"sync_committee_expected"`
+	SyncCommitteeMissed   int                   `json:"sync_committee_missed"`
+
+	totalInclusionDelay int
+}
+
+func newValidatorReport() *validatorReport {
+	return &validatorReport{
+		Validators:   make([]*validatorPerformance, 0),
+		performances: make(map[phase0.ValidatorIndex]*validatorPerformance),
+	}
+}
+
+// performance returns the performance for the given validator, creating it if required.
+func (r *validatorReport) performance(index phase0.ValidatorIndex) *validatorPerformance {
+	performance, exists := r.performances[index]
+	if !exists {
+		performance = &validatorPerformance{
+			Validator: index,
+		}
+		r.performances[index] = performance
+	}
+
+	return performance
+}
+
+// add adds the results of an epoch summary to the report.
+func (r *validatorReport) add(summary *validatorSummary) {
+	for _, validator := range summary.Validators {
+		// Only active validators are expected to attest.
+		if validator.Validator.ActivationEpoch > summary.Epoch || validator.Validator.ExitEpoch <= summary.Epoch {
+			continue
+		}
+		performance := r.performance(validator.Index)
+		performance.ActiveEpochs++
+		performance.AttestationsExpected++
+
+		attestation, exists := summary.Attestations[validator.Index]
+		if !exists {
+			continue
+		}
+		performance.AttestationsIncluded++
+		performance.totalInclusionDelay += attestation.InclusionDelay
+		if attestation.CorrectHead {
+			performance.CorrectHead++
+		}
+		if attestation.TimelyHead {
+			performance.TimelyHead++
+		}
+		if attestation.TimelySource {
+			performance.TimelySource++
+		}
+		if attestation.CorrectTarget {
+			performance.CorrectTarget++
+		}
+		if attestation.TimelyTarget {
+			performance.TimelyTarget++
+		}
+	}
+
+	for _, proposal := range summary.Proposals {
+		performance := r.performance(proposal.Proposer)
+		performance.ProposalsExpected++
+		if !proposal.Block {
+			performance.ProposalsMissed++
+		}
+	}
+
+	for _, syncCommittee := range summary.SyncCommittee {
+		performance := r.performance(syncCommittee.Index)
+		performance.SyncCommitteeExpected += syncCommittee.Expected
+		performance.SyncCommitteeMissed += syncCommittee.Missed
+	}
+}
+
+// finalize calculates the rates and orders the validators in the report.
+func (r *validatorReport) finalize() {
+	r.Validators = make([]*validatorPerformance, 0, len(r.performances))
+	for _, performance := range r.performances {
+		if performance.AttestationsExpected > 0 {
+			expected := float64(performance.AttestationsExpected)
+			performance.ParticipationRate = float64(performance.AttestationsIncluded) / expected
+			performance.CorrectHeadRate = float64(performance.CorrectHead) / expected
+			performance.TimelyHeadRate = float64(performance.TimelyHead) / expected
+			performance.TimelySourceRate = float64(performance.TimelySource) / expected
+			performance.CorrectTargetRate = float64(performance.CorrectTarget) / expected
+			performance.TimelyTargetRate = float64(performance.TimelyTarget) / expected
+		}
+		if performance.AttestationsIncluded > 0 {
+			performance.AverageInclusionDelay = float64(performance.totalInclusionDelay) / float64(performance.AttestationsIncluded)
+		}
+		r.Validators = append(r.Validators, performance)
+	}
+
+	sort.Slice(r.Validators, func(i int, j int) bool {
+		return r.Validators[i].Validator < r.Validators[j].Validator
+	})
+}
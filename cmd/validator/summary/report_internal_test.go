@@ -0,0 +1,117 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsummary
+
+import (
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReport(t *testing.T) {
+	validators := []*apiv1.Validator{
+		{
+			Index: 1,
+			Validator: &phase0.Validator{
+				ActivationEpoch: 0,
+				ExitEpoch:       0xffffffffffffffff,
+			},
+		},
+		{
+			Index: 2,
+			Validator: &phase0.Validator{
+				ActivationEpoch: 0,
+				ExitEpoch:       11,
+			},
+		},
+	}
+
+	report := newValidatorReport()
+	report.add(&validatorSummary{
+		Epoch:      10,
+		Validators: validators,
+		Attestations: map[phase0.ValidatorIndex]*validatorAttestation{
+			1: {
+				InclusionDelay: 1,
+				CorrectHead:    true,
+				TimelyHead:     true,
+				TimelySource:   true,
+				CorrectTarget:  true,
+				TimelyTarget:   true,
+			},
+			2: {
+				InclusionDelay: 2,
+				TimelySource:   true,
+				CorrectTarget:  true,
+				TimelyTarget:   true,
+			},
+		},
+		Proposals: []*epochProposal{
+			{
+				Slot:     320,
+				Proposer: 2,
+				Block:    false,
+			},
+		},
+	})
+	report.add(&validatorSummary{
+		Epoch:      11,
+		Validators: validators,
+		Attestations: map[phase0.ValidatorIndex]*validatorAttestation{
+			1: {
+				InclusionDelay: 3,
+				TimelySource:   true,
+				CorrectTarget:  true,
+			},
+		},
+		SyncCommittee: []*epochSyncCommittee{
+			{
+				Index:    1,
+				Expected: 32,
+				Missed:   2,
+			},
+		},
+	})
+	report.finalize()
+
+	require.Len(t, report.Validators, 2)
+
+	first := report.Validators[0]
+	require.Equal(t, phase0.ValidatorIndex(1), first.Validator)
+	require.Equal(t, 2, first.ActiveEpochs)
+	require.Equal(t, 2, first.AttestationsExpected)
+	require.Equal(t, 2, first.AttestationsIncluded)
+	require.InDelta(t, 1.0, first.ParticipationRate, 0.0001)
+	require.InDelta(t, 0.5, first.CorrectHeadRate, 0.0001)
+	require.InDelta(t, 0.5, first.TimelyHeadRate, 0.0001)
+	require.InDelta(t, 1.0, first.TimelySourceRate, 0.0001)
+	require.InDelta(t, 1.0, first.CorrectTargetRate, 0.0001)
+	require.InDelta(t, 0.5, first.TimelyTargetRate, 0.0001)
+	require.InDelta(t, 2.0, first.AverageInclusionDelay, 0.0001)
+	require.Equal(t, 32, first.SyncCommitteeExpected)
+	require.Equal(t, 2, first.SyncCommitteeMissed)
+
+	// Second validator exited at epoch 11 so is only expected to attest in epoch 10.
+	second := report.Validators[1]
+	require.Equal(t, phase0.ValidatorIndex(2), second.Validator)
+	require.Equal(t, 1, second.ActiveEpochs)
+	require.Equal(t, 1, second.AttestationsExpected)
+	require.Equal(t, 1, second.AttestationsIncluded)
+	require.InDelta(t, 0.0, second.CorrectHeadRate, 0.0001)
+	require.InDelta(t, 2.0, second.AverageInclusionDelay, 0.0001)
+	require.Equal(t, 1, second.ProposalsExpected)
+	require.Equal(t, 1, second.ProposalsMissed)
+}
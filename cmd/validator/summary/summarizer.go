@@ -0,0 +1,117 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorsummary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// EpochPerformance is the performance of a single validator in a single epoch.
+type EpochPerformance struct {
+	Validator             phase0.ValidatorIndex
+	Balance               phase0.Gwei
+	Active                bool
+	AttestationIncluded   bool
+	InclusionDelay        int
+	CorrectHead           bool
+	TimelyHead            bool
+	TimelySource          bool
+	CorrectTarget         bool
+	TimelyTarget          bool
+	ProposalsExpected     int
+	ProposalsMissed       int
+	SyncCommitteeExpected int
+	SyncCommitteeMissed   int
+}
+
+// Summarizer provides per-epoch validator summaries to long-running commands.
+type Summarizer struct {
+	c *command
+}
+
+// NewSummarizer creates a new summarizer, using the same configuration as the validator summary command.
+func NewSummarizer(ctx context.Context) (*Summarizer, error) {
+	c, err := newCommand(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(c.validators) == 0 {
+		return nil, errors.New("no validators supplied")
+	}
+	if err := c.setup(ctx); err != nil {
+		return nil, err
+	}
+
+	return &Summarizer{
+		c: c,
+	}, nil
+}
+
+// Summarize provides the performance of the configured validators in the given epoch.
+func (s *Summarizer) Summarize(ctx context.Context, epoch phase0.Epoch) ([]*EpochPerformance, error) {
+	if err := s.c.processEpoch(ctx, epoch); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to process epoch %d", epoch))
+	}
+	if err := s.c.processSyncCommitteeDuties(ctx); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to process sync committee duties for epoch %d", epoch))
+	}
+	summary := s.c.summary
+
+	performances := make(map[phase0.ValidatorIndex]*EpochPerformance, len(summary.Validators))
+	res := make([]*EpochPerformance, 0, len(summary.Validators))
+	for _, validator := range summary.Validators {
+		performance := &EpochPerformance{
+			Validator: validator.Index,
+			Balance:   validator.Balance,
+			Active:    validator.Validator.ActivationEpoch <= epoch && validator.Validator.ExitEpoch > epoch,
+		}
+		if attestation, exists := summary.Attestations[validator.Index]; exists {
+			performance.AttestationIncluded = true
+			performance.InclusionDelay = attestation.InclusionDelay
+			performance.CorrectHead = attestation.CorrectHead
+			performance.TimelyHead = attestation.TimelyHead
+			performance.TimelySource = attestation.TimelySource
+			performance.CorrectTarget = attestation.CorrectTarget
+			performance.TimelyTarget = attestation.TimelyTarget
+		}
+		performances[validator.Index] = performance
+		res = append(res, performance)
+	}
+
+	for _, proposal := range summary.Proposals {
+		performance, exists := performances[proposal.Proposer]
+		if !exists {
+			continue
+		}
+		performance.ProposalsExpected++
+		if !proposal.Block {
+			performance.ProposalsMissed++
+		}
+	}
+
+	for _, syncCommittee := range summary.SyncCommittee {
+		performance, exists := performances[syncCommittee.Index]
+		if !exists {
+			continue
+		}
+		performance.SyncCommitteeExpected += syncCommittee.Expected
+		performance.SyncCommitteeMissed += syncCommittee.Missed
+	}
+
+	return res, nil
+}
@@ -41,10 +41,11 @@ type command struct {
 	allowInsecureConnections bool
 
 	// Processing.
-	consensusClient          consensusclient.Service
-	chainTime                chaintime.Service
-	maxWithdrawalsPerPayload uint64
-	maxEffectiveBalance      phase0.Gwei
+	consensusClient            consensusclient.Service
+	chainTime                  chaintime.Service
+	maxWithdrawalsPerPayload   uint64
+	maxEffectiveBalance        phase0.Gwei
+	maxEffectiveBalanceElectra phase0.Gwei
 
 	// Output.
 	res *res
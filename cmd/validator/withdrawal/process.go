@@ -29,7 +29,8 @@ import (
 )
 
 const (
-	ethWithdrawalPrefix = 0x01
+	ethWithdrawalPrefix         = 0x01
+	compoundingWithdrawalPrefix = 0x02
 )
 
 func (c *command) process(ctx context.Context) error {
@@ -42,7 +43,7 @@ func (c *command) process(ctx context.Context) error {
 		return errors.Wrap(err, "failed to parse validator")
 	}
 
-	if validator.Validator.WithdrawalCredentials[0] != ethWithdrawalPrefix {
+	if !hasExecutionWithdrawalCredentials(validator.Validator) {
 		return errors.New("validator does not have suitable withdrawal credentials")
 	}
 	if validator.Balance == 0 {
@@ -98,8 +99,8 @@ func (c *command) process(ctx context.Context) error {
 		if index == int(validator.Index) {
 			break
 		}
-		if validators[index].Validator.WithdrawalCredentials[0] == ethWithdrawalPrefix &&
-			validators[index].Validator.EffectiveBalance == c.maxEffectiveBalance &&
+		if hasExecutionWithdrawalCredentials(validators[index].Validator) &&
+			validators[index].Validator.EffectiveBalance == c.validatorMaxEffectiveBalance(validators[index].Validator) &&
 			validators[index].Validator.ActivationEpoch <= c.chainTime.SlotToEpoch(withdrawalSlot) {
 			c.res.WithdrawalsToGo++
 			withdrawalsInSlot++
@@ -164,5 +165,28 @@ func (c *command) setup(ctx context.Context) error {
 		c.maxEffectiveBalance = phase0.Gwei(val.(uint64))
 	}
 
+	if val, exists := specResponse.Data["MAX_EFFECTIVE_BALANCE_ELECTRA"]; !exists {
+		c.maxEffectiveBalanceElectra = 2048000000000
+	} else {
+		c.maxEffectiveBalanceElectra = phase0.Gwei(val.(uint64))
+	}
+
 	return nil
 }
+
+// hasExecutionWithdrawalCredentials returns true if the validator has execution
+// withdrawal credentials, either standard or compounding.
+func hasExecutionWithdrawalCredentials(validator *phase0.Validator) bool {
+	return validator.WithdrawalCredentials[0] == ethWithdrawalPrefix ||
+		validator.WithdrawalCredentials[0] == compoundingWithdrawalPrefix
+}
+
+// validatorMaxEffectiveBalance returns the maximum effective balance for the validator,
+// which depends on the type of its withdrawal credentials.
+func (c *command) validatorMaxEffectiveBalance(validator *phase0.Validator) phase0.Gwei {
+	if validator.WithdrawalCredentials[0] == compoundingWithdrawalPrefix {
+		return c.maxEffectiveBalanceElectra
+	}
+
+	return c.maxEffectiveBalance
+}
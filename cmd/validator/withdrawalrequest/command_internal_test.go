@@ -0,0 +1,90 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInput(t *testing.T) {
+	tests := []struct {
+		name string
+		vars map[string]interface{}
+		err  string
+	}{
+		{
+			name: "TimeoutMissing",
+			vars: map[string]interface{}{},
+			err:  "timeout is required",
+		},
+		{
+			name: "FeeMissing",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"amount":    "0",
+			},
+			err: "execution connection or fee is required",
+		},
+		{
+			name: "FeeAndExecutionConnection",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"validator":            "1",
+				"amount":               "0",
+				"fee":                  "1 wei",
+				"execution-connection": "http://localhost:8545/",
+			},
+			err: "cannot specify both fee and execution connection",
+		},
+		{
+			name: "Fee",
+			vars: map[string]interface{}{
+				"timeout":   "5s",
+				"validator": "1",
+				"amount":    "0",
+				"fee":       "1 wei",
+			},
+		},
+		{
+			name: "ExecutionConnection",
+			vars: map[string]interface{}{
+				"timeout":              "5s",
+				"validator":            "1",
+				"amount":               "0",
+				"execution-connection": "http://localhost:8545/",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+
+			for k, v := range test.vars {
+				viper.Set(k, v)
+			}
+			_, err := newCommand(context.Background())
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
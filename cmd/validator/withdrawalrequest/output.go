@@ -0,0 +1,59 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	if c.prepareOffline {
+		return fmt.Sprintf("%s generated", offlinePreparationFilename), nil
+	}
+
+	if c.json {
+		data, err := json.Marshal(c.request)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to marshal withdrawal request")
+		}
+
+		return string(data), nil
+	}
+
+	builder := strings.Builder{}
+	if c.request.Amount == 0 {
+		builder.WriteString(fmt.Sprintf("Withdraw all funds from validator %d (full exit)\n", c.request.ValidatorIndex))
+	} else {
+		builder.WriteString(fmt.Sprintf("Withdraw %s from validator %d\n", string2eth.GWeiToString(uint64(c.request.Amount), true), c.request.ValidatorIndex))
+	}
+	if c.verbose {
+		builder.WriteString(fmt.Sprintf("Public key: %#x\n", c.request.Pubkey))
+	}
+	builder.WriteString(fmt.Sprintf("From: %s\n", addressBytesToEIP55(c.request.From[:])))
+	builder.WriteString(fmt.Sprintf("To: %s\n", addressBytesToEIP55(c.request.To[:])))
+	builder.WriteString(fmt.Sprintf("Value: %s\n", string2eth.WeiToString(c.request.Value, true)))
+	builder.WriteString(fmt.Sprintf("Data: %#x", c.request.Data()))
+
+	return builder.String(), nil
+}
@@ -0,0 +1,207 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"time"
+
+	consensusclient "github.com/attestantio/go-eth2-client"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	standardchaintime "github.com/wealdtech/ethdo/services/chaintime/standard"
+	"github.com/wealdtech/ethdo/util"
+	ethutil "github.com/wealdtech/go-eth2-util"
+	string2eth "github.com/wealdtech/go-string2eth"
+)
+
+// minTimeout is the minimum timeout for this command.
+// It needs to be set here as we want timeouts to be low in general, but this can be pulling
+// a lot of data for an unsophisticated audience so it's easier to set a higher timeout..
+var minTimeout = 5 * time.Minute
+
+var offlinePreparationFilename = "offline-preparation.json"
+
+// withdrawalRequestContractAddress is the address of the EIP-7002 withdrawal request predeploy.
+var withdrawalRequestContractAddress = bellatrix.ExecutionAddress{
+	0x00, 0x00, 0x09, 0x61, 0xef, 0x48, 0x0e, 0xb5, 0x5e, 0x80,
+	0xd1, 0x9a, 0xd8, 0x35, 0x79, 0xa6, 0x4c, 0x00, 0x70, 0x02,
+}
+
+const (
+	// blsWithdrawalPrefix is the prefix for BLS withdrawal credentials.
+	blsWithdrawalPrefix = 0x00
+	// ethWithdrawalPrefix is the prefix for execution address withdrawal credentials.
+	ethWithdrawalPrefix = 0x01
+	// compoundingWithdrawalPrefix is the prefix for compounding withdrawal credentials.
+	compoundingWithdrawalPrefix = 0x02
+)
+
+func (c *command) process(ctx context.Context) error {
+	if err := c.setup(ctx); err != nil {
+		return err
+	}
+
+	if err := c.obtainChainInfo(ctx); err != nil {
+		return err
+	}
+
+	if c.prepareOffline {
+		return c.writeChainInfoToFile(ctx)
+	}
+
+	if err := c.parseAmount(ctx); err != nil {
+		return err
+	}
+
+	if err := c.obtainFee(ctx); err != nil {
+		return err
+	}
+
+	return c.generateRequest(ctx)
+}
+
+func (c *command) parseAmount(_ context.Context) error {
+	amount, err := string2eth.StringToGWei(c.amountStr)
+	if err != nil {
+		return errors.Wrap(err, "invalid amount")
+	}
+	c.amount = phase0.Gwei(amount)
+
+	return nil
+}
+
+// obtainFee obtains the fee for the request, either as supplied or as the
+// current fee from the contract.
+func (c *command) obtainFee(ctx context.Context) error {
+	switch {
+	case c.feeStr != "":
+		var err error
+		c.fee, err = string2eth.StringToWei(c.feeStr)
+		if err != nil {
+			return errors.Wrap(err, "invalid fee")
+		}
+		if c.fee.Sign() <= 0 {
+			return errors.New("fee must be greater than 0")
+		}
+	default:
+		var err error
+		c.fee, err = util.ObtainExecutionRequestFee(ctx, c.executionConnection, c.timeout, withdrawalRequestContractAddress[:])
+		if err != nil {
+			return errors.Wrap(err, "failed to obtain withdrawal request fee")
+		}
+	}
+
+	return nil
+}
+
+func (c *command) generateRequest(ctx context.Context) error {
+	validator, err := c.chainInfo.FetchValidatorInfo(ctx, c.validator)
+	if err != nil {
+		return errors.Wrap(err, "failed to obtain validator")
+	}
+
+	if c.debug {
+		fmt.Fprintf(os.Stderr, "Validator: %v\n", validator)
+	}
+
+	if validator.State != apiv1.ValidatorStateActiveOngoing {
+		return fmt.Errorf("validator %d is in state %v, not suitable for a withdrawal request", validator.Index, validator.State)
+	}
+
+	switch validator.WithdrawalCredentials[0] {
+	case ethWithdrawalPrefix:
+		if c.amount != 0 {
+			return fmt.Errorf("validator %d does not have compounding (0x02) withdrawal credentials so can only request a full exit", validator.Index)
+		}
+	case compoundingWithdrawalPrefix:
+		// Good.
+	case blsWithdrawalPrefix:
+		return fmt.Errorf("validator %d has BLS withdrawal credentials; change them to an execution address first", validator.Index)
+	default:
+		return fmt.Errorf("validator %d has unknown withdrawal credentials %#x", validator.Index, validator.WithdrawalCredentials)
+	}
+
+	c.request = &withdrawalRequest{
+		ValidatorIndex: validator.Index,
+		Pubkey:         validator.Pubkey,
+		Amount:         c.amount,
+		To:             withdrawalRequestContractAddress,
+		Value:          c.fee,
+	}
+	copy(c.request.From[:], validator.WithdrawalCredentials[12:])
+
+	return nil
+}
+
+func (c *command) setup(ctx context.Context) error {
+	if c.offline {
+		return nil
+	}
+
+	// Ensure timeout is at least the minimum.
+	if c.timeout < minTimeout {
+		if c.debug {
+			fmt.Fprintf(os.Stderr, "Increasing timeout to %v\n", minTimeout)
+		}
+		c.timeout = minTimeout
+	}
+
+	// Connect to the consensus node.
+	var err error
+	c.consensusClient, err = util.ConnectToBeaconNode(ctx, &util.ConnectOpts{
+		Address:       c.connection,
+		Timeout:       c.timeout,
+		AllowInsecure: c.allowInsecureConnections,
+		LogFallback:   !c.quiet,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Set up chaintime.
+	c.chainTime, err = standardchaintime.New(ctx,
+		standardchaintime.WithGenesisProvider(c.consensusClient.(consensusclient.GenesisProvider)),
+		standardchaintime.WithSpecProvider(c.consensusClient.(consensusclient.SpecProvider)),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to create chaintime service")
+	}
+
+	return nil
+}
+
+// addressBytesToEIP55 converts a byte array in to an EIP-55 string format.
+func addressBytesToEIP55(address []byte) string {
+	bytes := []byte(hex.EncodeToString(address))
+	hash := ethutil.Keccak256(bytes)
+	for i := 0; i < len(bytes); i++ {
+		hashByte := hash[i/2]
+		if i%2 == 0 {
+			hashByte >>= 4
+		} else {
+			hashByte &= 0xf
+		}
+		if bytes[i] > '9' && hashByte > 7 {
+			bytes[i] -= 32
+		}
+	}
+
+	return fmt.Sprintf("0x%s", string(bytes))
+}
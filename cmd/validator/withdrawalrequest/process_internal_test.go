@@ -0,0 +1,139 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+)
+
+func TestGenerateRequest(t *testing.T) {
+	ctx := context.Background()
+
+	chainInfo := &beacon.ChainInfo{
+		Version: 3,
+		Validators: []*beacon.ValidatorInfo{
+			{
+				Index:                 0,
+				Pubkey:                phase0.BLSPubKey{0x01},
+				State:                 apiv1.ValidatorStateActiveOngoing,
+				WithdrawalCredentials: []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15},
+			},
+			{
+				Index:                 1,
+				Pubkey:                phase0.BLSPubKey{0x02},
+				State:                 apiv1.ValidatorStateActiveOngoing,
+				WithdrawalCredentials: []byte{0x02, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15},
+			},
+			{
+				Index:                 2,
+				Pubkey:                phase0.BLSPubKey{0x03},
+				State:                 apiv1.ValidatorStateActiveOngoing,
+				WithdrawalCredentials: []byte{0x00, 0x8b, 0xa1, 0xcc, 0x4b, 0x09, 0x1b, 0x91, 0xc1, 0x20, 0x2b, 0xba, 0x3f, 0x50, 0x80, 0x75, 0xd6, 0xff, 0x56, 0x5c, 0x77, 0xe5, 0x59, 0xf0, 0x80, 0x3c, 0x07, 0x92, 0xe0, 0x30, 0x2b, 0xf1},
+			},
+			{
+				Index:                 3,
+				Pubkey:                phase0.BLSPubKey{0x04},
+				State:                 apiv1.ValidatorStateActiveExiting,
+				WithdrawalCredentials: []byte{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x8c, 0x1f, 0xf9, 0x78, 0x03, 0x6f, 0x2e, 0x9d, 0x7c, 0xc3, 0x82, 0xef, 0xf7, 0xb4, 0xc8, 0xc5, 0x3c, 0x22, 0xac, 0x15},
+			},
+		},
+		Epoch: 1,
+	}
+
+	tests := []struct {
+		name     string
+		command  *command
+		expected string
+		err      string
+	}{
+		{
+			name: "ValidatorUnknown",
+			command: &command{
+				validator: "99",
+				chainInfo: chainInfo,
+				fee:       big.NewInt(1),
+			},
+			err: "failed to obtain validator: unknown validator",
+		},
+		{
+			name: "ValidatorBLS",
+			command: &command{
+				validator: "2",
+				chainInfo: chainInfo,
+				fee:       big.NewInt(1),
+			},
+			err: "validator 2 has BLS withdrawal credentials; change them to an execution address first",
+		},
+		{
+			name: "ValidatorExiting",
+			command: &command{
+				validator: "3",
+				chainInfo: chainInfo,
+				fee:       big.NewInt(1),
+			},
+			err: "validator 3 is in state active_exiting, not suitable for a withdrawal request",
+		},
+		{
+			name: "PartialNotCompounding",
+			command: &command{
+				validator: "0",
+				chainInfo: chainInfo,
+				amount:    1000000000,
+				fee:       big.NewInt(1),
+			},
+			err: "validator 0 does not have compounding (0x02) withdrawal credentials so can only request a full exit",
+		},
+		{
+			name: "FullExit",
+			command: &command{
+				validator: "0",
+				chainInfo: chainInfo,
+				fee:       big.NewInt(1),
+			},
+			expected: `{"from":"0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15","to":"0x00000961Ef480Eb55e80D19ad83579A64c007002","value":"0x1","data":"0x0100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000"}`,
+		},
+		{
+			name: "Partial",
+			command: &command{
+				validator: "1",
+				chainInfo: chainInfo,
+				amount:    1000000000,
+				fee:       big.NewInt(2),
+			},
+			expected: `{"from":"0x8c1Ff978036F2e9d7CC382Eff7B4c8c53C22ac15","to":"0x00000961Ef480Eb55e80D19ad83579A64c007002","value":"0x2","data":"0x020000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000003b9aca00"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.command.generateRequest(ctx)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				data, err := json.Marshal(test.command.request)
+				require.NoError(t, err)
+				require.Equal(t, test.expected, string(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validatorwithdrawalrequest
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// withdrawalRequest is an execution layer transaction that requests a withdrawal.
+type withdrawalRequest struct {
+	ValidatorIndex phase0.ValidatorIndex
+	Pubkey         phase0.BLSPubKey
+	Amount         phase0.Gwei
+	From           bellatrix.ExecutionAddress
+	To             bellatrix.ExecutionAddress
+	Value          *big.Int
+}
+
+// withdrawalRequestJSON is the JSON representation of a withdrawal request,
+// laid out as the parameters of an eth_sendTransaction call.
+type withdrawalRequestJSON struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Value string `json:"value"`
+	Data  string `json:"data"`
+}
+
+// Data provides the calldata for the request, which is the validator public key
+// followed by the amount in Gwei as a big-endian 8-byte value.
+func (r *withdrawalRequest) Data() []byte {
+	data := make([]byte, phase0.PublicKeyLength+8)
+	copy(data, r.Pubkey[:])
+	binary.BigEndian.PutUint64(data[phase0.PublicKeyLength:], uint64(r.Amount))
+
+	return data
+}
+
+// MarshalJSON implements json.Marshaler.
+func (r *withdrawalRequest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&withdrawalRequestJSON{
+		From:  addressBytesToEIP55(r.From[:]),
+		To:    addressBytesToEIP55(r.To[:]),
+		Value: fmt.Sprintf("%#x", r.Value),
+		Data:  fmt.Sprintf("%#x", r.Data()),
+	})
+}
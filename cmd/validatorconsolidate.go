@@ -0,0 +1,81 @@
+// Copyright © 2024 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorconsolidate "github.com/wealdtech/ethdo/cmd/validator/consolidate"
+)
+
+var validatorConsolidateCmd = &cobra.Command{
+	Use:   "consolidate",
+	Short: "Generate a consolidation request for one or more validators",
+	Long: `Generate an EIP-7251 consolidation request for one or more validators.  For example:
+
+    ethdo validator consolidate --source-validator=12345 --target-validator=12346 --execution-connection=http://localhost:8545/
+
+The source and target validators must have the same withdrawal address, and the target validator must have compounding (0x02) withdrawal credentials.  Supplying the same validator as both source and target generates a request to switch that validator from 0x01 to 0x02 withdrawal credentials.
+
+The output is a transaction that must be sent from the withdrawal address of the validators; ethdo does not send the transaction itself.  The fee for the transaction is obtained from the consolidation contract with --execution-connection, or supplied with --fee; a supplied fee may be insufficient if other consolidation requests are pending, and any fee paid above that required by the consolidation contract is not refunded.
+
+In quiet mode this will return 0 if the consolidation requests have been generated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorconsolidate.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorConsolidateCmd)
+	validatorFlags(validatorConsolidateCmd)
+	validatorConsolidateCmd.Flags().StringSlice("source-validator", nil, "Validator(s) to consolidate from")
+	validatorConsolidateCmd.Flags().String("target-validator", "", "Validator to consolidate in to")
+	validatorConsolidateCmd.Flags().String("fee", "", "Fee to pay for each consolidation request, if not using an execution connection")
+	validatorConsolidateCmd.Flags().String("execution-connection", "", "Execution node from which to obtain the current consolidation request fee")
+	validatorConsolidateCmd.Flags().Bool("prepare-offline", false, "Create files for offline use")
+	validatorConsolidateCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the operation")
+}
+
+func validatorConsolidateBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("source-validator", cmd.Flags().Lookup("source-validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("target-validator", cmd.Flags().Lookup("target-validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("fee", cmd.Flags().Lookup("fee")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("execution-connection", cmd.Flags().Lookup("execution-connection")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("prepare-offline", cmd.Flags().Lookup("prepare-offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", cmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+}
@@ -36,6 +36,7 @@ The validator account can be specified in one of a number of ways:
   - mnemonic and withdrawal private key using --mnemonic and --private-key; this will generate all applicable operations
   - validator and withdrawal private key using --validator and --private-key; this will generate a single operation
   - account and withdrawal account using --account and --withdrawal-account; this will generate a single operation
+  - validator and withdrawal public key using --validator and --withdrawal-account, with the withdrawal key held by a Web3Signer-compatible remote signer at --signer-url; this will generate a single operation
 
 In quiet mode this will return 0 if the credentials operation has been generated (and successfully broadcast if online), otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
@@ -37,6 +37,7 @@ The validator and key can be specified in one of a number of ways:
   - mnemonic and validator index or public key using --mnemonic and --validator
   - validator private key using --private-key
   - validator account using --validator
+  - validator index or public key using --validator, with the key held by a Web3Signer-compatible remote signer at --signer-url
 
 In quiet mode this will return 0 if the exit operation has been generated (and successfully broadcast if online), otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
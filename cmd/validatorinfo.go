@@ -1,4 +1,4 @@
-// Copyright © 2020 - 2022 Weald Technology Trading
+// Copyright © 2020 - 2024 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -42,6 +42,8 @@ var validatorInfoCmd = &cobra.Command{
 
     ethdo validator info --validator=primary/validator
 
+If --quorum is supplied the validator information is cross-checked between the beacon nodes in --connection, and any disagreements reported.
+
 In quiet mode this will return 0 if the validator information can be obtained, otherwise 1.`,
 	Run: func(_ *cobra.Command, _ []string) {
 		ctx := context.Background()
@@ -67,7 +69,14 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 			os.Exit(_exitFailure)
 		}
 
-		validator, err := util.ParseValidator(ctx, eth2Client.(eth2client.ValidatorsProvider), viper.GetString("validator"), "head")
+		var validator *api.Validator
+		if quorum := viper.GetInt("quorum"); quorum > 0 {
+			var disagreements []*util.QuorumDisagreement
+			validator, disagreements, err = util.QuorumFetch(ctx, quorumClients(ctx), quorum, fetchValidator, summariseValidator)
+			reportDisagreements(disagreements)
+		} else {
+			validator, err = fetchValidator(ctx, eth2Client)
+		}
 		errCheck(err, "Failed to obtain validator")
 
 		if viper.GetBool("verbose") {
@@ -125,6 +134,29 @@ In quiet mode this will return 0 if the validator information can be obtained, o
 	},
 }
 
+// fetchValidator fetches the validator from a beacon node.
+func fetchValidator(ctx context.Context, eth2Client eth2client.Service) (*api.Validator, error) {
+	validatorsProvider, isProvider := eth2Client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("beacon node does not provide validator information")
+	}
+
+	return util.ParseValidator(ctx, validatorsProvider, viper.GetString("validator"), "head")
+}
+
+// summariseValidator summarises validator information for comparison between beacon nodes.
+func summariseValidator(validator *api.Validator) string {
+	return fmt.Sprintf("index %d, status %v, balance %d, effective balance %d, exit epoch %d, withdrawable epoch %d, withdrawal credentials %#x",
+		validator.Index,
+		validator.Status,
+		validator.Balance,
+		validator.Validator.EffectiveBalance,
+		validator.Validator.ExitEpoch,
+		validator.Validator.WithdrawableEpoch,
+		validator.Validator.WithdrawalCredentials,
+	)
+}
+
 // graphData returns data from the graph about number and amount of deposits.
 func graphData(network string, validatorPubKey []byte) (uint64, spec.Gwei, error) {
 	subgraph := ""
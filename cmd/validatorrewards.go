@@ -0,0 +1,71 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorrewards "github.com/wealdtech/ethdo/cmd/validator/rewards"
+)
+
+var validatorRewardsCmd = &cobra.Command{
+	Use:   "rewards",
+	Short: "Obtain rewards and penalties for validator(s) over a range of epochs",
+	Long: `Obtain the rewards and penalties earned by one or more validators over a range of epochs.  For example:
+
+    ethdo validator rewards --validators=1,2,3 --from-epoch=12300 --to-epoch=12345
+
+Rewards are obtained from the beacon node's rewards API.  For epochs where this is not available they are estimated from changes in balance.
+
+The results can be output as CSV with --csv, or as JSON with --json.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorrewards.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorRewardsCmd)
+	validatorFlags(validatorRewardsCmd)
+	validatorRewardsCmd.Flags().StringSlice("validators", nil, "the list of validators for which to obtain rewards")
+	validatorRewardsCmd.Flags().String("from-epoch", "", "the first epoch for which to obtain rewards")
+	validatorRewardsCmd.Flags().String("to-epoch", "", "the last epoch for which to obtain rewards (defaults to from-epoch)")
+	validatorRewardsCmd.Flags().Bool("csv", false, "output the rewards as CSV")
+}
+
+func validatorRewardsBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
+}
@@ -28,6 +28,12 @@ var validatorSummaryCmd = &cobra.Command{
 
     ethdo validator summary --validators=1,2,3 --epoch=12345
 
+Alternatively, a per-validator performance report over a range of epochs can be obtained.  For example:
+
+    ethdo validator summary --validators=1,2,3 --from-epoch=12300 --to-epoch=12345
+
+The report can be output as CSV with --csv, or as JSON with --json.
+
 In quiet mode this will return 0 if information for the epoch is found, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := validatorsummary.Run(cmd)
@@ -49,6 +55,9 @@ func init() {
 	validatorFlags(validatorSummaryCmd)
 	validatorSummaryCmd.Flags().String("epoch", "", "the epoch for which to obtain information ()")
 	validatorSummaryCmd.Flags().StringSlice("validators", nil, "the list of validators for which to obtain information")
+	validatorSummaryCmd.Flags().String("from-epoch", "", "the first epoch for which to obtain a performance report")
+	validatorSummaryCmd.Flags().String("to-epoch", "", "the last epoch for which to obtain a performance report")
+	validatorSummaryCmd.Flags().Bool("csv", false, "output the performance report as CSV")
 }
 
 func validatorSummaryBindings(cmd *cobra.Command) {
@@ -59,4 +68,13 @@ func validatorSummaryBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("validators", cmd.Flags().Lookup("validators")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("from-epoch", cmd.Flags().Lookup("from-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("to-epoch", cmd.Flags().Lookup("to-epoch")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("csv", cmd.Flags().Lookup("csv")); err != nil {
+		panic(err)
+	}
 }
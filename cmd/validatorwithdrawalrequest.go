@@ -0,0 +1,81 @@
+// Copyright © 2024 Weald Technology Trading
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	validatorwithdrawalrequest "github.com/wealdtech/ethdo/cmd/validator/withdrawalrequest"
+)
+
+var validatorWithdrawalRequestCmd = &cobra.Command{
+	Use:   "withdrawal-request",
+	Short: "Generate a withdrawal request for a validator",
+	Long: `Generate an EIP-7002 withdrawal request for a validator.  For example:
+
+    ethdo validator withdrawal-request --validator=12345 --amount="1 Ether"
+
+An amount of 0 requests a full exit of the validator.  Partial withdrawals require the validator to have compounding (0x02) withdrawal credentials.
+
+The output is a transaction that must be sent from the withdrawal address of the validator; ethdo does not send the transaction itself.  The fee for the transaction is either supplied with --fee or obtained from the withdrawal request contract with --execution-connection; exactly one of these must be supplied.  Any fee paid above that required by the withdrawal request contract is not refunded.
+
+In quiet mode this will return 0 if the withdrawal request has been generated, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := validatorwithdrawalrequest.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	validatorCmd.AddCommand(validatorWithdrawalRequestCmd)
+	validatorFlags(validatorWithdrawalRequestCmd)
+	validatorWithdrawalRequestCmd.Flags().String("validator", "", "Validator for which to request the withdrawal")
+	validatorWithdrawalRequestCmd.Flags().String("amount", "", `Amount to withdraw (e.g. "1 Ether"); 0 for a full exit`)
+	validatorWithdrawalRequestCmd.Flags().String("fee", "", "Fee to pay for the withdrawal request")
+	validatorWithdrawalRequestCmd.Flags().String("execution-connection", "", "Execution node from which to obtain the current withdrawal request fee")
+	validatorWithdrawalRequestCmd.Flags().Bool("prepare-offline", false, "Create files for offline use")
+	validatorWithdrawalRequestCmd.Flags().Bool("offline", false, "Do not attempt to connect to a beacon node to obtain information for the operation")
+}
+
+func validatorWithdrawalRequestBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("validator", cmd.Flags().Lookup("validator")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("amount", cmd.Flags().Lookup("amount")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("fee", cmd.Flags().Lookup("fee")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("execution-connection", cmd.Flags().Lookup("execution-connection")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("prepare-offline", cmd.Flags().Lookup("prepare-offline")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("offline", cmd.Flags().Lookup("offline")); err != nil {
+		panic(err)
+	}
+}
@@ -31,6 +31,10 @@ type dataIn struct {
 	debug      bool
 	wallet     e2wtypes.Wallet
 	passphrase string
+	// For keystore exports.
+	keystoresDir       string
+	passphrases        []string
+	keystorePassphrase string
 }
 
 func input(ctx context.Context) (*dataIn, error) {
@@ -56,6 +60,21 @@ func input(ctx context.Context) (*dataIn, error) {
 	}
 	data.wallet = wallet
 
+	// Keystores directory.
+	if viper.GetString("keystores-dir") != "" {
+		data.keystoresDir = viper.GetString("keystores-dir")
+		data.passphrases = util.GetPassphrases()
+		if len(data.passphrases) == 0 {
+			return nil, errors.New("passphrase is required")
+		}
+		data.keystorePassphrase = viper.GetString("keystore-passphrase")
+		if data.keystorePassphrase == "" {
+			return nil, errors.New("keystore passphrase is required")
+		}
+
+		return data, nil
+	}
+
 	// Passphrase.
 	data.passphrase, err = util.GetPassphrase()
 	if err != nil {
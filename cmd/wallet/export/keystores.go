@@ -0,0 +1,147 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletexport
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// unsafeFilenameChars are characters in account names that are replaced when creating filenames.
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+func processKeystores(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if !util.AcceptablePassphrase(data.keystorePassphrase) {
+		return nil, errors.New("supplied keystore passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	accounts := make([]e2wtypes.Account, 0)
+	for account := range data.wallet.Accounts(ctx) {
+		accounts = append(accounts, account)
+	}
+	if len(accounts) == 0 {
+		return nil, errors.New("wallet has no accounts")
+	}
+	sort.Slice(accounts, func(i int, j int) bool {
+		return accounts[i].Name() < accounts[j].Name()
+	})
+
+	// Generate all keystores before writing any.
+	encryptor := keystorev4.New()
+	keystores := make(map[string][]byte, len(accounts))
+	filenames := make([]string, 0, len(accounts))
+	for _, account := range accounts {
+		keystore, err := generateKeystore(ctx, account, data.passphrases, data.keystorePassphrase, encryptor)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to generate keystore for account %q", account.Name()))
+		}
+		filename := filepath.Join(data.keystoresDir, fmt.Sprintf("keystore-%s.json", unsafeFilenameChars.ReplaceAllString(account.Name(), "_")))
+		if _, exists := keystores[filename]; exists {
+			return nil, fmt.Errorf("multiple accounts would be written to %s", filename)
+		}
+		keystores[filename] = keystore
+		filenames = append(filenames, filename)
+	}
+
+	if err := os.MkdirAll(data.keystoresDir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create keystores directory")
+	}
+	for _, filename := range filenames {
+		if _, err := os.Stat(filename); err == nil {
+			return nil, fmt.Errorf("%s already exists", filename)
+		}
+	}
+	for _, filename := range filenames {
+		// Do not overwrite existing files.
+		f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create keystore file")
+		}
+		if _, err := f.Write(keystores[filename]); err != nil {
+			_ = f.Close()
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to write %s", filename))
+		}
+		if err := f.Close(); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to close %s", filename))
+		}
+	}
+
+	return &dataOut{
+		keystores: filenames,
+	}, nil
+}
+
+// generateKeystore generates an EIP-2335 keystore for the account.
+func generateKeystore(ctx context.Context,
+	account e2wtypes.Account,
+	passphrases []string,
+	keystorePassphrase string,
+	encryptor *keystorev4.Encryptor,
+) (
+	[]byte,
+	error,
+) {
+	privateKeyProvider, isPrivateKeyProvider := account.(e2wtypes.AccountPrivateKeyProvider)
+	if !isPrivateKeyProvider {
+		return nil, errors.New("account does not provide its private key")
+	}
+	alreadyUnlocked, err := util.UnlockAccount(ctx, account, passphrases)
+	if err != nil {
+		return nil, err
+	}
+	if !alreadyUnlocked {
+		defer func() {
+			if err := util.LockAccount(ctx, account); err != nil {
+				util.Log.Trace().Err(err).Msg("Failed to lock account")
+			}
+		}()
+	}
+	key, err := privateKeyProvider.PrivateKey(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain private key")
+	}
+
+	crypto, err := encryptor.Encrypt(key.Marshal(), keystorePassphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt private key")
+	}
+
+	ks := make(map[string]any)
+	ks["uuid"] = account.ID().String()
+	ks["pubkey"] = hex.EncodeToString(key.PublicKey().Marshal())
+	ks["version"] = 4
+	ks["path"] = ""
+	if pathProvider, isPathProvider := account.(e2wtypes.AccountPathProvider); isPathProvider {
+		ks["path"] = pathProvider.Path()
+	}
+	ks["description"] = account.Name()
+	ks["crypto"] = crypto
+	out, err := json.Marshal(ks)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal keystore JSON")
+	}
+
+	return out, nil
+}
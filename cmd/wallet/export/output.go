@@ -16,12 +16,15 @@ package walletexport
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 )
 
 type dataOut struct {
 	export []byte
+	// For keystore exports.
+	keystores []string
 }
 
 func output(_ context.Context, data *dataOut) (string, error) {
@@ -29,5 +32,9 @@ func output(_ context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no data")
 	}
 
+	if data.keystores != nil {
+		return strings.Join(data.keystores, "\n"), nil
+	}
+
 	return fmt.Sprintf("%#x", data.export), nil
 }
@@ -28,6 +28,9 @@ func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data.wallet == nil {
 		return nil, errors.New("wallet is required")
 	}
+	if data.keystoresDir != "" {
+		return processKeystores(ctx, data)
+	}
 	if !util.AcceptablePassphrase(data.passphrase) {
 		return nil, errors.New("supplied passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
 	}
@@ -15,16 +15,22 @@ package walletexport
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
 	filesystem "github.com/wealdtech/go-eth2-wallet-store-filesystem"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
 )
 
 func TestProcess(t *testing.T) {
@@ -85,3 +91,86 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessKeystores(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	store := scratch.New()
+	require.NoError(t, e2wallet.UseStore(store))
+	wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+	key := testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+	_, err = wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, "Interop 0", key, []byte("pass"))
+	require.NoError(t, err)
+	require.NoError(t, wallet.(e2wtypes.WalletLocker).Lock(ctx))
+
+	dir := t.TempDir()
+
+	tests := []struct {
+		name   string
+		dataIn *dataIn
+		err    string
+	}{
+		{
+			name: "KeystorePassphraseWeak",
+			dataIn: &dataIn{
+				wallet:             wallet,
+				keystoresDir:       dir,
+				passphrases:        []string{"pass"},
+				keystorePassphrase: "weak",
+			},
+			err: "supplied keystore passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag",
+		},
+		{
+			name: "PassphraseIncorrect",
+			dataIn: &dataIn{
+				wallet:             wallet,
+				keystoresDir:       dir,
+				passphrases:        []string{"bad"},
+				keystorePassphrase: "ce%NohGhah4ye5ra",
+			},
+			err: `failed to generate keystore for account "Interop 0": failed to unlock account`,
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				wallet:             wallet,
+				keystoresDir:       dir,
+				passphrases:        []string{"bad", "pass"},
+				keystorePassphrase: "ce%NohGhah4ye5ra",
+			},
+		},
+		{
+			name: "Exists",
+			dataIn: &dataIn{
+				wallet:             wallet,
+				keystoresDir:       dir,
+				passphrases:        []string{"pass"},
+				keystorePassphrase: "ce%NohGhah4ye5ra",
+			},
+			err: fmt.Sprintf("%s already exists", filepath.Join(dir, "keystore-Interop_0.json")),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(ctx, test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{filepath.Join(dir, "keystore-Interop_0.json")}, res.keystores)
+
+			data, err := os.ReadFile(res.keystores[0])
+			require.NoError(t, err)
+			account, err := util.ParseAccount(ctx, string(data), []string{"ce%NohGhah4ye5ra"}, true)
+			require.NoError(t, err)
+			privateKey, err := account.(e2wtypes.AccountPrivateKeyProvider).PrivateKey(ctx)
+			require.NoError(t, err)
+			require.Equal(t, key, privateKey.Marshal())
+		})
+	}
+}
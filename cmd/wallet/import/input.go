@@ -34,6 +34,11 @@ type dataIn struct {
 	data       []byte
 	passphrase string
 	verify     bool
+	// For keystore imports.
+	keystoresDir       string
+	walletName         string
+	walletPassphrase   string
+	keystorePassphrase string
 }
 
 func input(_ context.Context) (*dataIn, error) {
@@ -52,6 +57,11 @@ func input(_ context.Context) (*dataIn, error) {
 	data.verbose = viper.GetBool("verbose")
 	data.debug = viper.GetBool("debug")
 
+	// Keystores directory.
+	if viper.GetString("keystores-dir") != "" {
+		return inputKeystores(data)
+	}
+
 	// Data.
 	if viper.GetString("data") == "" {
 		return nil, errors.New("data is required")
@@ -80,3 +90,36 @@ func input(_ context.Context) (*dataIn, error) {
 
 	return data, nil
 }
+
+func inputKeystores(data *dataIn) (*dataIn, error) {
+	var err error
+
+	if viper.GetString("data") != "" {
+		return nil, errors.New("only one of data and keystores-dir is allowed")
+	}
+	data.keystoresDir = viper.GetString("keystores-dir")
+
+	// Wallet.
+	data.walletName = viper.GetString("wallet")
+	if data.walletName == "" {
+		return nil, errors.New("wallet is required when importing keystores")
+	}
+	data.walletPassphrase = util.GetWalletPassphrase()
+
+	// Keystore passphrase.
+	data.keystorePassphrase = viper.GetString("keystore-passphrase")
+	if data.keystorePassphrase == "" {
+		return nil, errors.New("keystore passphrase is required")
+	}
+
+	// Passphrase.
+	data.passphrase, err = util.GetPassphrase()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to obtain account passphrase")
+	}
+
+	// Verify.
+	data.verify = viper.GetBool("verify")
+
+	return data, nil
+}
@@ -0,0 +1,197 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletimport
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	e2wallet "github.com/wealdtech/go-eth2-wallet"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// importKeystore is a keystore to be imported.
+type importKeystore struct {
+	name string
+	key  *e2types.BLSPrivateKey
+}
+
+func processKeystores(ctx context.Context, data *dataIn) (*dataOut, error) {
+	if !util.AcceptablePassphrase(data.passphrase) {
+		return nil, errors.New("supplied passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	keystores, err := readKeystores(data.keystoresDir, data.keystorePassphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	results := &dataOut{
+		verify:    data.verify,
+		quiet:     data.quiet,
+		verbose:   data.verbose,
+		keystores: make([]*keystoreInfo, 0, len(keystores)),
+	}
+	for _, keystore := range keystores {
+		results.keystores = append(results.keystores, &keystoreInfo{
+			name:   keystore.name,
+			pubKey: keystore.key.PublicKey().Marshal(),
+		})
+	}
+
+	wallet, err := e2wallet.OpenWallet(data.walletName)
+	if err != nil {
+		if data.verify {
+			// Wallet would be created on import; nothing to check against.
+			return results, nil
+		}
+		wallet, err = e2wallet.CreateWallet(data.walletName, e2wallet.WithType("nd"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create wallet")
+		}
+	}
+	if wallet.Type() != "non-deterministic" {
+		return nil, fmt.Errorf("keystores can only be imported in to non-deterministic wallets; %q is %s", data.walletName, wallet.Type())
+	}
+	if err := checkClashes(ctx, wallet, keystores); err != nil {
+		return nil, err
+	}
+	if data.verify {
+		return results, nil
+	}
+
+	importer, isImporter := wallet.(e2wtypes.WalletAccountImporter)
+	if !isImporter {
+		return nil, fmt.Errorf("%s wallets do not support importing accounts", wallet.Type())
+	}
+	if locker, isLocker := wallet.(e2wtypes.WalletLocker); isLocker {
+		if err := locker.Unlock(ctx, []byte(data.walletPassphrase)); err != nil {
+			return nil, errors.Wrap(err, "failed to unlock wallet")
+		}
+		defer func() {
+			if err := locker.Lock(ctx); err != nil {
+				util.Log.Trace().Err(err).Msg("Failed to lock wallet")
+			}
+		}()
+	}
+	for _, keystore := range keystores {
+		if _, err := importer.ImportAccount(ctx, keystore.name, keystore.key.Marshal(), []byte(data.passphrase)); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to import account %q", keystore.name))
+		}
+	}
+
+	return results, nil
+}
+
+// readKeystores reads and decrypts the EIP-2335 keystores in a directory.
+// Accounts are named after the files that contain them, without any "keystore-" prefix or ".json" extension.
+func readKeystores(dir string, passphrase string) ([]*importKeystore, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read keystores directory")
+	}
+	sort.Slice(entries, func(i int, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	encryptor := keystorev4.New()
+	keystores := make([]*importKeystore, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		fileData, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to read %s", entry.Name()))
+		}
+		keystore := make(map[string]any)
+		if err := json.Unmarshal(fileData, &keystore); err != nil {
+			// Not a keystore; for example deposit data.
+			continue
+		}
+		crypto, isCrypto := keystore["crypto"].(map[string]any)
+		if !isCrypto {
+			// Not a keystore.
+			continue
+		}
+
+		secret, err := encryptor.Decrypt(crypto, passphrase)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to decrypt %s", entry.Name()))
+		}
+		key, err := e2types.BLSPrivateKeyFromBytes(secret)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid private key in %s", entry.Name()))
+		}
+		if pubKeyStr, isString := keystore["pubkey"].(string); isString && pubKeyStr != "" {
+			pubKey, err := hex.DecodeString(strings.TrimPrefix(pubKeyStr, "0x"))
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("invalid public key in %s", entry.Name()))
+			}
+			if !bytes.Equal(pubKey, key.PublicKey().Marshal()) {
+				return nil, fmt.Errorf("public key in %s does not match its private key", entry.Name())
+			}
+		}
+
+		keystores = append(keystores, &importKeystore{
+			name: strings.TrimPrefix(strings.TrimSuffix(entry.Name(), ".json"), "keystore-"),
+			key:  key,
+		})
+	}
+
+	if len(keystores) == 0 {
+		return nil, fmt.Errorf("no keystores found in %s", dir)
+	}
+
+	return keystores, nil
+}
+
+// checkClashes ensures that none of the keystores clash with existing accounts in the wallet.
+func checkClashes(ctx context.Context, wallet e2wtypes.Wallet, keystores []*importKeystore) error {
+	names := make(map[string]bool)
+	pubKeys := make(map[string]string)
+	for account := range wallet.Accounts(ctx) {
+		names[account.Name()] = true
+		if pubKeyProvider, isProvider := account.(e2wtypes.AccountPublicKeyProvider); isProvider {
+			pubKeys[string(pubKeyProvider.PublicKey().Marshal())] = account.Name()
+		}
+	}
+
+	seen := make(map[string]string)
+	for _, keystore := range keystores {
+		if names[keystore.name] {
+			return fmt.Errorf("account %q already exists in wallet", keystore.name)
+		}
+		if name, exists := pubKeys[string(keystore.key.PublicKey().Marshal())]; exists {
+			return fmt.Errorf("key in %s already exists in wallet as account %q", keystore.name, name)
+		}
+		if name, exists := seen[string(keystore.key.PublicKey().Marshal())]; exists {
+			return fmt.Errorf("key in %s duplicates key in %s", keystore.name, name)
+		}
+		seen[string(keystore.key.PublicKey().Marshal())] = keystore.name
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ package walletimport
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gofrs/uuid"
 	"github.com/pkg/errors"
@@ -26,6 +27,13 @@ type dataOut struct {
 	quiet   bool
 	verbose bool
 	export  *export
+	// For keystore imports.
+	keystores []*keystoreInfo
+}
+
+type keystoreInfo struct {
+	name   string
+	pubKey []byte
 }
 
 type accountInfo struct {
@@ -46,6 +54,10 @@ func output(_ context.Context, data *dataOut) (string, error) {
 		return "", errors.New("no data")
 	}
 
+	if data.keystores != nil {
+		return outputKeystores(data), nil
+	}
+
 	res := ""
 	if data.verify {
 		if !data.quiet {
@@ -60,3 +72,25 @@ func output(_ context.Context, data *dataOut) (string, error) {
 
 	return res, nil
 }
+
+func outputKeystores(data *dataOut) string {
+	if data.quiet {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	if data.verify {
+		builder.WriteString(fmt.Sprintf("Keystores: %d\n", len(data.keystores)))
+		if data.verbose {
+			for _, keystore := range data.keystores {
+				builder.WriteString(fmt.Sprintf("  %s: %#x\n", keystore.name, keystore.pubKey))
+			}
+		}
+	} else if data.verbose {
+		for _, keystore := range data.keystores {
+			builder.WriteString(fmt.Sprintf("Imported account %s\n", keystore.name))
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "\n")
+}
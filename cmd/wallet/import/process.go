@@ -22,10 +22,13 @@ import (
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 )
 
-func process(_ context.Context, data *dataIn) (*dataOut, error) {
+func process(ctx context.Context, data *dataIn) (*dataOut, error) {
 	if data == nil {
 		return nil, errors.New("no data")
 	}
+	if data.keystoresDir != "" {
+		return processKeystores(ctx, data)
+	}
 	if data.data == nil {
 		return nil, errors.New("import data is required")
 	}
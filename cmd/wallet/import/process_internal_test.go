@@ -15,10 +15,15 @@ package walletimport
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
@@ -130,3 +135,104 @@ func TestProcess(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessKeystores(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	// Create a directory of keystores, along with a file that is not a keystore.
+	dir := t.TempDir()
+	key := testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866")
+	crypto, err := keystorev4.New().Encrypt(key, "keystore secret")
+	require.NoError(t, err)
+	keystore, err := json.Marshal(map[string]any{
+		"crypto":  crypto,
+		"pubkey":  "a99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c",
+		"path":    "m/12381/3600/0/0/0",
+		"uuid":    "cb0a7a7c-9f4b-4bd1-b49a-ab2e0b1c3e52",
+		"version": 4,
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keystore-m_12381_3600_0_0_0.json"), keystore, 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "deposit_data.json"), []byte("[]"), 0o600))
+
+	tests := []struct {
+		name     string
+		dataIn   *dataIn
+		accounts int
+		err      string
+	}{
+		{
+			name: "DirMissing",
+			dataIn: &dataIn{
+				keystoresDir:       filepath.Join(dir, "missing"),
+				walletName:         "Test wallet",
+				keystorePassphrase: "keystore secret",
+				passphrase:         "ce%NohGhah4ye5ra",
+			},
+			err: fmt.Sprintf("failed to read keystores directory: open %s: no such file or directory", filepath.Join(dir, "missing")),
+		},
+		{
+			name: "KeystorePassphraseIncorrect",
+			dataIn: &dataIn{
+				keystoresDir:       dir,
+				walletName:         "Test wallet",
+				keystorePassphrase: "bad",
+				passphrase:         "ce%NohGhah4ye5ra",
+			},
+			err: "failed to decrypt keystore-m_12381_3600_0_0_0.json: invalid checksum",
+		},
+		{
+			name: "Verify",
+			dataIn: &dataIn{
+				keystoresDir:       dir,
+				walletName:         "Test wallet",
+				keystorePassphrase: "keystore secret",
+				passphrase:         "ce%NohGhah4ye5ra",
+				verify:             true,
+			},
+		},
+		{
+			name: "Good",
+			dataIn: &dataIn{
+				keystoresDir:       dir,
+				walletName:         "Test wallet",
+				keystorePassphrase: "keystore secret",
+				passphrase:         "ce%NohGhah4ye5ra",
+			},
+			accounts: 1,
+		},
+		{
+			name: "Duplicate",
+			dataIn: &dataIn{
+				keystoresDir:       dir,
+				walletName:         "Test wallet",
+				keystorePassphrase: "keystore secret",
+				passphrase:         "ce%NohGhah4ye5ra",
+			},
+			err: `account "m_12381_3600_0_0_0" already exists in wallet`,
+		},
+	}
+
+	require.NoError(t, e2wallet.UseStore(scratch.New()))
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := process(ctx, test.dataIn)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.keystores, 1)
+			require.Equal(t, "m_12381_3600_0_0_0", res.keystores[0].name)
+
+			if test.accounts > 0 {
+				wallet, err := e2wallet.OpenWallet("Test wallet")
+				require.NoError(t, err)
+				account, err := wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "m_12381_3600_0_0_0")
+				require.NoError(t, err)
+				require.NoError(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte("ce%NohGhah4ye5ra")))
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletrekey
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/util"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+type command struct {
+	quiet   bool
+	verbose bool
+	debug   bool
+
+	timeout time.Duration
+
+	// Input.
+	wallet        string
+	passphrases   []string
+	newPassphrase string
+	encryptor     e2wtypes.Encryptor
+	backupDir     string
+
+	// Output.
+	rekeyed []*util.RekeyedAccount
+}
+
+func newCommand(_ context.Context) (*command, error) {
+	c := &command{
+		quiet:         viper.GetBool("quiet"),
+		verbose:       viper.GetBool("verbose"),
+		debug:         viper.GetBool("debug"),
+		timeout:       viper.GetDuration("timeout"),
+		wallet:        viper.GetString("wallet"),
+		passphrases:   util.GetPassphrases(),
+		newPassphrase: viper.GetString("new-passphrase"),
+		backupDir:     viper.GetString("backup-dir"),
+	}
+
+	if c.timeout == 0 {
+		return nil, errors.New("timeout is required")
+	}
+
+	if c.wallet == "" {
+		return nil, errors.New("wallet is required")
+	}
+
+	if len(c.passphrases) == 0 {
+		return nil, errors.New("passphrase is required")
+	}
+
+	if c.newPassphrase == "" {
+		return nil, errors.New("new passphrase is required")
+	}
+	if !util.AcceptablePassphrase(c.newPassphrase) {
+		return nil, errors.New("supplied new passphrase is weak; use a stronger one or run with the --allow-weak-passphrases flag")
+	}
+
+	var err error
+	c.encryptor, err = util.KeystoreEncryptor(viper.GetString("kdf"))
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
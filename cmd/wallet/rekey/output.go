@@ -0,0 +1,44 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package walletrekey
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func (c *command) output(_ context.Context) (string, error) {
+	if c.quiet {
+		return "", nil
+	}
+
+	names := make([]string, 0, len(c.rekeyed))
+	for _, account := range c.rekeyed {
+		names = append(names, account.Name)
+	}
+	sort.Strings(names)
+
+	builder := strings.Builder{}
+	for _, name := range names {
+		builder.WriteString(fmt.Sprintf("Re-encrypted account %s\n", name))
+	}
+	if len(c.rekeyed) > 0 {
+		builder.WriteString(fmt.Sprintf("Previous keystores backed up to %s", filepath.Dir(c.rekeyed[0].Backup)))
+	}
+
+	return builder.String(), nil
+}
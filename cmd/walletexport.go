@@ -17,6 +17,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	walletexport "github.com/wealdtech/ethdo/cmd/wallet/export"
 )
 
@@ -27,6 +28,10 @@ var walletExportCmd = &cobra.Command{
 
     ethdo wallet export --wallet=primary --passphrase="my export secret"
 
+Alternatively, write each account in the wallet to a directory as a standalone EIP-2335 keystore.  For example:
+
+    ethdo wallet export --wallet=primary --passphrase="my account secret" --keystores-dir=keystores --keystore-passphrase="my keystore secret"
+
 In quiet mode this will return 0 if the wallet is able to be exported, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := walletexport.Run(cmd)
@@ -43,4 +48,15 @@ In quiet mode this will return 0 if the wallet is able to be exported, otherwise
 func init() {
 	walletCmd.AddCommand(walletExportCmd)
 	walletFlags(walletExportCmd)
+	walletExportCmd.Flags().String("keystores-dir", "", "A directory in which to write each account as an EIP-2335 keystore")
+	walletExportCmd.Flags().String("keystore-passphrase", "", "The passphrase with which to encrypt the keystores")
+}
+
+func walletExportBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("keystores-dir", cmd.Flags().Lookup("keystores-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("keystore-passphrase", cmd.Flags().Lookup("keystore-passphrase")); err != nil {
+		panic(err)
+	}
 }
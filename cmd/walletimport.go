@@ -28,6 +28,10 @@ var walletImportCmd = &cobra.Command{
 
     ethdo wallet import --data=primary --passphrase="my export secret"
 
+Alternatively, import a directory of EIP-2335 keystores in to a non-deterministic wallet, which will be created if it does not exist.  Accounts are named after the files that contain their keystores, without any "keystore-" prefix or ".json" extension.  For example:
+
+    ethdo wallet import --keystores-dir=validator_keys --keystore-passphrase="my keystore secret" --wallet=validators --passphrase="my account secret"
+
 In quiet mode this will return 0 if the wallet is imported successfully, otherwise 1.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		res, err := walletimport.Run(cmd)
@@ -46,6 +50,8 @@ func init() {
 	walletFlags(walletImportCmd)
 	walletImportCmd.Flags().String("data", "", "The data to import, or the name of a data import file")
 	walletImportCmd.Flags().Bool("verify", false, "Verify the wallet can be imported, but do not import it")
+	walletImportCmd.Flags().String("keystores-dir", "", "A directory of EIP-2335 keystores to import in to a non-deterministic wallet")
+	walletImportCmd.Flags().String("keystore-passphrase", "", "The passphrase of the keystores")
 }
 
 func walletImportBindings(cmd *cobra.Command) {
@@ -55,4 +61,10 @@ func walletImportBindings(cmd *cobra.Command) {
 	if err := viper.BindPFlag("verify", cmd.Flags().Lookup("verify")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("keystores-dir", cmd.Flags().Lookup("keystores-dir")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("keystore-passphrase", cmd.Flags().Lookup("keystore-passphrase")); err != nil {
+		panic(err)
+	}
 }
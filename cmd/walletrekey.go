@@ -0,0 +1,67 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	walletrekey "github.com/wealdtech/ethdo/cmd/wallet/rekey"
+)
+
+var walletRekeyCmd = &cobra.Command{
+	Use:   "rekey",
+	Short: "Re-encrypt all accounts in a wallet",
+	Long: `Re-encrypt all accounts in a wallet with a new passphrase.  For example:
+
+    ethdo wallet rekey --wallet="Primary wallet" --passphrase="my secret" --new-passphrase="my new secret" --kdf=scrypt
+
+Multiple current passphrases can be supplied if accounts are protected by different passphrases.  All accounts must be able to be decrypted for any to be changed.  Keystores of the accounts as they were prior to the change are written to a new directory inside the directory supplied with --backup-dir, or the current directory if not supplied.
+
+In quiet mode this will return 0 if the wallet is rekeyed, otherwise 1.`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		res, err := walletrekey.Run(cmd)
+		if err != nil {
+			return err
+		}
+		if viper.GetBool("quiet") {
+			return nil
+		}
+		if res != "" {
+			fmt.Println(res)
+		}
+		return nil
+	},
+}
+
+func init() {
+	walletCmd.AddCommand(walletRekeyCmd)
+	walletFlags(walletRekeyCmd)
+	walletRekeyCmd.Flags().String("new-passphrase", "", "The new passphrase for the accounts")
+	walletRekeyCmd.Flags().String("kdf", "pbkdf2", "The key derivation function with which to encrypt the accounts (pbkdf2 or scrypt)")
+	walletRekeyCmd.Flags().String("backup-dir", "", "The directory in which to back up the accounts prior to the change")
+}
+
+func walletRekeyBindings(cmd *cobra.Command) {
+	if err := viper.BindPFlag("new-passphrase", cmd.Flags().Lookup("new-passphrase")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("kdf", cmd.Flags().Lookup("kdf")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("backup-dir", cmd.Flags().Lookup("backup-dir")); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,210 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// CeremonyVersion is the current version of the ceremony definition.
+const CeremonyVersion = 1
+
+// Ceremony is the definition of a distributed key generation ceremony.
+type Ceremony struct {
+	Version               uint64
+	ID                    uuid.UUID
+	Participants          uint64
+	Threshold             uint64
+	WithdrawalCredentials []byte
+	Amount                phase0.Gwei
+	ForkVersion           phase0.Version
+}
+
+type ceremonyJSON struct {
+	Version               string `json:"version"`
+	ID                    string `json:"id"`
+	Participants          string `json:"participants"`
+	Threshold             string `json:"threshold"`
+	WithdrawalCredentials string `json:"withdrawal_credentials"`
+	Amount                string `json:"amount"`
+	ForkVersion           string `json:"fork_version"`
+}
+
+// NewCeremony creates a new ceremony definition.
+func NewCeremony(participants uint64,
+	threshold uint64,
+	withdrawalCredentials []byte,
+	amount phase0.Gwei,
+	forkVersion phase0.Version,
+) (
+	*Ceremony,
+	error,
+) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate ceremony ID")
+	}
+
+	ceremony := &Ceremony{
+		Version:               CeremonyVersion,
+		ID:                    id,
+		Participants:          participants,
+		Threshold:             threshold,
+		WithdrawalCredentials: withdrawalCredentials,
+		Amount:                amount,
+		ForkVersion:           forkVersion,
+	}
+	if err := ceremony.verify(); err != nil {
+		return nil, err
+	}
+
+	return ceremony, nil
+}
+
+// verify ensures that the parameters of the ceremony are consistent.
+func (c *Ceremony) verify() error {
+	if c.Participants < 2 {
+		return errors.New("ceremony requires at least two participants")
+	}
+	if c.Threshold == 0 {
+		return errors.New("signing threshold must be at least one")
+	}
+	if c.Threshold <= c.Participants/2 {
+		return errors.New("signing threshold must be more than half the number of participants")
+	}
+	if c.Threshold > c.Participants {
+		return errors.New("signing threshold cannot be higher than the number of participants")
+	}
+	if len(c.WithdrawalCredentials) != phase0.RootLength {
+		return errors.New("withdrawal credentials must be 32 bytes")
+	}
+	if c.Amount == 0 {
+		return errors.New("deposit amount must be supplied")
+	}
+
+	return nil
+}
+
+// verifyParticipant ensures that the participant is part of the ceremony.
+func (c *Ceremony) verifyParticipant(participant uint64) error {
+	if participant == 0 || participant > c.Participants {
+		return fmt.Errorf("participant %d invalid; must be between 1 and %d", participant, c.Participants)
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *Ceremony) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&ceremonyJSON{
+		Version:               strconv.FormatUint(c.Version, 10),
+		ID:                    c.ID.String(),
+		Participants:          strconv.FormatUint(c.Participants, 10),
+		Threshold:             strconv.FormatUint(c.Threshold, 10),
+		WithdrawalCredentials: fmt.Sprintf("%#x", c.WithdrawalCredentials),
+		Amount:                fmt.Sprintf("%d", c.Amount),
+		ForkVersion:           fmt.Sprintf("%#x", c.ForkVersion),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Ceremony) UnmarshalJSON(input []byte) error {
+	var data ceremonyJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	var err error
+	c.Version, err = parseUint(data.Version, "version")
+	if err != nil {
+		return err
+	}
+	if c.Version != CeremonyVersion {
+		return fmt.Errorf("unsupported version %d", c.Version)
+	}
+
+	if data.ID == "" {
+		return errors.New("id missing")
+	}
+	c.ID, err = uuid.Parse(data.ID)
+	if err != nil {
+		return errors.Wrap(err, "id invalid")
+	}
+
+	c.Participants, err = parseUint(data.Participants, "participants")
+	if err != nil {
+		return err
+	}
+
+	c.Threshold, err = parseUint(data.Threshold, "threshold")
+	if err != nil {
+		return err
+	}
+
+	c.WithdrawalCredentials, err = parseHex(data.WithdrawalCredentials, "withdrawal credentials", phase0.RootLength)
+	if err != nil {
+		return err
+	}
+
+	amount, err := parseUint(data.Amount, "amount")
+	if err != nil {
+		return err
+	}
+	c.Amount = phase0.Gwei(amount)
+
+	forkVersion, err := parseHex(data.ForkVersion, "fork version", phase0.ForkVersionLength)
+	if err != nil {
+		return err
+	}
+	copy(c.ForkVersion[:], forkVersion)
+
+	return c.verify()
+}
+
+// parseUint parses a decimal value from a JSON field.
+func parseUint(input string, name string) (uint64, error) {
+	if input == "" {
+		return 0, fmt.Errorf("%s missing", name)
+	}
+	res, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("%s invalid", name))
+	}
+
+	return res, nil
+}
+
+// parseHex parses a hex value of known length from a JSON field.
+func parseHex(input string, name string, length int) ([]byte, error) {
+	if input == "" {
+		return nil, fmt.Errorf("%s missing", name)
+	}
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("%s invalid", name))
+	}
+	if len(res) != length {
+		return nil, fmt.Errorf("%s incorrect length", name)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,247 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// Contribution is the public part of a participant's contribution to a ceremony.
+// It contains commitments to the coefficients of the participant's secret polynomial,
+// against which the shares sent to other participants can be verified.
+type Contribution struct {
+	CeremonyID  uuid.UUID
+	Participant uint64
+	Commitments []phase0.BLSPubKey
+	// Proof is a signature by the participant's secret, proving knowledge of it.
+	Proof phase0.BLSSignature
+}
+
+type contributionJSON struct {
+	CeremonyID  string   `json:"ceremony_id"`
+	Participant string   `json:"participant"`
+	Commitments []string `json:"commitments"`
+	Proof       string   `json:"proof"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c *Contribution) MarshalJSON() ([]byte, error) {
+	commitments := make([]string, len(c.Commitments))
+	for i := range c.Commitments {
+		commitments[i] = fmt.Sprintf("%#x", c.Commitments[i])
+	}
+
+	return json.Marshal(&contributionJSON{
+		CeremonyID:  c.CeremonyID.String(),
+		Participant: strconv.FormatUint(c.Participant, 10),
+		Commitments: commitments,
+		Proof:       fmt.Sprintf("%#x", c.Proof),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *Contribution) UnmarshalJSON(input []byte) error {
+	var data contributionJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	var err error
+	c.CeremonyID, err = parseID(data.CeremonyID)
+	if err != nil {
+		return err
+	}
+
+	c.Participant, err = parseUint(data.Participant, "participant")
+	if err != nil {
+		return err
+	}
+
+	if len(data.Commitments) == 0 {
+		return errors.New("commitments missing")
+	}
+	c.Commitments = make([]phase0.BLSPubKey, len(data.Commitments))
+	for i := range data.Commitments {
+		commitment, err := parseHex(data.Commitments[i], fmt.Sprintf("commitment %d", i), phase0.PublicKeyLength)
+		if err != nil {
+			return err
+		}
+		copy(c.Commitments[i][:], commitment)
+	}
+
+	proof, err := parseHex(data.Proof, "proof", phase0.SignatureLength)
+	if err != nil {
+		return err
+	}
+	copy(c.Proof[:], proof)
+
+	return nil
+}
+
+// Share is a secret share of a participant's contribution, sent privately to another participant.
+// Shares are only ever written to disk encrypted to their recipient, as an EncryptedShare.
+type Share struct {
+	CeremonyID uuid.UUID
+	From       uint64
+	To         uint64
+	Secret     []byte
+}
+
+// EncryptedShare is a secret share encrypted to the encryption key of its recipient.
+type EncryptedShare struct {
+	CeremonyID   uuid.UUID
+	From         uint64
+	To           uint64
+	EphemeralKey []byte
+	Nonce        []byte
+	Ciphertext   []byte
+}
+
+type encryptedShareJSON struct {
+	CeremonyID   string `json:"ceremony_id"`
+	From         string `json:"from"`
+	To           string `json:"to"`
+	EphemeralKey string `json:"ephemeral_key"`
+	Nonce        string `json:"nonce"`
+	Ciphertext   string `json:"ciphertext"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e *EncryptedShare) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&encryptedShareJSON{
+		CeremonyID:   e.CeremonyID.String(),
+		From:         strconv.FormatUint(e.From, 10),
+		To:           strconv.FormatUint(e.To, 10),
+		EphemeralKey: fmt.Sprintf("%#x", e.EphemeralKey),
+		Nonce:        fmt.Sprintf("%#x", e.Nonce),
+		Ciphertext:   fmt.Sprintf("%#x", e.Ciphertext),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (e *EncryptedShare) UnmarshalJSON(input []byte) error {
+	var data encryptedShareJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	var err error
+	e.CeremonyID, err = parseID(data.CeremonyID)
+	if err != nil {
+		return err
+	}
+
+	e.From, err = parseUint(data.From, "from")
+	if err != nil {
+		return err
+	}
+
+	e.To, err = parseUint(data.To, "to")
+	if err != nil {
+		return err
+	}
+
+	e.EphemeralKey, err = parseHex(data.EphemeralKey, "ephemeral key", encryptionKeyLength)
+	if err != nil {
+		return err
+	}
+
+	if data.Nonce == "" {
+		return errors.New("nonce missing")
+	}
+	e.Nonce, err = hex.DecodeString(strings.TrimPrefix(data.Nonce, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "nonce invalid")
+	}
+
+	if data.Ciphertext == "" {
+		return errors.New("ciphertext missing")
+	}
+	e.Ciphertext, err = hex.DecodeString(strings.TrimPrefix(data.Ciphertext, "0x"))
+	if err != nil {
+		return errors.Wrap(err, "ciphertext invalid")
+	}
+
+	return nil
+}
+
+// DepositSignature is a participant's signature share over the deposit of the composite key.
+type DepositSignature struct {
+	CeremonyID  uuid.UUID
+	Participant uint64
+	Signature   phase0.BLSSignature
+}
+
+type depositSignatureJSON struct {
+	CeremonyID  string `json:"ceremony_id"`
+	Participant string `json:"participant"`
+	Signature   string `json:"signature"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (d *DepositSignature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&depositSignatureJSON{
+		CeremonyID:  d.CeremonyID.String(),
+		Participant: strconv.FormatUint(d.Participant, 10),
+		Signature:   fmt.Sprintf("%#x", d.Signature),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *DepositSignature) UnmarshalJSON(input []byte) error {
+	var data depositSignatureJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	var err error
+	d.CeremonyID, err = parseID(data.CeremonyID)
+	if err != nil {
+		return err
+	}
+
+	d.Participant, err = parseUint(data.Participant, "participant")
+	if err != nil {
+		return err
+	}
+
+	signature, err := parseHex(data.Signature, "signature", phase0.SignatureLength)
+	if err != nil {
+		return err
+	}
+	copy(d.Signature[:], signature)
+
+	return nil
+}
+
+// parseID parses a ceremony ID from a JSON field.
+func parseID(input string) (uuid.UUID, error) {
+	if input == "" {
+		return uuid.UUID{}, errors.New("ceremony id missing")
+	}
+	id, err := uuid.Parse(input)
+	if err != nil {
+		return uuid.UUID{}, errors.Wrap(err, "ceremony id invalid")
+	}
+
+	return id, nil
+}
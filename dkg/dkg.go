@@ -0,0 +1,413 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dkg provides a file-based distributed key generation ceremony.
+//
+// The ceremony uses joint Feldman verifiable secret sharing.  Each participant generates a random
+// polynomial of degree threshold-1, publishes commitments to its coefficients, and privately sends
+// each participant the evaluation of the polynomial at that participant's identifier.  The sum of
+// the shares received by a participant is its share of the composite key, and the sum of the
+// constant term commitments is the composite public key.  No single participant ever knows the
+// composite private key.
+package dkg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// Result is the outcome of a ceremony for a single participant.
+type Result struct {
+	Participant uint64
+	// Key is the participant's share of the composite key.
+	Key *e2types.BLSPrivateKey
+	// PublicKey is the composite public key.
+	PublicKey phase0.BLSPubKey
+	// ParticipantPublicKeys are the public keys of each participant's share of the composite key.
+	ParticipantPublicKeys map[uint64]phase0.BLSPubKey
+}
+
+// Contribute generates a participant's contribution to the ceremony.
+// It returns the public contribution, and the secret shares for each participant in the ceremony,
+// including the contributing participant itself.
+func Contribute(ceremony *Ceremony, participant uint64) (*Contribution, []*Share, error) {
+	if err := ceremony.verifyParticipant(participant); err != nil {
+		return nil, nil, err
+	}
+
+	var secret bls.SecretKey
+	secret.SetByCSPRNG()
+	// The polynomial has degree threshold-1, so any threshold shares can recover it.
+	msk := secret.GetMasterSecretKey(int(ceremony.Threshold))
+	mpk := bls.GetMasterPublicKey(msk)
+
+	contribution := &Contribution{
+		CeremonyID:  ceremony.ID,
+		Participant: participant,
+		Commitments: make([]phase0.BLSPubKey, len(mpk)),
+	}
+	for i := range mpk {
+		copy(contribution.Commitments[i][:], mpk[i].Serialize())
+	}
+	copy(contribution.Proof[:], secret.SignByte(proofMessage(ceremony, participant)).Serialize())
+
+	shares := make([]*Share, ceremony.Participants)
+	for i := range shares {
+		to := uint64(i + 1)
+		var share bls.SecretKey
+		if err := share.Set(msk, util.BLSID(to)); err != nil {
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to generate share for participant %d", to))
+		}
+		shares[i] = &Share{
+			CeremonyID: ceremony.ID,
+			From:       participant,
+			To:         to,
+			Secret:     share.Serialize(),
+		}
+	}
+
+	return contribution, shares, nil
+}
+
+// VerifyContribution ensures that a contribution is well-formed for the ceremony, and that its
+// author knows the secret to which it commits.
+func VerifyContribution(ceremony *Ceremony, contribution *Contribution) error {
+	if contribution.CeremonyID != ceremony.ID {
+		return errors.New("contribution is for a different ceremony")
+	}
+	if err := ceremony.verifyParticipant(contribution.Participant); err != nil {
+		return err
+	}
+	if uint64(len(contribution.Commitments)) != ceremony.Threshold {
+		return fmt.Errorf("contribution has %d commitments; expected %d", len(contribution.Commitments), ceremony.Threshold)
+	}
+
+	commitments, err := commitmentKeys(contribution)
+	if err != nil {
+		return err
+	}
+
+	var proof bls.Sign
+	// Copy the signature, as the BLS library cannot accept memory that is part of a larger structure.
+	if err := proof.Deserialize(append([]byte{}, contribution.Proof[:]...)); err != nil {
+		return errors.Wrap(err, "invalid proof")
+	}
+	if !proof.VerifyByte(&commitments[0], proofMessage(ceremony, contribution.Participant)) {
+		return errors.New("proof does not verify")
+	}
+
+	return nil
+}
+
+// VerifyShare ensures that a share matches the commitments of the contribution from which it came.
+func VerifyShare(ceremony *Ceremony, contribution *Contribution, share *Share) error {
+	if share.CeremonyID != ceremony.ID {
+		return errors.New("share is for a different ceremony")
+	}
+	if share.From != contribution.Participant {
+		return fmt.Errorf("share is from participant %d but contribution is from participant %d", share.From, contribution.Participant)
+	}
+	if err := ceremony.verifyParticipant(share.To); err != nil {
+		return err
+	}
+
+	commitments, err := commitmentKeys(contribution)
+	if err != nil {
+		return err
+	}
+	var expected bls.PublicKey
+	if err := expected.Set(commitments, util.BLSID(share.To)); err != nil {
+		return errors.Wrap(err, "failed to evaluate commitments")
+	}
+
+	var secret bls.SecretKey
+	if err := secret.Deserialize(share.Secret); err != nil {
+		return errors.Wrap(err, "invalid share")
+	}
+	if !secret.GetPublicKey().IsEqual(&expected) {
+		return fmt.Errorf("share from participant %d does not match its commitments", share.From)
+	}
+
+	return nil
+}
+
+// Finalize verifies the contributions of all participants and the shares received by the given
+// participant, and combines them to obtain the participant's share of the composite key.
+func Finalize(ceremony *Ceremony,
+	participant uint64,
+	contributions []*Contribution,
+	shares []*Share,
+) (
+	*Result,
+	error,
+) {
+	if err := ceremony.verifyParticipant(participant); err != nil {
+		return nil, err
+	}
+
+	contributionMap := make(map[uint64]*Contribution)
+	for _, contribution := range contributions {
+		if err := VerifyContribution(ceremony, contribution); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("contribution from participant %d", contribution.Participant))
+		}
+		if _, exists := contributionMap[contribution.Participant]; exists {
+			return nil, fmt.Errorf("duplicate contribution from participant %d", contribution.Participant)
+		}
+		contributionMap[contribution.Participant] = contribution
+	}
+	for i := uint64(1); i <= ceremony.Participants; i++ {
+		if _, exists := contributionMap[i]; !exists {
+			return nil, fmt.Errorf("no contribution from participant %d", i)
+		}
+	}
+
+	var secret bls.SecretKey
+	received := make(map[uint64]bool)
+	for _, share := range shares {
+		if share.To != participant {
+			return nil, fmt.Errorf("share from participant %d is for participant %d", share.From, share.To)
+		}
+		contribution, exists := contributionMap[share.From]
+		if !exists {
+			return nil, fmt.Errorf("share from unknown participant %d", share.From)
+		}
+		if err := VerifyShare(ceremony, contribution, share); err != nil {
+			return nil, err
+		}
+		if received[share.From] {
+			return nil, fmt.Errorf("duplicate share from participant %d", share.From)
+		}
+
+		var key bls.SecretKey
+		if err := key.Deserialize(share.Secret); err != nil {
+			return nil, errors.Wrap(err, "invalid share")
+		}
+		if len(received) == 0 {
+			secret = key
+		} else {
+			secret.Add(&key)
+		}
+		received[share.From] = true
+	}
+	for i := uint64(1); i <= ceremony.Participants; i++ {
+		if !received[i] {
+			return nil, fmt.Errorf("no share from participant %d", i)
+		}
+	}
+
+	// The composite public key is the sum of the constant term commitments, and the public key of each
+	// participant is the sum of the evaluations of all commitments at that participant's identifier.
+	var publicKey bls.PublicKey
+	participantKeys := make([]bls.PublicKey, ceremony.Participants)
+	for i := uint64(1); i <= ceremony.Participants; i++ {
+		commitments, err := commitmentKeys(contributionMap[i])
+		if err != nil {
+			return nil, err
+		}
+		if i == 1 {
+			publicKey = commitments[0]
+		} else {
+			publicKey.Add(&commitments[0])
+		}
+		for j := range participantKeys {
+			var key bls.PublicKey
+			if err := key.Set(commitments, util.BLSID(uint64(j+1))); err != nil {
+				return nil, errors.Wrap(err, "failed to evaluate commitments")
+			}
+			if i == 1 {
+				participantKeys[j] = key
+			} else {
+				participantKeys[j].Add(&key)
+			}
+		}
+	}
+	if !secret.GetPublicKey().IsEqual(&participantKeys[participant-1]) {
+		return nil, errors.New("combined share does not match commitments")
+	}
+
+	key, err := e2types.BLSPrivateKeyFromBytes(secret.Serialize())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create key from combined share")
+	}
+	result := &Result{
+		Participant:           participant,
+		Key:                   key,
+		ParticipantPublicKeys: make(map[uint64]phase0.BLSPubKey),
+	}
+	copy(result.PublicKey[:], publicKey.Serialize())
+	for i := range participantKeys {
+		var participantKey phase0.BLSPubKey
+		copy(participantKey[:], participantKeys[i].Serialize())
+		result.ParticipantPublicKeys[uint64(i+1)] = participantKey
+	}
+
+	return result, nil
+}
+
+// SignDeposit signs the deposit for the composite key with the participant's share.
+func (r *Result) SignDeposit(ceremony *Ceremony) (*DepositSignature, error) {
+	_, signingRoot, err := depositRoots(ceremony, r.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &DepositSignature{
+		CeremonyID:  ceremony.ID,
+		Participant: r.Participant,
+	}
+	copy(res.Signature[:], r.Key.Sign(signingRoot[:]).Marshal())
+
+	return res, nil
+}
+
+// CombineDepositSignatures combines the deposit signatures of at least threshold participants
+// in to a signed deposit for the composite key.
+func (r *Result) CombineDepositSignatures(ceremony *Ceremony, signatures []*DepositSignature) (*phase0.DepositData, error) {
+	_, signingRoot, err := depositRoots(ceremony, r.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := make(map[uint64]*DepositSignature)
+	for _, signature := range signatures {
+		if signature.CeremonyID != ceremony.ID {
+			return nil, fmt.Errorf("deposit signature from participant %d is for a different ceremony", signature.Participant)
+		}
+		participantKey, exists := r.ParticipantPublicKeys[signature.Participant]
+		if !exists {
+			return nil, fmt.Errorf("deposit signature from unknown participant %d", signature.Participant)
+		}
+		if err := verifySignature(participantKey, signature.Signature, signingRoot); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("deposit signature from participant %d", signature.Participant))
+		}
+		valid[signature.Participant] = signature
+	}
+	if uint64(len(valid)) < ceremony.Threshold {
+		return nil, fmt.Errorf("%d deposit signatures available; %d required", len(valid), ceremony.Threshold)
+	}
+
+	participants := make([]uint64, 0, len(valid))
+	for participant := range valid {
+		participants = append(participants, participant)
+	}
+	sort.Slice(participants, func(i, j int) bool { return participants[i] < participants[j] })
+	participants = participants[:ceremony.Threshold]
+
+	sigs := make([]bls.Sign, len(participants))
+	ids := make([]bls.ID, len(participants))
+	for i, participant := range participants {
+		// Copy the signature, as the BLS library cannot accept memory that is part of a larger structure.
+		if err := sigs[i].Deserialize(append([]byte{}, valid[participant].Signature[:]...)); err != nil {
+			return nil, errors.Wrap(err, "invalid signature")
+		}
+		ids[i] = *util.BLSID(participant)
+	}
+	var combined bls.Sign
+	if err := combined.Recover(sigs, ids); err != nil {
+		return nil, errors.Wrap(err, "failed to combine deposit signatures")
+	}
+
+	deposit := &phase0.DepositData{
+		PublicKey:             r.PublicKey,
+		WithdrawalCredentials: ceremony.WithdrawalCredentials,
+		Amount:                ceremony.Amount,
+	}
+	copy(deposit.Signature[:], combined.Serialize())
+	if err := verifySignature(deposit.PublicKey, deposit.Signature, signingRoot); err != nil {
+		return nil, errors.Wrap(err, "combined deposit signature")
+	}
+
+	return deposit, nil
+}
+
+// DepositMessageRoot returns the root of the deposit message for the composite key.
+func (r *Result) DepositMessageRoot(ceremony *Ceremony) (phase0.Root, error) {
+	messageRoot, _, err := depositRoots(ceremony, r.PublicKey)
+
+	return messageRoot, err
+}
+
+// depositRoots returns the message and signing roots for the deposit of the composite key.
+func depositRoots(ceremony *Ceremony, publicKey phase0.BLSPubKey) (phase0.Root, phase0.Root, error) {
+	messageRoot, err := (&phase0.DepositMessage{
+		PublicKey:             publicKey,
+		WithdrawalCredentials: ceremony.WithdrawalCredentials,
+		Amount:                ceremony.Amount,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, phase0.Root{}, errors.Wrap(err, "failed to generate deposit message root")
+	}
+
+	domain := phase0.Domain{}
+	copy(domain[:], e2types.Domain(e2types.DomainDeposit, ceremony.ForkVersion[:], e2types.ZeroGenesisValidatorsRoot))
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: messageRoot,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, phase0.Root{}, errors.Wrap(err, "failed to generate deposit signing root")
+	}
+
+	return messageRoot, signingRoot, nil
+}
+
+// verifySignature verifies a signature over a root.
+func verifySignature(publicKey phase0.BLSPubKey, signature phase0.BLSSignature, root phase0.Root) error {
+	var pubKey bls.PublicKey
+	// Copy the key, as the BLS library cannot accept memory that is part of a larger structure.
+	if err := pubKey.Deserialize(append([]byte{}, publicKey[:]...)); err != nil {
+		return errors.Wrap(err, "invalid public key")
+	}
+	var sig bls.Sign
+	// Copy the signature, as the BLS library cannot accept memory that is part of a larger structure.
+	if err := sig.Deserialize(append([]byte{}, signature[:]...)); err != nil {
+		return errors.Wrap(err, "invalid signature")
+	}
+	if !sig.VerifyByte(&pubKey, root[:]) {
+		return errors.New("signature does not verify")
+	}
+
+	return nil
+}
+
+// commitmentKeys returns the commitments of a contribution as BLS public keys.
+func commitmentKeys(contribution *Contribution) ([]bls.PublicKey, error) {
+	keys := make([]bls.PublicKey, len(contribution.Commitments))
+	for i := range contribution.Commitments {
+		// Copy the key, as the BLS library cannot accept memory that is part of a larger structure.
+		if err := keys[i].Deserialize(append([]byte{}, contribution.Commitments[i][:]...)); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid commitment %d", i))
+		}
+	}
+
+	return keys, nil
+}
+
+// proofMessage is the message signed by a participant to prove knowledge of its secret.
+// It binds the proof to both the ceremony and the participant, so it cannot be replayed.
+func proofMessage(ceremony *Ceremony, participant uint64) []byte {
+	data := make([]byte, 24)
+	copy(data, ceremony.ID[:])
+	binary.LittleEndian.PutUint64(data[16:], participant)
+	hash := sha256.Sum256(data)
+
+	return hash[:]
+}
@@ -0,0 +1,373 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg_test
+
+import (
+	"crypto/ecdh"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/dkg"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+func TestNewCeremony(t *testing.T) {
+	withdrawalCredentials := testutil.HexToBytes("0x0100000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15")
+
+	tests := []struct {
+		name                  string
+		participants          uint64
+		threshold             uint64
+		withdrawalCredentials []byte
+		amount                phase0.Gwei
+		err                   string
+	}{
+		{
+			name:                  "SingleParticipant",
+			participants:          1,
+			threshold:             1,
+			withdrawalCredentials: withdrawalCredentials,
+			amount:                32000000000,
+			err:                   "ceremony requires at least two participants",
+		},
+		{
+			name:                  "ThresholdZero",
+			participants:          3,
+			withdrawalCredentials: withdrawalCredentials,
+			amount:                32000000000,
+			err:                   "signing threshold must be at least one",
+		},
+		{
+			name:                  "ThresholdLow",
+			participants:          4,
+			threshold:             2,
+			withdrawalCredentials: withdrawalCredentials,
+			amount:                32000000000,
+			err:                   "signing threshold must be more than half the number of participants",
+		},
+		{
+			name:                  "ThresholdHigh",
+			participants:          3,
+			threshold:             4,
+			withdrawalCredentials: withdrawalCredentials,
+			amount:                32000000000,
+			err:                   "signing threshold cannot be higher than the number of participants",
+		},
+		{
+			name:         "WithdrawalCredentialsMissing",
+			participants: 3,
+			threshold:    2,
+			amount:       32000000000,
+			err:          "withdrawal credentials must be 32 bytes",
+		},
+		{
+			name:                  "AmountMissing",
+			participants:          3,
+			threshold:             2,
+			withdrawalCredentials: withdrawalCredentials,
+			err:                   "deposit amount must be supplied",
+		},
+		{
+			name:                  "Good",
+			participants:          3,
+			threshold:             2,
+			withdrawalCredentials: withdrawalCredentials,
+			amount:                32000000000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ceremony, err := dkg.NewCeremony(test.participants, test.threshold, test.withdrawalCredentials, test.amount, phase0.Version{})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			// Ensure the ceremony survives a round trip.
+			data, err := json.Marshal(ceremony)
+			require.NoError(t, err)
+			res := &dkg.Ceremony{}
+			require.NoError(t, json.Unmarshal(data, res))
+			require.Equal(t, ceremony, res)
+		})
+	}
+}
+
+// runCeremony runs the contribution phase of a ceremony, returning the contributions and the shares
+// indexed by recipient.
+func runCeremony(t *testing.T, ceremony *dkg.Ceremony) ([]*dkg.Contribution, map[uint64][]*dkg.Share) {
+	t.Helper()
+
+	contributions := make([]*dkg.Contribution, 0, ceremony.Participants)
+	shares := make(map[uint64][]*dkg.Share)
+	for i := uint64(1); i <= ceremony.Participants; i++ {
+		contribution, participantShares, err := dkg.Contribute(ceremony, i)
+		require.NoError(t, err)
+		require.Len(t, participantShares, int(ceremony.Participants))
+
+		// Pass everything through JSON, as would happen when transferring files.
+		data, err := json.Marshal(contribution)
+		require.NoError(t, err)
+		contribution = &dkg.Contribution{}
+		require.NoError(t, json.Unmarshal(data, contribution))
+		contributions = append(contributions, contribution)
+
+		// Shares are encrypted when transferred; see TestEncryptedShare.
+		for _, share := range participantShares {
+			shares[share.To] = append(shares[share.To], share)
+		}
+	}
+
+	return contributions, shares
+}
+
+func TestCeremony(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	withdrawalCredentials := testutil.HexToBytes("0x0100000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15")
+	ceremony, err := dkg.NewCeremony(5, 3, withdrawalCredentials, 32000000000, phase0.Version{})
+	require.NoError(t, err)
+
+	contributions, shares := runCeremony(t, ceremony)
+
+	results := make([]*dkg.Result, 0, ceremony.Participants)
+	signatures := make([]*dkg.DepositSignature, 0, ceremony.Participants)
+	for i := uint64(1); i <= ceremony.Participants; i++ {
+		result, err := dkg.Finalize(ceremony, i, contributions, shares[i])
+		require.NoError(t, err)
+		results = append(results, result)
+
+		signature, err := result.SignDeposit(ceremony)
+		require.NoError(t, err)
+		signatures = append(signatures, signature)
+	}
+
+	// All participants must agree on the composite and participant public keys.
+	for _, result := range results[1:] {
+		require.Equal(t, results[0].PublicKey, result.PublicKey)
+		require.Equal(t, results[0].ParticipantPublicKeys, result.ParticipantPublicKeys)
+	}
+	for _, result := range results {
+		participantKey := result.ParticipantPublicKeys[result.Participant]
+		require.Equal(t, participantKey[:], result.Key.PublicKey().Marshal())
+	}
+
+	// Insufficient signatures.
+	_, err = results[0].CombineDepositSignatures(ceremony, signatures[:2])
+	require.EqualError(t, err, "2 deposit signatures available; 3 required")
+
+	// Any threshold of signatures results in the same deposit.
+	deposit1, err := results[0].CombineDepositSignatures(ceremony, signatures[:3])
+	require.NoError(t, err)
+	deposit2, err := results[4].CombineDepositSignatures(ceremony, signatures[2:])
+	require.NoError(t, err)
+	require.Equal(t, deposit1, deposit2)
+	require.Equal(t, results[0].PublicKey, deposit1.PublicKey)
+	require.Equal(t, ceremony.Amount, deposit1.Amount)
+
+	// A bad signature is rejected.
+	badSignature := *signatures[1]
+	badSignature.Signature = signatures[2].Signature
+	_, err = results[0].CombineDepositSignatures(ceremony, []*dkg.DepositSignature{signatures[0], &badSignature, signatures[2]})
+	require.EqualError(t, err, "deposit signature from participant 2: signature does not verify")
+}
+
+func TestFinalizeErrors(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	withdrawalCredentials := testutil.HexToBytes("0x0100000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15")
+	ceremony, err := dkg.NewCeremony(3, 2, withdrawalCredentials, 32000000000, phase0.Version{})
+	require.NoError(t, err)
+	contributions, shares := runCeremony(t, ceremony)
+
+	otherCeremony, err := dkg.NewCeremony(3, 2, withdrawalCredentials, 32000000000, phase0.Version{})
+	require.NoError(t, err)
+	otherContributions, otherShares := runCeremony(t, otherCeremony)
+
+	tamperedContribution := *contributions[1]
+	tamperedContribution.Commitments = append([]phase0.BLSPubKey{}, contributions[1].Commitments...)
+	tamperedContribution.Commitments[1] = otherContributions[1].Commitments[1]
+
+	stolenContribution := *otherContributions[1]
+	stolenContribution.CeremonyID = ceremony.ID
+
+	tamperedShare := *shares[1][1]
+	tamperedShare.Secret = shares[1][2].Secret
+
+	tests := []struct {
+		name          string
+		participant   uint64
+		contributions []*dkg.Contribution
+		shares        []*dkg.Share
+		err           string
+	}{
+		{
+			name:          "ParticipantInvalid",
+			participant:   4,
+			contributions: contributions,
+			shares:        shares[1],
+			err:           "participant 4 invalid; must be between 1 and 3",
+		},
+		{
+			name:          "ContributionMissing",
+			participant:   1,
+			contributions: contributions[:2],
+			shares:        shares[1],
+			err:           "no contribution from participant 3",
+		},
+		{
+			name:          "ContributionDuplicate",
+			participant:   1,
+			contributions: []*dkg.Contribution{contributions[0], contributions[1], contributions[1]},
+			shares:        shares[1],
+			err:           "duplicate contribution from participant 2",
+		},
+		{
+			name:          "ContributionOtherCeremony",
+			participant:   1,
+			contributions: []*dkg.Contribution{contributions[0], otherContributions[1], contributions[2]},
+			shares:        shares[1],
+			err:           "contribution from participant 2: contribution is for a different ceremony",
+		},
+		{
+			name:          "ContributionStolen",
+			participant:   1,
+			contributions: []*dkg.Contribution{contributions[0], &stolenContribution, contributions[2]},
+			shares:        shares[1],
+			err:           "contribution from participant 2: proof does not verify",
+		},
+		{
+			name:          "ShareMissing",
+			participant:   1,
+			contributions: contributions,
+			shares:        shares[1][:2],
+			err:           "no share from participant 3",
+		},
+		{
+			name:          "ShareWrongRecipient",
+			participant:   1,
+			contributions: contributions,
+			shares:        []*dkg.Share{shares[1][0], shares[2][1], shares[1][2]},
+			err:           "share from participant 2 is for participant 2",
+		},
+		{
+			name:          "ShareOtherCeremony",
+			participant:   1,
+			contributions: contributions,
+			shares:        []*dkg.Share{shares[1][0], otherShares[1][1], shares[1][2]},
+			err:           "share is for a different ceremony",
+		},
+		{
+			name:          "ShareTampered",
+			participant:   1,
+			contributions: contributions,
+			shares:        []*dkg.Share{shares[1][0], &tamperedShare, shares[1][2]},
+			err:           "share from participant 2 does not match its commitments",
+		},
+		{
+			name:          "CommitmentTampered",
+			participant:   1,
+			contributions: []*dkg.Contribution{contributions[0], &tamperedContribution, contributions[2]},
+			shares:        shares[1],
+			err:           "share from participant 2 does not match its commitments",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := dkg.Finalize(ceremony, test.participant, test.contributions, test.shares)
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
+
+func TestEncryptedShare(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+
+	withdrawalCredentials := testutil.HexToBytes("0x0100000000000000000000008c1ff978036f2e9d7cc382eff7b4c8c53c22ac15")
+	ceremony, err := dkg.NewCeremony(3, 2, withdrawalCredentials, 32000000000, phase0.Version{})
+	require.NoError(t, err)
+	_, shares, err := dkg.Contribute(ceremony, 1)
+	require.NoError(t, err)
+	share := shares[1]
+
+	_, _, err = dkg.GenerateEncryptionKey(ceremony, 4, "secret 4")
+	require.EqualError(t, err, "participant 4 invalid; must be between 1 and 3")
+	_, _, err = dkg.GenerateEncryptionKey(ceremony, 2, "")
+	require.EqualError(t, err, "passphrase is required")
+
+	dir := t.TempDir()
+	encryptionKeys := make(map[uint64]*dkg.EncryptionKey)
+	for _, participant := range []uint64{2, 3} {
+		encryptionKey, encryptionSecret, err := dkg.GenerateEncryptionKey(ceremony, participant, fmt.Sprintf("secret %d", participant))
+		require.NoError(t, err)
+		require.NoError(t, dkg.WriteFile(filepath.Join(dir, dkg.EncryptionKeyFilename(participant)), encryptionKey))
+		require.NoError(t, dkg.WriteFile(filepath.Join(dir, dkg.EncryptionSecretFilename(participant)), encryptionSecret))
+		encryptionKeys[participant] = &dkg.EncryptionKey{}
+		require.NoError(t, dkg.ReadFile(filepath.Join(dir, dkg.EncryptionKeyFilename(participant)), encryptionKeys[participant]))
+	}
+	decryptionKey := func(participant uint64, passphrase string) (*ecdh.PrivateKey, error) {
+		encryptionSecret := &dkg.EncryptionSecret{}
+		require.NoError(t, dkg.ReadFile(filepath.Join(dir, dkg.EncryptionSecretFilename(participant)), encryptionSecret))
+
+		return encryptionSecret.Decrypt(passphrase)
+	}
+
+	_, err = share.Encrypt(encryptionKeys[3])
+	require.EqualError(t, err, "encryption key for participant 3 cannot be used for share to participant 2")
+
+	encryptedShare, err := share.Encrypt(encryptionKeys[2])
+	require.NoError(t, err)
+	path := filepath.Join(dir, dkg.ShareFilename(share.From, share.To))
+	require.NoError(t, dkg.WriteFile(path, encryptedShare))
+
+	// The share file alone does not reveal the secret.
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, strings.ToLower(string(data)), fmt.Sprintf("%x", share.Secret))
+
+	readShare := &dkg.EncryptedShare{}
+	require.NoError(t, dkg.ReadFile(path, readShare))
+
+	// The encryption key cannot be obtained without its passphrase.
+	_, err = decryptionKey(2, "secret 3")
+	require.EqualError(t, err, "failed to decrypt encryption key: invalid checksum")
+
+	// Participant 3 cannot decrypt the share sent to participant 2.
+	key3, err := decryptionKey(3, "secret 3")
+	require.NoError(t, err)
+	_, err = readShare.Decrypt(key3)
+	require.EqualError(t, err, "failed to decrypt share from participant 1: cipher: message authentication failed")
+
+	// The share cannot be redirected to another recipient.
+	key2, err := decryptionKey(2, "secret 2")
+	require.NoError(t, err)
+	redirectedShare := *readShare
+	redirectedShare.To = 3
+	_, err = redirectedShare.Decrypt(key2)
+	require.EqualError(t, err, "failed to decrypt share from participant 1: cipher: message authentication failed")
+
+	decryptedShare, err := readShare.Decrypt(key2)
+	require.NoError(t, err)
+	require.Equal(t, share, decryptedShare)
+}
@@ -0,0 +1,284 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	"golang.org/x/crypto/hkdf"
+)
+
+// encryptionKeyLength is the length of an X25519 public key.
+const encryptionKeyLength = 32
+
+// shareKeyInfo is the HKDF info prefix for the key that encrypts a share.
+var shareKeyInfo = []byte("ethdo dkg share")
+
+// EncryptionKey is the public key of a participant, to which shares sent to that participant are encrypted.
+type EncryptionKey struct {
+	CeremonyID  uuid.UUID
+	Participant uint64
+	PublicKey   []byte
+}
+
+type encryptionKeyJSON struct {
+	CeremonyID  string `json:"ceremony_id"`
+	Participant string `json:"participant"`
+	PublicKey   string `json:"pubkey"`
+}
+
+// EncryptionSecret is the private key of a participant, encrypted with a passphrase known only to that participant.
+// The private key is held as an EIP-2335 crypto module.
+type EncryptionSecret struct {
+	CeremonyID  uuid.UUID
+	Participant uint64
+	Crypto      map[string]any
+}
+
+type encryptionSecretJSON struct {
+	CeremonyID  string         `json:"ceremony_id"`
+	Participant string         `json:"participant"`
+	Crypto      map[string]any `json:"crypto"`
+}
+
+// GenerateEncryptionKey generates an X25519 key pair for a participant in the ceremony, returning the
+// public key to be sent to all participants and the private key encrypted with the participant's passphrase.
+func GenerateEncryptionKey(ceremony *Ceremony, participant uint64, passphrase string) (*EncryptionKey, *EncryptionSecret, error) {
+	if err := ceremony.verifyParticipant(participant); err != nil {
+		return nil, nil, err
+	}
+	if passphrase == "" {
+		return nil, nil, errors.New("passphrase is required")
+	}
+
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate encryption key")
+	}
+	crypto, err := keystorev4.New().Encrypt(key.Bytes(), passphrase)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to encrypt encryption key")
+	}
+
+	return &EncryptionKey{
+		CeremonyID:  ceremony.ID,
+		Participant: participant,
+		PublicKey:   key.PublicKey().Bytes(),
+	}, &EncryptionSecret{
+		CeremonyID:  ceremony.ID,
+		Participant: participant,
+		Crypto:      crypto,
+	}, nil
+}
+
+// Decrypt decrypts the private key with the participant's passphrase.
+func (s *EncryptionSecret) Decrypt(passphrase string) (*ecdh.PrivateKey, error) {
+	secret, err := keystorev4.New().Decrypt(s.Crypto, passphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt encryption key")
+	}
+	key, err := ecdh.X25519().NewPrivateKey(secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "encryption key invalid")
+	}
+
+	return key, nil
+}
+
+// Encrypt encrypts the share to the recipient's encryption key, using X25519 with an ephemeral key and AES-GCM.
+func (s *Share) Encrypt(recipient *EncryptionKey) (*EncryptedShare, error) {
+	if recipient.CeremonyID != s.CeremonyID {
+		return nil, fmt.Errorf("encryption key for participant %d is for a different ceremony", recipient.Participant)
+	}
+	if recipient.Participant != s.To {
+		return nil, fmt.Errorf("encryption key for participant %d cannot be used for share to participant %d", recipient.Participant, s.To)
+	}
+	recipientKey, err := ecdh.X25519().NewPublicKey(recipient.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("encryption key for participant %d invalid", recipient.Participant))
+	}
+
+	ephemeralKey, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate ephemeral key")
+	}
+	sharedSecret, err := ephemeralKey.ECDH(recipientKey)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to agree key with participant %d", s.To))
+	}
+	aead, err := shareCipher(sharedSecret, ephemeralKey.PublicKey().Bytes(), recipientKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+
+	return &EncryptedShare{
+		CeremonyID:   s.CeremonyID,
+		From:         s.From,
+		To:           s.To,
+		EphemeralKey: ephemeralKey.PublicKey().Bytes(),
+		Nonce:        nonce,
+		Ciphertext:   aead.Seal(nil, nonce, s.Secret, shareAssociatedData(s.CeremonyID, s.From, s.To)),
+	}, nil
+}
+
+// Decrypt decrypts the share with the recipient's private encryption key.
+func (e *EncryptedShare) Decrypt(key *ecdh.PrivateKey) (*Share, error) {
+	ephemeralKey, err := ecdh.X25519().NewPublicKey(e.EphemeralKey)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("ephemeral key for share from participant %d invalid", e.From))
+	}
+	sharedSecret, err := key.ECDH(ephemeralKey)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to agree key with participant %d", e.From))
+	}
+	aead, err := shareCipher(sharedSecret, e.EphemeralKey, key.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	if len(e.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("share from participant %d has incorrect nonce length", e.From)
+	}
+	secret, err := aead.Open(nil, e.Nonce, e.Ciphertext, shareAssociatedData(e.CeremonyID, e.From, e.To))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to decrypt share from participant %d", e.From))
+	}
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("share from participant %d has incorrect length", e.From)
+	}
+
+	return &Share{
+		CeremonyID: e.CeremonyID,
+		From:       e.From,
+		To:         e.To,
+		Secret:     secret,
+	}, nil
+}
+
+// shareCipher derives the cipher for a share from the shared secret and the keys that generated it.
+func shareCipher(sharedSecret []byte, ephemeralKey []byte, recipientKey []byte) (cipher.AEAD, error) {
+	info := make([]byte, 0, len(shareKeyInfo)+len(ephemeralKey)+len(recipientKey))
+	info = append(info, shareKeyInfo...)
+	info = append(info, ephemeralKey...)
+	info = append(info, recipientKey...)
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedSecret, nil, info), key); err != nil {
+		return nil, errors.Wrap(err, "failed to derive share key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create share cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create share cipher")
+	}
+
+	return aead, nil
+}
+
+// shareAssociatedData binds the ciphertext of a share to its ceremony, sender and recipient.
+func shareAssociatedData(ceremonyID uuid.UUID, from uint64, to uint64) []byte {
+	data := make([]byte, 0, len(ceremonyID)+16)
+	data = append(data, ceremonyID[:]...)
+	data = binary.BigEndian.AppendUint64(data, from)
+	data = binary.BigEndian.AppendUint64(data, to)
+
+	return data
+}
+
+// MarshalJSON implements json.Marshaler.
+func (k *EncryptionKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&encryptionKeyJSON{
+		CeremonyID:  k.CeremonyID.String(),
+		Participant: strconv.FormatUint(k.Participant, 10),
+		PublicKey:   fmt.Sprintf("%#x", k.PublicKey),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (k *EncryptionKey) UnmarshalJSON(input []byte) error {
+	var data encryptionKeyJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	var err error
+	k.CeremonyID, err = parseID(data.CeremonyID)
+	if err != nil {
+		return err
+	}
+
+	k.Participant, err = parseUint(data.Participant, "participant")
+	if err != nil {
+		return err
+	}
+
+	k.PublicKey, err = parseHex(data.PublicKey, "public key", encryptionKeyLength)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *EncryptionSecret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&encryptionSecretJSON{
+		CeremonyID:  s.CeremonyID.String(),
+		Participant: strconv.FormatUint(s.Participant, 10),
+		Crypto:      s.Crypto,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *EncryptionSecret) UnmarshalJSON(input []byte) error {
+	var data encryptionSecretJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	var err error
+	s.CeremonyID, err = parseID(data.CeremonyID)
+	if err != nil {
+		return err
+	}
+
+	s.Participant, err = parseUint(data.Participant, "participant")
+	if err != nil {
+		return err
+	}
+
+	if len(data.Crypto) == 0 {
+		return errors.New("crypto missing")
+	}
+	s.Crypto = data.Crypto
+
+	return nil
+}
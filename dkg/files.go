@@ -0,0 +1,73 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// EncryptionKeyFilename is the name of the file holding a participant's public encryption key.
+func EncryptionKeyFilename(participant uint64) string {
+	return fmt.Sprintf("dkg-encryption-key-%d.json", participant)
+}
+
+// EncryptionSecretFilename is the name of the file holding a participant's encrypted private encryption key.
+func EncryptionSecretFilename(participant uint64) string {
+	return fmt.Sprintf("dkg-encryption-secret-%d.json", participant)
+}
+
+// ContributionFilename is the name of the file holding a participant's public contribution.
+func ContributionFilename(participant uint64) string {
+	return fmt.Sprintf("dkg-contribution-%d.json", participant)
+}
+
+// ShareFilename is the name of the file holding the secret share from one participant to another.
+func ShareFilename(from uint64, to uint64) string {
+	return fmt.Sprintf("dkg-share-%d-to-%d.json", from, to)
+}
+
+// DepositSignatureFilename is the name of the file holding a participant's deposit signature.
+func DepositSignatureFilename(participant uint64) string {
+	return fmt.Sprintf("dkg-deposit-signature-%d.json", participant)
+}
+
+// ReadFile reads and unmarshals a ceremony file.
+func ReadFile(path string, obj json.Unmarshaler) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to read %s", path))
+	}
+	if err := obj.UnmarshalJSON(data); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("%s invalid", path))
+	}
+
+	return nil
+}
+
+// WriteFile marshals and writes a ceremony file.
+func WriteFile(path string, obj json.Marshaler) error {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to generate JSON for %s", path))
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to write %s", path))
+	}
+
+	return nil
+}
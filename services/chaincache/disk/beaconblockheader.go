@@ -0,0 +1,77 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/pkg/errors"
+)
+
+const beaconBlockHeadersKind = "headers"
+
+// BeaconBlockHeader provides the block header of a given block ID.
+func (s *Service) BeaconBlockHeader(ctx context.Context,
+	opts *api.BeaconBlockHeaderOpts,
+) (
+	*api.Response[*apiv1.BeaconBlockHeader],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	key, cacheable := s.cacheKey(opts.Block)
+	if !cacheable {
+		return s.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, opts)
+	}
+
+	data, found, err := s.read(beaconBlockHeadersKind, key)
+	if err != nil {
+		log.Debug().Err(err).Str("block", opts.Block).Msg("Failed to read cached header")
+	}
+	if found {
+		header := &apiv1.BeaconBlockHeader{}
+		err := json.Unmarshal(data, header)
+		if err == nil {
+			return &api.Response[*apiv1.BeaconBlockHeader]{
+				Data:     header,
+				Metadata: make(map[string]any),
+			}, nil
+		}
+		log.Debug().Err(err).Str("block", opts.Block).Msg("Failed to decode cached header")
+	}
+
+	response, err := s.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Data.Header.Message.Slot > s.finalizedSlot {
+		// Headers contain their canonical status, which can change until finalized.
+		return response, nil
+	}
+
+	data, err = json.Marshal(response.Data)
+	if err != nil {
+		log.Debug().Err(err).Str("block", opts.Block).Msg("Failed to encode header")
+	} else if err := s.write(beaconBlockHeadersKind, key, data); err != nil {
+		log.Debug().Err(err).Str("block", opts.Block).Msg("Failed to cache header")
+	}
+
+	return response, nil
+}
@@ -0,0 +1,76 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/pkg/errors"
+)
+
+const beaconCommitteesKind = "committees"
+
+// BeaconCommittees fetches all beacon committees for the given options.
+func (s *Service) BeaconCommittees(ctx context.Context,
+	opts *api.BeaconCommitteesOpts,
+) (
+	*api.Response[[]*apiv1.BeaconCommittee],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	key, cacheable := s.cacheKey(opts.State)
+	if !cacheable {
+		return s.beaconCommitteesProvider.BeaconCommittees(ctx, opts)
+	}
+	if opts.Epoch != nil {
+		key = fmt.Sprintf("%s-%d", key, *opts.Epoch)
+	}
+
+	data, found, err := s.read(beaconCommitteesKind, key)
+	if err != nil {
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to read cached committees")
+	}
+	if found {
+		committees := make([]*apiv1.BeaconCommittee, 0)
+		err := json.Unmarshal(data, &committees)
+		if err == nil {
+			return &api.Response[[]*apiv1.BeaconCommittee]{
+				Data:     committees,
+				Metadata: make(map[string]any),
+			}, nil
+		}
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to decode cached committees")
+	}
+
+	response, err := s.beaconCommitteesProvider.BeaconCommittees(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = json.Marshal(response.Data)
+	if err != nil {
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to encode committees")
+	} else if err := s.write(beaconCommitteesKind, key, data); err != nil {
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to cache committees")
+	}
+
+	return response, nil
+}
@@ -0,0 +1,136 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+const beaconStatesKind = "states"
+
+// BeaconState fetches a beacon state given a state ID.
+func (s *Service) BeaconState(ctx context.Context,
+	opts *api.BeaconStateOpts,
+) (
+	*api.Response[*spec.VersionedBeaconState],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	key, cacheable := s.cacheKey(opts.State)
+	if !cacheable {
+		return s.beaconStateProvider.BeaconState(ctx, opts)
+	}
+
+	data, found, err := s.read(beaconStatesKind, key)
+	if err != nil {
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to read cached state")
+	}
+	if found && len(data) > 0 {
+		state, err := decodeBeaconState(data)
+		if err == nil {
+			return &api.Response[*spec.VersionedBeaconState]{
+				Data:     state,
+				Metadata: make(map[string]any),
+			}, nil
+		}
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to decode cached state")
+	}
+
+	response, err := s.beaconStateProvider.BeaconState(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = encodeBeaconState(response.Data)
+	if err != nil {
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to encode state")
+	} else if err := s.write(beaconStatesKind, key, data); err != nil {
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to cache state")
+	}
+
+	return response, nil
+}
+
+// encodeBeaconState encodes a state as its version followed by its SSZ representation.
+func encodeBeaconState(state *spec.VersionedBeaconState) ([]byte, error) {
+	var obj sszObject
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		obj = state.Phase0
+	case spec.DataVersionAltair:
+		obj = state.Altair
+	case spec.DataVersionBellatrix:
+		obj = state.Bellatrix
+	case spec.DataVersionCapella:
+		obj = state.Capella
+	case spec.DataVersionDeneb:
+		obj = state.Deneb
+	case spec.DataVersionElectra:
+		obj = state.Electra
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+
+	return encodeVersioned(state.Version, obj)
+}
+
+// decodeBeaconState decodes a state encoded with encodeBeaconState.
+func decodeBeaconState(data []byte) (*spec.VersionedBeaconState, error) {
+	state := &spec.VersionedBeaconState{
+		Version: spec.DataVersion(data[0]),
+	}
+	var obj sszObject
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		state.Phase0 = &phase0.BeaconState{}
+		obj = state.Phase0
+	case spec.DataVersionAltair:
+		state.Altair = &altair.BeaconState{}
+		obj = state.Altair
+	case spec.DataVersionBellatrix:
+		state.Bellatrix = &bellatrix.BeaconState{}
+		obj = state.Bellatrix
+	case spec.DataVersionCapella:
+		state.Capella = &capella.BeaconState{}
+		obj = state.Capella
+	case spec.DataVersionDeneb:
+		state.Deneb = &deneb.BeaconState{}
+		obj = state.Deneb
+	case spec.DataVersionElectra:
+		state.Electra = &electra.BeaconState{}
+		obj = state.Electra
+	default:
+		return nil, fmt.Errorf("unhandled state version %v", state.Version)
+	}
+
+	if err := obj.UnmarshalSSZ(data[1:]); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal state")
+	}
+
+	return state, nil
+}
@@ -0,0 +1,131 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	zerologger "github.com/rs/zerolog/log"
+)
+
+// Service provides historical chain data, caching finalized data on disk.
+type Service struct {
+	baseDir                    string
+	finalizedSlot              phase0.Slot
+	signedBeaconBlockProvider  eth2client.SignedBeaconBlockProvider
+	beaconBlockHeadersProvider eth2client.BeaconBlockHeadersProvider
+	beaconCommitteesProvider   eth2client.BeaconCommitteesProvider
+	beaconStateProvider        eth2client.BeaconStateProvider
+	validatorsProvider         eth2client.ValidatorsProvider
+}
+
+// module-wide log.
+var log zerolog.Logger
+
+// New creates a new disk cache.
+func New(ctx context.Context, params ...Parameter) (*Service, error) {
+	parameters, err := parseAndCheckParameters(params...)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "problem with parameters")
+	}
+
+	// Set logging.
+	log = zerologger.With().Str("service", "chaincache").Str("impl", "disk").Logger().Level(parameters.logLevel)
+
+	s := &Service{}
+
+	genesisProvider, isProvider := parameters.client.(eth2client.GenesisProvider)
+	if !isProvider {
+		return nil, errors.New("client does not provide genesis")
+	}
+	s.signedBeaconBlockProvider, isProvider = parameters.client.(eth2client.SignedBeaconBlockProvider)
+	if !isProvider {
+		return nil, errors.New("client does not provide signed beacon blocks")
+	}
+	s.beaconBlockHeadersProvider, isProvider = parameters.client.(eth2client.BeaconBlockHeadersProvider)
+	if !isProvider {
+		return nil, errors.New("client does not provide beacon block headers")
+	}
+	s.beaconCommitteesProvider, isProvider = parameters.client.(eth2client.BeaconCommitteesProvider)
+	if !isProvider {
+		return nil, errors.New("client does not provide beacon committees")
+	}
+	s.beaconStateProvider, isProvider = parameters.client.(eth2client.BeaconStateProvider)
+	if !isProvider {
+		return nil, errors.New("client does not provide beacon states")
+	}
+	s.validatorsProvider, isProvider = parameters.client.(eth2client.ValidatorsProvider)
+	if !isProvider {
+		return nil, errors.New("client does not provide validators")
+	}
+
+	// Data is cached per-network, as block and state IDs are not unique across networks.
+	genesisResponse, err := genesisProvider.Genesis(ctx, &api.GenesisOpts{})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to obtain genesis")
+	}
+	s.baseDir = filepath.Join(parameters.baseDir, fmt.Sprintf("%#x", genesisResponse.Data.GenesisValidatorsRoot))
+	if err := os.MkdirAll(s.baseDir, 0o700); err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to create cache directory")
+	}
+
+	// Only data at or before the finalized slot is cached, as anything later could change.
+	headerResponse, err := s.beaconBlockHeadersProvider.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{
+		Block: "finalized",
+	})
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to obtain finalized block header")
+	}
+	s.finalizedSlot = headerResponse.Data.Header.Message.Slot
+	log.Trace().Uint64("finalized_slot", uint64(s.finalizedSlot)).Msg("Obtained finalized slot")
+
+	return s, nil
+}
+
+// cacheKey returns the key under which data for the given block or state ID is cached.
+// Roots are always cacheable as the data to which they refer cannot change, whereas
+// slots are only cacheable if they are finalized.
+func (s *Service) cacheKey(id string) (string, bool) {
+	if strings.HasPrefix(id, "0x") {
+		root, err := hex.DecodeString(strings.TrimPrefix(id, "0x"))
+		if err != nil || len(root) != phase0.RootLength {
+			return "", false
+		}
+
+		return hex.EncodeToString(root), true
+	}
+
+	slot, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		// A special value such as "head", which is not cacheable.
+		return "", false
+	}
+	if phase0.Slot(slot) > s.finalizedSlot {
+		return "", false
+	}
+
+	return strconv.FormatUint(slot, 10), true
+}
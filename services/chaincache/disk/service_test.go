@@ -0,0 +1,379 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/holiman/uint256"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/services/chaincache"
+	"github.com/wealdtech/ethdo/services/chaincache/disk"
+)
+
+// electraSlot is the first slot for which the counting client returns Electra blocks.
+const electraSlot = phase0.Slot(70)
+
+// countingClient is a mock client that counts the requests made to it.
+type countingClient struct {
+	genesisValidatorsRoot phase0.Root
+	finalizedSlot         phase0.Slot
+	requests              map[string]int
+}
+
+func newCountingClient(finalizedSlot phase0.Slot) *countingClient {
+	return &countingClient{
+		genesisValidatorsRoot: phase0.Root{0x01},
+		finalizedSlot:         finalizedSlot,
+		requests:              make(map[string]int),
+	}
+}
+
+func (c *countingClient) Name() string    { return "counting mock" }
+func (c *countingClient) Address() string { return "mock" }
+func (c *countingClient) IsActive() bool  { return true }
+func (c *countingClient) IsSynced() bool  { return true }
+
+func (c *countingClient) Genesis(_ context.Context, _ *api.GenesisOpts) (*api.Response[*apiv1.Genesis], error) {
+	return &api.Response[*apiv1.Genesis]{
+		Data: &apiv1.Genesis{
+			GenesisValidatorsRoot: c.genesisValidatorsRoot,
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func (c *countingClient) slot(id string) (phase0.Slot, error) {
+	if id == "finalized" {
+		return c.finalizedSlot, nil
+	}
+	if id == "head" || strings.HasPrefix(id, "0x") {
+		return c.finalizedSlot + 10, nil
+	}
+	slot, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if slot%10 == 5 {
+		// Empty slot.
+		return 0, &api.Error{Method: http.MethodGet, StatusCode: http.StatusNotFound}
+	}
+
+	return phase0.Slot(slot), nil
+}
+
+func (c *countingClient) SignedBeaconBlock(_ context.Context, opts *api.SignedBeaconBlockOpts) (*api.Response[*spec.VersionedSignedBeaconBlock], error) {
+	c.requests[fmt.Sprintf("block %s", opts.Block)]++
+	slot, err := c.slot(opts.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	if slot >= electraSlot {
+		return &api.Response[*spec.VersionedSignedBeaconBlock]{
+			Data: &spec.VersionedSignedBeaconBlock{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.SignedBeaconBlock{
+					Message: &electra.BeaconBlock{
+						Slot: slot,
+						Body: &electra.BeaconBlockBody{
+							ETH1Data: &phase0.ETH1Data{
+								BlockHash: make([]byte, 32),
+							},
+							SyncAggregate: &altair.SyncAggregate{
+								SyncCommitteeBits: bitfield.NewBitvector512(),
+							},
+							ExecutionPayload: &deneb.ExecutionPayload{
+								BaseFeePerGas: uint256.NewInt(0),
+							},
+							ExecutionRequests: &electra.ExecutionRequests{},
+						},
+					},
+				},
+			},
+			Metadata: make(map[string]any),
+		}, nil
+	}
+
+	return &api.Response[*spec.VersionedSignedBeaconBlock]{
+		Data: &spec.VersionedSignedBeaconBlock{
+			Version: spec.DataVersionPhase0,
+			Phase0: &phase0.SignedBeaconBlock{
+				Message: &phase0.BeaconBlock{
+					Slot: slot,
+					Body: &phase0.BeaconBlockBody{
+						ETH1Data: &phase0.ETH1Data{
+							BlockHash: make([]byte, 32),
+						},
+					},
+				},
+			},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func (c *countingClient) BeaconBlockHeader(_ context.Context, opts *api.BeaconBlockHeaderOpts) (*api.Response[*apiv1.BeaconBlockHeader], error) {
+	c.requests[fmt.Sprintf("header %s", opts.Block)]++
+	slot, err := c.slot(opts.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.Response[*apiv1.BeaconBlockHeader]{
+		Data: &apiv1.BeaconBlockHeader{
+			Header: &phase0.SignedBeaconBlockHeader{
+				Message: &phase0.BeaconBlockHeader{
+					Slot: slot,
+				},
+			},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func (c *countingClient) BeaconCommittees(_ context.Context, opts *api.BeaconCommitteesOpts) (*api.Response[[]*apiv1.BeaconCommittee], error) {
+	c.requests[fmt.Sprintf("committees %s", opts.State)]++
+
+	return &api.Response[[]*apiv1.BeaconCommittee]{
+		Data: []*apiv1.BeaconCommittee{
+			{
+				Slot:       1,
+				Index:      2,
+				Validators: []phase0.ValidatorIndex{3, 4},
+			},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func (c *countingClient) BeaconState(_ context.Context, opts *api.BeaconStateOpts) (*api.Response[*spec.VersionedBeaconState], error) {
+	c.requests[fmt.Sprintf("state %s", opts.State)]++
+
+	return nil, &api.Error{Method: http.MethodGet, StatusCode: http.StatusNotFound}
+}
+
+func (c *countingClient) Validators(_ context.Context, opts *api.ValidatorsOpts) (*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator], error) {
+	c.requests[fmt.Sprintf("validators %s %d", opts.State, len(opts.Indices))]++
+
+	return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+		Data: map[phase0.ValidatorIndex]*apiv1.Validator{
+			1: {
+				Index:   1,
+				Balance: 32000000000,
+				Status:  apiv1.ValidatorStateActiveOngoing,
+				Validator: &phase0.Validator{
+					WithdrawalCredentials: make([]byte, 32),
+					EffectiveBalance:      32000000000,
+				},
+			},
+		},
+		Metadata: make(map[string]any),
+	}, nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name   string
+		params []disk.Parameter
+		err    string
+	}{
+		{
+			name: "BaseDirMissing",
+			params: []disk.Parameter{
+				disk.WithLogLevel(zerolog.Disabled),
+				disk.WithClient(newCountingClient(100)),
+			},
+			err: "problem with parameters: no base directory specified",
+		},
+		{
+			name: "ClientMissing",
+			params: []disk.Parameter{
+				disk.WithLogLevel(zerolog.Disabled),
+				disk.WithBaseDir(t.TempDir()),
+			},
+			err: "problem with parameters: no client specified",
+		},
+		{
+			name: "Good",
+			params: []disk.Parameter{
+				disk.WithLogLevel(zerolog.Disabled),
+				disk.WithBaseDir(t.TempDir()),
+				disk.WithClient(newCountingClient(100)),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := disk.New(context.Background(), test.params...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCaching(t *testing.T) {
+	ctx := context.Background()
+	baseDir := t.TempDir()
+	client := newCountingClient(100)
+
+	var service chaincache.Service
+	service, err := disk.New(ctx,
+		disk.WithLogLevel(zerolog.Disabled),
+		disk.WithBaseDir(baseDir),
+		disk.WithClient(client),
+	)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		// Finalized block is cached.
+		blockResponse, err := service.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "50"})
+		require.NoError(t, err)
+		slot, err := blockResponse.Data.Slot()
+		require.NoError(t, err)
+		require.Equal(t, phase0.Slot(50), slot)
+
+		// Finalized Electra block is cached.
+		blockResponse, err = service.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "80"})
+		require.NoError(t, err)
+		require.Equal(t, spec.DataVersionElectra, blockResponse.Data.Version)
+		slot, err = blockResponse.Data.Slot()
+		require.NoError(t, err)
+		require.Equal(t, phase0.Slot(80), slot)
+
+		// Missing blocks are not cached, as the beacon node may not have the data.
+		_, err = service.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "55"})
+		var apiErr *api.Error
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+		// Unfinalized and head blocks are not cached.
+		_, err = service.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "101"})
+		require.NoError(t, err)
+		_, err = service.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "head"})
+		require.NoError(t, err)
+
+		// Finalized header is cached.
+		headerResponse, err := service.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: "60"})
+		require.NoError(t, err)
+		require.Equal(t, phase0.Slot(60), headerResponse.Data.Header.Message.Slot)
+
+		// Unfinalized header is not cached, even by root.
+		_, err = service.BeaconBlockHeader(ctx, &api.BeaconBlockHeaderOpts{Block: "0x0000000000000000000000000000000000000000000000000000000000000000"})
+		require.NoError(t, err)
+
+		// Committees are cached.
+		committeesResponse, err := service.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{State: "64"})
+		require.NoError(t, err)
+		require.Len(t, committeesResponse.Data, 1)
+		require.Equal(t, []phase0.ValidatorIndex{3, 4}, committeesResponse.Data[0].Validators)
+
+		// Validators are cached, separately for different filters.
+		validatorsResponse, err := service.Validators(ctx, &api.ValidatorsOpts{State: "64"})
+		require.NoError(t, err)
+		require.Equal(t, phase0.Gwei(32000000000), validatorsResponse.Data[1].Balance)
+		_, err = service.Validators(ctx, &api.ValidatorsOpts{State: "64", Indices: []phase0.ValidatorIndex{1}})
+		require.NoError(t, err)
+
+		// Errors are not cached.
+		_, err = service.BeaconState(ctx, &api.BeaconStateOpts{State: "64"})
+		require.Error(t, err)
+	}
+
+	require.Equal(t, map[string]int{
+		"header finalized": 1,
+		"block 50":         1,
+		"block 80":         1,
+		"block 55":         2,
+		"block 101":        2,
+		"block head":       2,
+		"header 60":        1,
+		"header 0x0000000000000000000000000000000000000000000000000000000000000000": 2,
+		"committees 64":   1,
+		"validators 64 0": 1,
+		"validators 64 1": 1,
+		"state 64":        2,
+	}, client.requests)
+
+	// A new service with the same base directory uses the existing cache.
+	client = newCountingClient(100)
+	service, err = disk.New(ctx,
+		disk.WithLogLevel(zerolog.Disabled),
+		disk.WithBaseDir(baseDir),
+		disk.WithClient(client),
+	)
+	require.NoError(t, err)
+	_, err = service.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "50"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{
+		"header finalized": 1,
+	}, client.requests)
+}
+
+func TestNetworkSeparation(t *testing.T) {
+	ctx := context.Background()
+	baseDir := t.TempDir()
+
+	client1 := newCountingClient(100)
+	service1, err := disk.New(ctx,
+		disk.WithLogLevel(zerolog.Disabled),
+		disk.WithBaseDir(baseDir),
+		disk.WithClient(client1),
+	)
+	require.NoError(t, err)
+	_, err = service1.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "50"})
+	require.NoError(t, err)
+	_, err = service1.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "50"})
+	require.NoError(t, err)
+	require.Equal(t, 1, client1.requests["block 50"])
+
+	// A client for a different network sharing the base directory does not see the cached data.
+	client2 := newCountingClient(100)
+	client2.genesisValidatorsRoot = phase0.Root{0x02}
+	service2, err := disk.New(ctx,
+		disk.WithLogLevel(zerolog.Disabled),
+		disk.WithBaseDir(baseDir),
+		disk.WithClient(client2),
+	)
+	require.NoError(t, err)
+	_, err = service2.SignedBeaconBlock(ctx, &api.SignedBeaconBlockOpts{Block: "50"})
+	require.NoError(t, err)
+	require.Equal(t, 1, client2.requests["block 50"])
+
+	// Each network has its own directory.
+	entries, err := os.ReadDir(baseDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, fmt.Sprintf("%#x", phase0.Root{0x01}), entries[0].Name())
+	require.Equal(t, fmt.Sprintf("%#x", phase0.Root{0x02}), entries[1].Name())
+}
@@ -0,0 +1,136 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+const signedBeaconBlocksKind = "blocks"
+
+// SignedBeaconBlock fetches a signed beacon block given a block ID.
+func (s *Service) SignedBeaconBlock(ctx context.Context,
+	opts *api.SignedBeaconBlockOpts,
+) (
+	*api.Response[*spec.VersionedSignedBeaconBlock],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	key, cacheable := s.cacheKey(opts.Block)
+	if !cacheable {
+		return s.signedBeaconBlockProvider.SignedBeaconBlock(ctx, opts)
+	}
+
+	data, found, err := s.read(signedBeaconBlocksKind, key)
+	if err != nil {
+		log.Debug().Err(err).Str("block", opts.Block).Msg("Failed to read cached block")
+	}
+	if found {
+		block, err := decodeSignedBeaconBlock(data)
+		if err == nil {
+			return &api.Response[*spec.VersionedSignedBeaconBlock]{
+				Data:     block,
+				Metadata: make(map[string]any),
+			}, nil
+		}
+		log.Debug().Err(err).Str("block", opts.Block).Msg("Failed to decode cached block")
+	}
+
+	response, err := s.signedBeaconBlockProvider.SignedBeaconBlock(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = encodeSignedBeaconBlock(response.Data)
+	if err != nil {
+		log.Debug().Err(err).Str("block", opts.Block).Msg("Failed to encode block")
+	} else if err := s.write(signedBeaconBlocksKind, key, data); err != nil {
+		log.Debug().Err(err).Str("block", opts.Block).Msg("Failed to cache block")
+	}
+
+	return response, nil
+}
+
+// encodeSignedBeaconBlock encodes a block as its version followed by its SSZ representation.
+func encodeSignedBeaconBlock(block *spec.VersionedSignedBeaconBlock) ([]byte, error) {
+	var obj sszObject
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		obj = block.Phase0
+	case spec.DataVersionAltair:
+		obj = block.Altair
+	case spec.DataVersionBellatrix:
+		obj = block.Bellatrix
+	case spec.DataVersionCapella:
+		obj = block.Capella
+	case spec.DataVersionDeneb:
+		obj = block.Deneb
+	case spec.DataVersionElectra:
+		obj = block.Electra
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+
+	return encodeVersioned(block.Version, obj)
+}
+
+// decodeSignedBeaconBlock decodes a block encoded with encodeSignedBeaconBlock.
+func decodeSignedBeaconBlock(data []byte) (*spec.VersionedSignedBeaconBlock, error) {
+	block := &spec.VersionedSignedBeaconBlock{
+		Version: spec.DataVersion(data[0]),
+	}
+	var obj sszObject
+	switch block.Version {
+	case spec.DataVersionPhase0:
+		block.Phase0 = &phase0.SignedBeaconBlock{}
+		obj = block.Phase0
+	case spec.DataVersionAltair:
+		block.Altair = &altair.SignedBeaconBlock{}
+		obj = block.Altair
+	case spec.DataVersionBellatrix:
+		block.Bellatrix = &bellatrix.SignedBeaconBlock{}
+		obj = block.Bellatrix
+	case spec.DataVersionCapella:
+		block.Capella = &capella.SignedBeaconBlock{}
+		obj = block.Capella
+	case spec.DataVersionDeneb:
+		block.Deneb = &deneb.SignedBeaconBlock{}
+		obj = block.Deneb
+	case spec.DataVersionElectra:
+		block.Electra = &electra.SignedBeaconBlock{}
+		obj = block.Electra
+	default:
+		return nil, fmt.Errorf("unhandled block version %v", block.Version)
+	}
+
+	if err := obj.UnmarshalSSZ(data[1:]); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal block")
+	}
+
+	return block, nil
+}
@@ -0,0 +1,110 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/pkg/errors"
+)
+
+// read reads the data for the given kind and key from the cache.
+// The second return value is false if there is no cached data.
+func (s *Service) read(kind string, key string) ([]byte, bool, error) {
+	file, err := os.Open(s.path(kind, key))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+
+		return nil, false, errors.Wrap(err, "failed to open cache file")
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to decompress cache file")
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "failed to read cache file")
+	}
+
+	return data, true, nil
+}
+
+// write writes the data for the given kind and key to the cache.
+func (s *Service) write(kind string, key string, data []byte) error {
+	dir := filepath.Join(s.baseDir, kind)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return errors.Wrap(err, "failed to create cache directory")
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return errors.Wrap(err, "failed to compress data")
+	}
+	if err := writer.Close(); err != nil {
+		return errors.Wrap(err, "failed to compress data")
+	}
+
+	// Write to a temporary file and rename, to avoid partial files if interrupted.
+	tmpFile, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary cache file")
+	}
+	if _, err := tmpFile.Write(buf.Bytes()); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to write temporary cache file")
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to close temporary cache file")
+	}
+	if err := os.Rename(tmpFile.Name(), s.path(kind, key)); err != nil {
+		os.Remove(tmpFile.Name())
+		return errors.Wrap(err, "failed to rename temporary cache file")
+	}
+
+	return nil
+}
+
+func (s *Service) path(kind string, key string) string {
+	return filepath.Join(s.baseDir, kind, key+".gz")
+}
+
+// sszObject is an object that can be encoded and decoded with SSZ.
+type sszObject interface {
+	MarshalSSZ() ([]byte, error)
+	UnmarshalSSZ(buf []byte) error
+}
+
+// encodeVersioned encodes an SSZ object prefixed with its data version.
+func encodeVersioned(version spec.DataVersion, obj sszObject) ([]byte, error) {
+	data, err := obj.MarshalSSZ()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal SSZ")
+	}
+
+	return append([]byte{byte(version)}, data...), nil
+}
@@ -0,0 +1,105 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package disk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/attestantio/go-eth2-client/api"
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+const validatorsKind = "validators"
+
+// Validators provides the validators, with their balance and status, for the given options.
+func (s *Service) Validators(ctx context.Context,
+	opts *api.ValidatorsOpts,
+) (
+	*api.Response[map[phase0.ValidatorIndex]*apiv1.Validator],
+	error,
+) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	key, cacheable := s.cacheKey(opts.State)
+	if !cacheable {
+		return s.validatorsProvider.Validators(ctx, opts)
+	}
+	if filter := validatorsFilterKey(opts); filter != "" {
+		key = fmt.Sprintf("%s-%s", key, filter)
+	}
+
+	data, found, err := s.read(validatorsKind, key)
+	if err != nil {
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to read cached validators")
+	}
+	if found {
+		validators := make(map[phase0.ValidatorIndex]*apiv1.Validator)
+		err := json.Unmarshal(data, &validators)
+		if err == nil {
+			return &api.Response[map[phase0.ValidatorIndex]*apiv1.Validator]{
+				Data:     validators,
+				Metadata: make(map[string]any),
+			}, nil
+		}
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to decode cached validators")
+	}
+
+	response, err := s.validatorsProvider.Validators(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err = json.Marshal(response.Data)
+	if err != nil {
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to encode validators")
+	} else if err := s.write(validatorsKind, key, data); err != nil {
+		log.Debug().Err(err).Str("state", opts.State).Msg("Failed to cache validators")
+	}
+
+	return response, nil
+}
+
+// validatorsFilterKey provides a key that identifies the filters in the options,
+// or an empty string if there are no filters.
+func validatorsFilterKey(opts *api.ValidatorsOpts) string {
+	if len(opts.Indices) == 0 && len(opts.PubKeys) == 0 && len(opts.ValidatorStates) == 0 {
+		return ""
+	}
+
+	hash := sha256.New()
+	buf := make([]byte, 8)
+	for _, index := range opts.Indices {
+		binary.LittleEndian.PutUint64(buf, uint64(index))
+		hash.Write(buf)
+	}
+	hash.Write([]byte{0x00})
+	for _, pubKey := range opts.PubKeys {
+		hash.Write(pubKey[:])
+	}
+	hash.Write([]byte{0x00})
+	for _, state := range opts.ValidatorStates {
+		hash.Write([]byte(state.String()))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,28 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaincache
+
+import (
+	eth2client "github.com/attestantio/go-eth2-client"
+)
+
+// Service provides historical chain data, caching immutable data where possible.
+// It can be used in place of the equivalent providers of a beacon node client.
+type Service interface {
+	eth2client.SignedBeaconBlockProvider
+	eth2client.BeaconBlockHeadersProvider
+	eth2client.BeaconCommitteesProvider
+	eth2client.BeaconStateProvider
+	eth2client.ValidatorsProvider
+}
@@ -229,3 +229,12 @@ func TestTimestampToEpoch(t *testing.T) {
 		})
 	}
 }
+
+func TestElectraInitialEpoch(t *testing.T) {
+	genesisTime := time.Now()
+	s, _, _, _, _, err := createService(genesisTime)
+	require.NoError(t, err)
+
+	// Mock spec does not contain an Electra fork epoch, so should be far future.
+	require.Equal(t, phase0.Epoch(0xffffffffffffffff), s.ElectraInitialEpoch())
+}
@@ -0,0 +1,248 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	"github.com/wealdtech/ethdo/beacon"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// TypedObject is a beacon object that can be signed.
+type TypedObject interface {
+	HashTreeRoot() ([32]byte, error)
+}
+
+// SignedTypedObject is a typed object along with its signature.
+type SignedTypedObject struct {
+	Message   TypedObject
+	Signature phase0.BLSSignature
+}
+
+type signedTypedObjectJSON struct {
+	Message   TypedObject `json:"message"`
+	Signature string      `json:"signature"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *SignedTypedObject) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&signedTypedObjectJSON{
+		Message:   s.Message,
+		Signature: fmt.Sprintf("%#x", s.Signature),
+	})
+}
+
+// objectType defines how to create and obtain the signature domain for a type of object.
+type objectType struct {
+	create func() TypedObject
+	domain func(chainInfo *beacon.ChainInfo, obj TypedObject) (phase0.Domain, error)
+}
+
+var objectTypes = map[string]*objectType{
+	"aggregate_and_proof": {
+		create: func() TypedObject { return &phase0.AggregateAndProof{} },
+		domain: slotDomain(e2types.DomainAggregateAndProof, func(obj TypedObject) (phase0.Slot, error) {
+			aggregate, isAggregate := obj.(*phase0.AggregateAndProof)
+			if !isAggregate || aggregate.Aggregate == nil || aggregate.Aggregate.Data == nil {
+				return 0, errors.New("aggregate data missing")
+			}
+
+			return aggregate.Aggregate.Data.Slot, nil
+		}),
+	},
+	"attestation_data": {
+		create: func() TypedObject { return &phase0.AttestationData{} },
+		domain: func(chainInfo *beacon.ChainInfo, obj TypedObject) (phase0.Domain, error) {
+			data, isData := obj.(*phase0.AttestationData)
+			if !isData || data.Target == nil {
+				return phase0.Domain{}, errors.New("attestation target missing")
+			}
+
+			return chainInfo.DomainAtEpoch(phase0.DomainType(e2types.DomainBeaconAttester), data.Target.Epoch)
+		},
+	},
+	"block_header": {
+		create: func() TypedObject { return &phase0.BeaconBlockHeader{} },
+		domain: slotDomain(e2types.DomainBeaconProposer, func(obj TypedObject) (phase0.Slot, error) {
+			header, isHeader := obj.(*phase0.BeaconBlockHeader)
+			if !isHeader {
+				return 0, errors.New("block header missing")
+			}
+
+			return header.Slot, nil
+		}),
+	},
+	"bls_to_execution_change": {
+		create: func() TypedObject { return &capella.BLSToExecutionChange{} },
+		domain: func(chainInfo *beacon.ChainInfo, _ TypedObject) (phase0.Domain, error) {
+			return chainInfo.BLSToExecutionChangeDomain()
+		},
+	},
+	"contribution_and_proof": {
+		create: func() TypedObject { return &altair.ContributionAndProof{} },
+		domain: slotDomain(e2types.DomainContributionAndProof, func(obj TypedObject) (phase0.Slot, error) {
+			contribution, isContribution := obj.(*altair.ContributionAndProof)
+			if !isContribution || contribution.Contribution == nil {
+				return 0, errors.New("contribution missing")
+			}
+
+			return contribution.Contribution.Slot, nil
+		}),
+	},
+	"deposit_message": {
+		create: func() TypedObject { return &phase0.DepositMessage{} },
+		domain: func(chainInfo *beacon.ChainInfo, _ TypedObject) (phase0.Domain, error) {
+			return chainInfo.DepositDomain(), nil
+		},
+	},
+	"sync_aggregator_selection_data": {
+		create: func() TypedObject { return &altair.SyncAggregatorSelectionData{} },
+		domain: slotDomain(e2types.DomainSyncCommitteeSelectionProof, func(obj TypedObject) (phase0.Slot, error) {
+			selection, isSelection := obj.(*altair.SyncAggregatorSelectionData)
+			if !isSelection {
+				return 0, errors.New("selection data missing")
+			}
+
+			return selection.Slot, nil
+		}),
+	},
+	"voluntary_exit": {
+		create: func() TypedObject { return &phase0.VoluntaryExit{} },
+		domain: func(chainInfo *beacon.ChainInfo, _ TypedObject) (phase0.Domain, error) {
+			return chainInfo.VoluntaryExitDomain()
+		},
+	},
+}
+
+// slotDomain returns the signature domain for objects whose domain is defined by the fork at their slot.
+func slotDomain(domainType e2types.DomainType,
+	slot func(obj TypedObject) (phase0.Slot, error),
+) func(chainInfo *beacon.ChainInfo, obj TypedObject) (phase0.Domain, error) {
+	return func(chainInfo *beacon.ChainInfo, obj TypedObject) (phase0.Domain, error) {
+		objSlot, err := slot(obj)
+		if err != nil {
+			return phase0.Domain{}, err
+		}
+
+		return chainInfo.DomainAtSlot(phase0.DomainType(domainType), objSlot)
+	}
+}
+
+// ObjectTypes returns the names of the object types that can be signed.
+func ObjectTypes() []string {
+	res := make([]string, 0, len(objectTypes))
+	for name := range objectTypes {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+
+	return res
+}
+
+// ParseTypedObject parses the JSON representation of an object of the given type.
+func ParseTypedObject(name string, input []byte) (TypedObject, error) {
+	objType, exists := objectTypes[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown object type %q", name)
+	}
+
+	obj := objType.create()
+	if err := json.Unmarshal(input, obj); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid %s", name))
+	}
+
+	return obj, nil
+}
+
+// ParseSignedTypedObject parses the JSON representation of a signed object of the given type.
+func ParseSignedTypedObject(name string, input []byte) (*SignedTypedObject, error) {
+	data := &struct {
+		Message   json.RawMessage `json:"message"`
+		Signature string          `json:"signature"`
+	}{}
+	if err := json.Unmarshal(input, data); err != nil {
+		return nil, errors.Wrap(err, "invalid signed object")
+	}
+	if data.Message == nil {
+		return nil, errors.New("message missing")
+	}
+
+	obj, err := ParseTypedObject(name, data.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := parseSignature(data.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedTypedObject{
+		Message:   obj,
+		Signature: signature,
+	}, nil
+}
+
+// TypedObjectRootAndDomain returns the root and signature domain for an object of the given type.
+func TypedObjectRootAndDomain(name string,
+	obj TypedObject,
+	chainInfo *beacon.ChainInfo,
+) (
+	phase0.Root,
+	phase0.Domain,
+	error,
+) {
+	objType, exists := objectTypes[name]
+	if !exists {
+		return phase0.Root{}, phase0.Domain{}, fmt.Errorf("unknown object type %q", name)
+	}
+
+	root, err := obj.HashTreeRoot()
+	if err != nil {
+		return phase0.Root{}, phase0.Domain{}, errors.Wrap(err, "failed to generate root")
+	}
+
+	domain, err := objType.domain(chainInfo, obj)
+	if err != nil {
+		return phase0.Root{}, phase0.Domain{}, err
+	}
+
+	return root, domain, nil
+}
+
+func parseSignature(input string) (phase0.BLSSignature, error) {
+	signature := phase0.BLSSignature{}
+	if input == "" {
+		return signature, errors.New("signature missing")
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return signature, errors.Wrap(err, "invalid signature")
+	}
+	if len(data) != phase0.SignatureLength {
+		return signature, errors.New("incorrect length for signature")
+	}
+	copy(signature[:], data)
+
+	return signature, nil
+}
@@ -0,0 +1,242 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/beacon"
+	"github.com/wealdtech/ethdo/signing"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// testForkSchedule is a fork schedule with a fork at epoch 100 and another at epoch 200.
+var testForkSchedule = []*phase0.Fork{
+	{PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00}, CurrentVersion: phase0.Version{0x00, 0x00, 0x00, 0x00}, Epoch: 0},
+	{PreviousVersion: phase0.Version{0x00, 0x00, 0x00, 0x00}, CurrentVersion: phase0.Version{0x03, 0x00, 0x00, 0x00}, Epoch: 100},
+	{PreviousVersion: phase0.Version{0x03, 0x00, 0x00, 0x00}, CurrentVersion: phase0.Version{0x04, 0x00, 0x00, 0x00}, Epoch: 200},
+}
+
+func TestParseTypedObject(t *testing.T) {
+	tests := []struct {
+		name    string
+		objType string
+		input   string
+		err     string
+	}{
+		{
+			name:    "UnknownType",
+			objType: "unknown",
+			input:   `{}`,
+			err:     `unknown object type "unknown"`,
+		},
+		{
+			name:    "Invalid",
+			objType: "voluntary_exit",
+			input:   `{"epoch":"bad","validator_index":"1"}`,
+			err:     "invalid voluntary_exit: invalid value for epoch: strconv.ParseUint: parsing \"bad\": invalid syntax",
+		},
+		{
+			name:    "VoluntaryExit",
+			objType: "voluntary_exit",
+			input:   `{"epoch":"1","validator_index":"2"}`,
+		},
+		{
+			name:    "BlockHeader",
+			objType: "block_header",
+			input:   `{"slot":"1","proposer_index":"2","parent_root":"0x0000000000000000000000000000000000000000000000000000000000000000","state_root":"0x0000000000000000000000000000000000000000000000000000000000000000","body_root":"0x0000000000000000000000000000000000000000000000000000000000000000"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			obj, err := signing.ParseTypedObject(test.objType, []byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			// Ensure the object round-trips.
+			output, err := json.Marshal(obj)
+			require.NoError(t, err)
+			require.JSONEq(t, test.input, string(output))
+		})
+	}
+}
+
+func TestParseSignedTypedObject(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "MessageMissing",
+			input: `{"signature":"0xb4e8fc5c1074b4a20a64d3938e317a8f864cf04bc1d3daf3da773b9c9db5309ce9194b99dcc37f5af582143450e5416911cc80a8b2131fbf09ef95e92fef44fc664ee00957d9fc9fb2487259ab4bb4677bc43f62b4f76d5aa5c2e24f8ada8c7c"}`,
+			err:   "message missing",
+		},
+		{
+			name:  "SignatureMissing",
+			input: `{"message":{"epoch":"194048","validator_index":"12345"}}`,
+			err:   "signature missing",
+		},
+		{
+			name:  "SignatureShort",
+			input: `{"message":{"epoch":"194048","validator_index":"12345"},"signature":"0xb4e8"}`,
+			err:   "incorrect length for signature",
+		},
+		{
+			name:  "Good",
+			input: `{"message":{"epoch":"194048","validator_index":"12345"},"signature":"0xb4e8fc5c1074b4a20a64d3938e317a8f864cf04bc1d3daf3da773b9c9db5309ce9194b99dcc37f5af582143450e5416911cc80a8b2131fbf09ef95e92fef44fc664ee00957d9fc9fb2487259ab4bb4677bc43f62b4f76d5aa5c2e24f8ada8c7c"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			signed, err := signing.ParseSignedTypedObject("voluntary_exit", []byte(test.input))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			output, err := json.Marshal(signed)
+			require.NoError(t, err)
+			require.JSONEq(t, test.input, string(output))
+		})
+	}
+}
+
+func TestTypedObjectRootAndDomain(t *testing.T) {
+	chainInfo := &beacon.ChainInfo{
+		GenesisValidatorsRoot:          phase0.Root{0x4b, 0x36, 0x3d, 0xb9, 0x4e, 0x28, 0x61, 0x20, 0xd7, 0x6e, 0xb9, 0x05, 0x34, 0x0f, 0xdd, 0x4e, 0x54, 0xbf, 0xe9, 0xf0, 0x6b, 0xf3, 0x3f, 0xf6, 0xcf, 0x5a, 0xd2, 0x7f, 0x51, 0x1b, 0xfe, 0x95},
+		GenesisForkVersion:             phase0.Version{0x00, 0x00, 0x00, 0x00},
+		ExitForkVersion:                phase0.Version{0x03, 0x00, 0x00, 0x00},
+		CurrentForkVersion:             phase0.Version{0x04, 0x00, 0x00, 0x00},
+		BLSToExecutionChangeDomainType: phase0.DomainType{0x0a, 0x00, 0x00, 0x00},
+		VoluntaryExitDomainType:        phase0.DomainType{0x04, 0x00, 0x00, 0x00},
+		SlotsPerEpoch:                  32,
+		ForkSchedule:                   testForkSchedule,
+	}
+
+	exitDomain, err := chainInfo.VoluntaryExitDomain()
+	require.NoError(t, err)
+	attesterDomain, err := chainInfo.CurrentDomain(phase0.DomainType{0x01, 0x00, 0x00, 0x00})
+	require.NoError(t, err)
+	preForkAttesterDomain, err := chainInfo.DomainAtEpoch(phase0.DomainType{0x01, 0x00, 0x00, 0x00}, 50)
+	require.NoError(t, err)
+	require.NotEqual(t, attesterDomain, preForkAttesterDomain)
+	preForkProposerDomain, err := chainInfo.DomainAtEpoch(phase0.DomainType{0x00, 0x00, 0x00, 0x00}, 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		objType string
+		obj     signing.TypedObject
+		domain  phase0.Domain
+		err     string
+	}{
+		{
+			name:    "UnknownType",
+			objType: "unknown",
+			obj:     &phase0.VoluntaryExit{},
+			err:     `unknown object type "unknown"`,
+		},
+		{
+			name:    "VoluntaryExit",
+			objType: "voluntary_exit",
+			obj:     &phase0.VoluntaryExit{Epoch: 1, ValidatorIndex: 2},
+			domain:  exitDomain,
+		},
+		{
+			name:    "DepositMessage",
+			objType: "deposit_message",
+			obj:     &phase0.DepositMessage{WithdrawalCredentials: make([]byte, 32), Amount: 32000000000},
+			domain:  chainInfo.DepositDomain(),
+		},
+		{
+			name:    "AttestationData",
+			objType: "attestation_data",
+			obj:     &phase0.AttestationData{Source: &phase0.Checkpoint{}, Target: &phase0.Checkpoint{Epoch: 200}},
+			domain:  attesterDomain,
+		},
+		{
+			name:    "AttestationDataPreFork",
+			objType: "attestation_data",
+			obj:     &phase0.AttestationData{Slot: 1600, Source: &phase0.Checkpoint{}, Target: &phase0.Checkpoint{Epoch: 50}},
+			domain:  preForkAttesterDomain,
+		},
+		{
+			name:    "BlockHeaderPreFork",
+			objType: "block_header",
+			obj:     &phase0.BeaconBlockHeader{Slot: 32},
+			domain:  preForkProposerDomain,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root, domain, err := signing.TypedObjectRootAndDomain(test.objType, test.obj, chainInfo)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			expectedRoot, err := test.obj.HashTreeRoot()
+			require.NoError(t, err)
+			require.Equal(t, phase0.Root(expectedRoot), root)
+			require.Equal(t, test.domain, domain)
+		})
+	}
+}
+
+func TestVerifyPreForkTypedObject(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	chainInfo := &beacon.ChainInfo{
+		GenesisValidatorsRoot: phase0.Root{0x01},
+		CurrentForkVersion:    phase0.Version{0x04, 0x00, 0x00, 0x00},
+		SlotsPerEpoch:         32,
+		ForkSchedule:          testForkSchedule,
+	}
+	account, err := util.ParseAccount(ctx, "0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866", nil, true)
+	require.NoError(t, err)
+
+	// A block header from before the most recent fork, signed with the domain of its fork.
+	header := &phase0.BeaconBlockHeader{Slot: 3200, ProposerIndex: 1}
+	headerRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+	signingDomain, err := chainInfo.DomainAtEpoch(phase0.DomainType{0x00, 0x00, 0x00, 0x00}, 100)
+	require.NoError(t, err)
+	signature, err := util.SignRoot(account, headerRoot, signingDomain)
+	require.NoError(t, err)
+
+	root, domain, err := signing.TypedObjectRootAndDomain("block_header", header, chainInfo)
+	require.NoError(t, err)
+	verified, err := util.VerifyRoot(account, root, domain, signature)
+	require.NoError(t, err)
+	require.True(t, verified)
+
+	// The domain of the current fork does not verify the signature.
+	currentDomain, err := chainInfo.CurrentDomain(phase0.DomainType{0x00, 0x00, 0x00, 0x00})
+	require.NoError(t, err)
+	verified, err = util.VerifyRoot(account, root, currentDomain, signature)
+	require.NoError(t, err)
+	require.False(t, verified)
+}
@@ -0,0 +1,93 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package web3signer provides a client for remote signers that implement the
+// Web3Signer Ethereum consensus signing API.
+package web3signer
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// options are the options for the remote signer client.
+type options struct {
+	url     string
+	timeout time.Duration
+}
+
+// Option gives options to New.
+type Option interface {
+	apply(*options)
+}
+
+type optionFunc func(*options)
+
+func (f optionFunc) apply(o *options) {
+	f(o)
+}
+
+// WithURL sets the base URL of the remote signer, for example "https://signer.example.com:9000".
+func WithURL(url string) Option {
+	return optionFunc(func(o *options) {
+		o.url = url
+	})
+}
+
+// WithTimeout sets the timeout for requests to the remote signer.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(o *options) {
+		if timeout != 0 {
+			o.timeout = timeout
+		}
+	})
+}
+
+// Service is a client for a Web3Signer-compatible remote signer.
+type Service struct {
+	client *http.Client
+	url    string
+}
+
+// New creates a new remote signer client.
+// This takes the following options:
+//   - url: the base URL of the remote signer, set with WithURL()
+//   - timeout: the timeout for requests to the remote signer, defaults to 30 seconds, set with WithTimeout()
+func New(opts ...Option) (*Service, error) {
+	options := options{
+		timeout: 30 * time.Second,
+	}
+	for _, o := range opts {
+		o.apply(&options)
+	}
+
+	if options.url == "" {
+		return nil, errors.New("no URL specified")
+	}
+	base, err := url.Parse(options.url)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid URL")
+	}
+	if base.Scheme != "http" && base.Scheme != "https" {
+		return nil, errors.New("URL must be http or https")
+	}
+
+	return &Service{
+		client: &http.Client{Timeout: options.timeout},
+		url:    strings.TrimSuffix(options.url, "/"),
+	}, nil
+}
@@ -0,0 +1,216 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web3signer_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/signing/web3signer"
+	"github.com/wealdtech/ethdo/testutil"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// stubSigner provides a minimal Web3Signer-compatible signing endpoint.
+func stubSigner(t *testing.T, key e2types.PrivateKey, status int, plainText bool, signRoot bool) *httptest.Server {
+	t.Helper()
+
+	pubKey := fmt.Sprintf("%#x", key.PublicKey().Marshal())
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/api/v1/eth2/sign/") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if strings.TrimPrefix(r.URL.Path, "/api/v1/eth2/sign/") != pubKey {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte("stub error"))
+			return
+		}
+
+		req := make(map[string]any)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req["type"] == nil || req["fork_info"] == nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data := testutil.HexToBytes(req["signingRoot"].(string))
+		if !signRoot {
+			// Sign something else.
+			data = make([]byte, 32)
+		}
+		signature := fmt.Sprintf("%#x", key.Sign(data).Marshal())
+
+		if plainText {
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(signature))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(fmt.Sprintf(`{"signature":"%s"}`, signature)))
+	}))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		err  string
+	}{
+		{
+			name: "URLMissing",
+			err:  "no URL specified",
+		},
+		{
+			name: "URLBadScheme",
+			url:  "ftp://localhost:9000",
+			err:  "URL must be http or https",
+		},
+		{
+			name: "Good",
+			url:  "http://localhost:9000/",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := web3signer.New(web3signer.WithURL(test.url))
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	key, err := e2types.BLSPrivateKeyFromBytes(testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"))
+	require.NoError(t, err)
+	pubKey := phase0.BLSPubKey(key.PublicKey().Marshal())
+	forkInfo := &web3signer.ForkInfo{
+		Fork: &phase0.Fork{
+			PreviousVersion: phase0.Version{0x03, 0x00, 0x00, 0x00},
+			CurrentVersion:  phase0.Version{0x03, 0x00, 0x00, 0x00},
+		},
+	}
+	domain := phase0.Domain{0x04}
+	exit := &phase0.VoluntaryExit{
+		Epoch:          194048,
+		ValidatorIndex: 12345,
+	}
+	change := &capella.BLSToExecutionChange{
+		ValidatorIndex:     12345,
+		FromBLSPubkey:      pubKey,
+		ToExecutionAddress: bellatrix.ExecutionAddress{0x01},
+	}
+
+	tests := []struct {
+		name      string
+		pubKey    phase0.BLSPubKey
+		status    int
+		plainText bool
+		signRoot  bool
+		err       string
+	}{
+		{
+			name:     "UnknownKey",
+			pubKey:   phase0.BLSPubKey{0x01},
+			status:   http.StatusOK,
+			signRoot: true,
+			err:      "remote signer does not hold key 0x010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name:     "SlashingProtection",
+			pubKey:   pubKey,
+			status:   http.StatusPreconditionFailed,
+			signRoot: true,
+			err:      "remote signer refused to sign due to slashing protection",
+		},
+		{
+			name:     "ServerError",
+			pubKey:   pubKey,
+			status:   http.StatusInternalServerError,
+			signRoot: true,
+			err:      "remote signer returned status 500: stub error",
+		},
+		{
+			name:   "BadSignature",
+			pubKey: pubKey,
+			status: http.StatusOK,
+			err:    "remote signer returned signature that does not verify",
+		},
+		{
+			name:     "Good",
+			pubKey:   pubKey,
+			status:   http.StatusOK,
+			signRoot: true,
+		},
+		{
+			name:      "GoodPlainText",
+			pubKey:    pubKey,
+			status:    http.StatusOK,
+			plainText: true,
+			signRoot:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := stubSigner(t, key, test.status, test.plainText, test.signRoot)
+			defer server.Close()
+
+			service, err := web3signer.New(web3signer.WithURL(server.URL))
+			require.NoError(t, err)
+
+			exitSignature, err := service.SignVoluntaryExit(ctx, test.pubKey, forkInfo, exit, domain)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				root, err := exit.HashTreeRoot()
+				require.NoError(t, err)
+				signingRoot, err := (&phase0.SigningData{ObjectRoot: root, Domain: domain}).HashTreeRoot()
+				require.NoError(t, err)
+				require.Equal(t, key.Sign(signingRoot[:]).Marshal(), exitSignature[:])
+			}
+
+			_, err = service.SignBLSToExecutionChange(ctx, test.pubKey, forkInfo, change, domain)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web3signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// ForkInfo is the fork information supplied to the remote signer, from which
+// it calculates the signature domain.
+type ForkInfo struct {
+	Fork                  *phase0.Fork
+	GenesisValidatorsRoot phase0.Root
+}
+
+type forkInfoJSON struct {
+	Fork                  *phase0.Fork `json:"fork"`
+	GenesisValidatorsRoot string       `json:"genesis_validators_root"`
+}
+
+type signRequest struct {
+	Type                 string                        `json:"type"`
+	ForkInfo             *forkInfoJSON                 `json:"fork_info,omitempty"`
+	SigningRoot          string                        `json:"signingRoot"`
+	VoluntaryExit        *phase0.VoluntaryExit         `json:"voluntary_exit,omitempty"`
+	BLSToExecutionChange *capella.BLSToExecutionChange `json:"bls_to_execution_change,omitempty"`
+}
+
+type signResponse struct {
+	Signature string `json:"signature"`
+}
+
+// SignVoluntaryExit signs a voluntary exit with the given domain.
+func (s *Service) SignVoluntaryExit(ctx context.Context,
+	pubKey phase0.BLSPubKey,
+	forkInfo *ForkInfo,
+	exit *phase0.VoluntaryExit,
+	domain phase0.Domain,
+) (
+	phase0.BLSSignature,
+	error,
+) {
+	root, err := exit.HashTreeRoot()
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to generate root for exit")
+	}
+
+	return s.sign(ctx, pubKey, &signRequest{
+		Type:          "VOLUNTARY_EXIT",
+		ForkInfo:      forkInfoToJSON(forkInfo),
+		VoluntaryExit: exit,
+	}, root, domain)
+}
+
+// SignBLSToExecutionChange signs a BLS to execution change with the given domain.
+func (s *Service) SignBLSToExecutionChange(ctx context.Context,
+	pubKey phase0.BLSPubKey,
+	forkInfo *ForkInfo,
+	change *capella.BLSToExecutionChange,
+	domain phase0.Domain,
+) (
+	phase0.BLSSignature,
+	error,
+) {
+	root, err := change.HashTreeRoot()
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to generate root for credentials change")
+	}
+
+	return s.sign(ctx, pubKey, &signRequest{
+		Type:                 "BLS_TO_EXECUTION_CHANGE",
+		ForkInfo:             forkInfoToJSON(forkInfo),
+		BLSToExecutionChange: change,
+	}, root, domain)
+}
+
+// sign sends a signing request to the remote signer, and verifies the returned signature.
+func (s *Service) sign(ctx context.Context,
+	pubKey phase0.BLSPubKey,
+	req *signRequest,
+	root phase0.Root,
+	domain phase0.Domain,
+) (
+	phase0.BLSSignature,
+	error,
+) {
+	signingRoot, err := (&phase0.SigningData{
+		ObjectRoot: root,
+		Domain:     domain,
+	}).HashTreeRoot()
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to generate signing root")
+	}
+	req.SigningRoot = fmt.Sprintf("%#x", signingRoot)
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to marshal request")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/api/v1/eth2/sign/%#x", s.url, pubKey), bytes.NewReader(body))
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to create request")
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "request to remote signer failed")
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "failed to read response")
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// Good.
+	case http.StatusNotFound:
+		return phase0.BLSSignature{}, fmt.Errorf("remote signer does not hold key %#x", pubKey)
+	case http.StatusPreconditionFailed:
+		return phase0.BLSSignature{}, errors.New("remote signer refused to sign due to slashing protection")
+	default:
+		if msg := strings.TrimSpace(string(data)); msg != "" {
+			return phase0.BLSSignature{}, fmt.Errorf("remote signer returned status %d: %s", resp.StatusCode, msg)
+		}
+		return phase0.BLSSignature{}, fmt.Errorf("remote signer returned status %d", resp.StatusCode)
+	}
+
+	signature, err := parseSignature(data)
+	if err != nil {
+		return phase0.BLSSignature{}, err
+	}
+
+	// Ensure that the signature is valid before returning it.
+	blsPubKey, err := e2types.BLSPublicKeyFromBytes(pubKey[:])
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "invalid public key")
+	}
+	blsSignature, err := e2types.BLSSignatureFromBytes(signature[:])
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "remote signer returned invalid signature")
+	}
+	if !blsSignature.Verify(signingRoot[:], blsPubKey) {
+		return phase0.BLSSignature{}, errors.New("remote signer returned signature that does not verify")
+	}
+
+	return signature, nil
+}
+
+// parseSignature parses a signature response, which can be either JSON or plain text.
+func parseSignature(data []byte) (phase0.BLSSignature, error) {
+	input := strings.TrimSpace(string(data))
+	if strings.HasPrefix(input, "{") {
+		res := &signResponse{}
+		if err := json.Unmarshal(data, res); err != nil {
+			return phase0.BLSSignature{}, errors.Wrap(err, "invalid response")
+		}
+		input = res.Signature
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return phase0.BLSSignature{}, errors.Wrap(err, "invalid signature")
+	}
+	if len(sig) != phase0.SignatureLength {
+		return phase0.BLSSignature{}, errors.New("incorrect length for signature")
+	}
+
+	var signature phase0.BLSSignature
+	copy(signature[:], sig)
+
+	return signature, nil
+}
+
+func forkInfoToJSON(forkInfo *ForkInfo) *forkInfoJSON {
+	if forkInfo == nil {
+		return nil
+	}
+
+	return &forkInfoJSON{
+		Fork:                  forkInfo.Fork,
+		GenesisValidatorsRoot: fmt.Sprintf("%#x", forkInfo.GenesisValidatorsRoot),
+	}
+}
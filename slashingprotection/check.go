@@ -0,0 +1,143 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Issue is an issue found with an interchange.
+type Issue struct {
+	// PubKey is the public key of the validator to which the issue relates, if any.
+	PubKey *phase0.BLSPubKey
+	// Message describes the issue.
+	Message string
+}
+
+// String implements fmt.Stringer.
+func (i *Issue) String() string {
+	if i.PubKey == nil {
+		return i.Message
+	}
+
+	return fmt.Sprintf("%#x: %s", *i.PubKey, i.Message)
+}
+
+// Check checks an interchange for internal consistency, returning any issues found.
+// This finds validators that appear multiple times, attestations whose source is after
+// their target, and blocks and attestations that would be slashable if broadcast.
+func Check(interchange *Interchange) []*Issue {
+	issues := make([]*Issue, 0)
+
+	seen := make(map[phase0.BLSPubKey]bool)
+	for _, validator := range interchange.Data {
+		pubKey := validator.PubKey
+		if seen[pubKey] {
+			issues = append(issues, &Issue{
+				PubKey:  &pubKey,
+				Message: "validator appears multiple times",
+			})
+		}
+		seen[pubKey] = true
+
+		for _, message := range checkBlocks(validator.SignedBlocks) {
+			issues = append(issues, &Issue{
+				PubKey:  &pubKey,
+				Message: message,
+			})
+		}
+		for _, message := range checkAttestations(validator.SignedAttestations) {
+			issues = append(issues, &Issue{
+				PubKey:  &pubKey,
+				Message: message,
+			})
+		}
+	}
+
+	return issues
+}
+
+// checkBlocks checks for multiple blocks signed for the same slot.
+func checkBlocks(blocks []*SignedBlock) []string {
+	messages := make([]string, 0)
+
+	bySlot := make(map[phase0.Slot][]*SignedBlock)
+	for _, block := range blocks {
+		bySlot[block.Slot] = append(bySlot[block.Slot], block)
+	}
+	slots := make([]phase0.Slot, 0, len(bySlot))
+	for slot, slotBlocks := range bySlot {
+		if len(dedupBlocks(slotBlocks)) > 1 {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Slice(slots, func(i int, j int) bool {
+		return slots[i] < slots[j]
+	})
+	for _, slot := range slots {
+		messages = append(messages, fmt.Sprintf("multiple blocks signed for slot %d", slot))
+	}
+
+	return messages
+}
+
+// checkAttestations checks for invalid, double and surround votes.
+func checkAttestations(attestations []*SignedAttestation) []string {
+	messages := make([]string, 0)
+
+	sorted := make([]*SignedAttestation, 0, len(attestations))
+	for _, attestation := range attestations {
+		if attestation.SourceEpoch > attestation.TargetEpoch {
+			messages = append(messages, fmt.Sprintf("attestation has source epoch %d after target epoch %d", attestation.SourceEpoch, attestation.TargetEpoch))
+			continue
+		}
+		sorted = append(sorted, attestation)
+	}
+	sorted = dedupAttestations(sorted)
+
+	// Double votes.
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].TargetEpoch == sorted[i-1].TargetEpoch && (i == 1 || sorted[i-2].TargetEpoch != sorted[i].TargetEpoch) {
+			messages = append(messages, fmt.Sprintf("multiple attestations signed for target epoch %d", sorted[i].TargetEpoch))
+		}
+	}
+
+	// Surround votes.  Sorting by source epoch means that any attestation that surrounds
+	// another is seen before it; track the highest target seen for lower source epochs.
+	sort.SliceStable(sorted, func(i int, j int) bool {
+		return sorted[i].SourceEpoch < sorted[j].SourceEpoch
+	})
+	var surrounding *SignedAttestation
+	for i := 0; i < len(sorted); {
+		// Process all attestations with the same source epoch together.
+		j := i
+		for j < len(sorted) && sorted[j].SourceEpoch == sorted[i].SourceEpoch {
+			if surrounding != nil && surrounding.TargetEpoch > sorted[j].TargetEpoch {
+				messages = append(messages, fmt.Sprintf("attestation %d->%d surrounds attestation %d->%d",
+					surrounding.SourceEpoch, surrounding.TargetEpoch, sorted[j].SourceEpoch, sorted[j].TargetEpoch))
+			}
+			j++
+		}
+		for ; i < j; i++ {
+			if surrounding == nil || sorted[i].TargetEpoch > surrounding.TargetEpoch {
+				surrounding = sorted[i]
+			}
+		}
+	}
+
+	return messages
+}
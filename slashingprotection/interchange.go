@@ -0,0 +1,264 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slashingprotection handles slashing protection data in the EIP-3076 interchange format.
+package slashingprotection
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// InterchangeFormatVersion is the version of the interchange format supported.
+const InterchangeFormatVersion = "5"
+
+// Interchange is slashing protection data in the EIP-3076 interchange format.
+type Interchange struct {
+	GenesisValidatorsRoot phase0.Root
+	Data                  []*Validator
+}
+
+// Validator is the slashing protection data for a single validator.
+type Validator struct {
+	PubKey             phase0.BLSPubKey
+	SignedBlocks       []*SignedBlock
+	SignedAttestations []*SignedAttestation
+}
+
+// SignedBlock is a block signed by a validator.
+type SignedBlock struct {
+	Slot        phase0.Slot
+	SigningRoot *phase0.Root
+}
+
+// SignedAttestation is an attestation signed by a validator.
+type SignedAttestation struct {
+	SourceEpoch phase0.Epoch
+	TargetEpoch phase0.Epoch
+	SigningRoot *phase0.Root
+}
+
+type interchangeJSON struct {
+	Metadata *metadataJSON    `json:"metadata"`
+	Data     []*validatorJSON `json:"data"`
+}
+
+type metadataJSON struct {
+	InterchangeFormatVersion string `json:"interchange_format_version"`
+	GenesisValidatorsRoot    string `json:"genesis_validators_root"`
+}
+
+type validatorJSON struct {
+	PubKey             string                   `json:"pubkey"`
+	SignedBlocks       []*signedBlockJSON       `json:"signed_blocks"`
+	SignedAttestations []*signedAttestationJSON `json:"signed_attestations"`
+}
+
+type signedBlockJSON struct {
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+type signedAttestationJSON struct {
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i *Interchange) MarshalJSON() ([]byte, error) {
+	data := make([]*validatorJSON, 0, len(i.Data))
+	for _, validator := range i.Data {
+		validatorData := &validatorJSON{
+			PubKey:             fmt.Sprintf("%#x", validator.PubKey),
+			SignedBlocks:       make([]*signedBlockJSON, 0, len(validator.SignedBlocks)),
+			SignedAttestations: make([]*signedAttestationJSON, 0, len(validator.SignedAttestations)),
+		}
+		for _, block := range validator.SignedBlocks {
+			validatorData.SignedBlocks = append(validatorData.SignedBlocks, &signedBlockJSON{
+				Slot:        fmt.Sprintf("%d", block.Slot),
+				SigningRoot: formatRoot(block.SigningRoot),
+			})
+		}
+		for _, attestation := range validator.SignedAttestations {
+			validatorData.SignedAttestations = append(validatorData.SignedAttestations, &signedAttestationJSON{
+				SourceEpoch: fmt.Sprintf("%d", attestation.SourceEpoch),
+				TargetEpoch: fmt.Sprintf("%d", attestation.TargetEpoch),
+				SigningRoot: formatRoot(attestation.SigningRoot),
+			})
+		}
+		data = append(data, validatorData)
+	}
+
+	return json.Marshal(&interchangeJSON{
+		Metadata: &metadataJSON{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    fmt.Sprintf("%#x", i.GenesisValidatorsRoot),
+		},
+		Data: data,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Interchange) UnmarshalJSON(input []byte) error {
+	var data interchangeJSON
+	if err := json.Unmarshal(input, &data); err != nil {
+		return errors.Wrap(err, "invalid JSON")
+	}
+
+	if data.Metadata == nil {
+		return errors.New("metadata missing")
+	}
+	if data.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version %q", data.Metadata.InterchangeFormatVersion)
+	}
+	genesisValidatorsRoot, err := parseHex(data.Metadata.GenesisValidatorsRoot, "genesis validators root", phase0.RootLength)
+	if err != nil {
+		return err
+	}
+	copy(i.GenesisValidatorsRoot[:], genesisValidatorsRoot)
+
+	i.Data = make([]*Validator, 0, len(data.Data))
+	for j, validatorData := range data.Data {
+		validator, err := validatorData.unmarshal()
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("data entry %d", j))
+		}
+		i.Data = append(i.Data, validator)
+	}
+
+	return nil
+}
+
+func (v *validatorJSON) unmarshal() (*Validator, error) {
+	pubKey, err := parseHex(v.PubKey, "pubkey", phase0.PublicKeyLength)
+	if err != nil {
+		return nil, err
+	}
+	validator := &Validator{
+		SignedBlocks:       make([]*SignedBlock, 0, len(v.SignedBlocks)),
+		SignedAttestations: make([]*SignedAttestation, 0, len(v.SignedAttestations)),
+	}
+	copy(validator.PubKey[:], pubKey)
+
+	for _, blockData := range v.SignedBlocks {
+		slot, err := parseUint(blockData.Slot, "slot")
+		if err != nil {
+			return nil, err
+		}
+		signingRoot, err := parseOptionalRoot(blockData.SigningRoot)
+		if err != nil {
+			return nil, err
+		}
+		validator.SignedBlocks = append(validator.SignedBlocks, &SignedBlock{
+			Slot:        phase0.Slot(slot),
+			SigningRoot: signingRoot,
+		})
+	}
+
+	for _, attestationData := range v.SignedAttestations {
+		sourceEpoch, err := parseUint(attestationData.SourceEpoch, "source epoch")
+		if err != nil {
+			return nil, err
+		}
+		targetEpoch, err := parseUint(attestationData.TargetEpoch, "target epoch")
+		if err != nil {
+			return nil, err
+		}
+		signingRoot, err := parseOptionalRoot(attestationData.SigningRoot)
+		if err != nil {
+			return nil, err
+		}
+		validator.SignedAttestations = append(validator.SignedAttestations, &SignedAttestation{
+			SourceEpoch: phase0.Epoch(sourceEpoch),
+			TargetEpoch: phase0.Epoch(targetEpoch),
+			SigningRoot: signingRoot,
+		})
+	}
+
+	return validator, nil
+}
+
+// ReadFile reads an interchange file.
+func ReadFile(path string) (*Interchange, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to read %s", path))
+	}
+	interchange := &Interchange{}
+	if err := interchange.UnmarshalJSON(data); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("%s invalid", path))
+	}
+
+	return interchange, nil
+}
+
+// formatRoot formats an optional signing root.
+func formatRoot(root *phase0.Root) string {
+	if root == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%#x", *root)
+}
+
+// parseUint parses a decimal value from a JSON field.
+func parseUint(input string, name string) (uint64, error) {
+	if input == "" {
+		return 0, fmt.Errorf("%s missing", name)
+	}
+	res, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("%s invalid", name))
+	}
+
+	return res, nil
+}
+
+// parseHex parses a hex value of known length from a JSON field.
+func parseHex(input string, name string, length int) ([]byte, error) {
+	if input == "" {
+		return nil, fmt.Errorf("%s missing", name)
+	}
+	res, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("%s invalid", name))
+	}
+	if len(res) != length {
+		return nil, fmt.Errorf("%s must be %d bytes", name, length)
+	}
+
+	return res, nil
+}
+
+// parseOptionalRoot parses an optional signing root from a JSON field.
+func parseOptionalRoot(input string) (*phase0.Root, error) {
+	if input == "" {
+		return nil, nil
+	}
+	data, err := parseHex(input, "signing root", phase0.RootLength)
+	if err != nil {
+		return nil, err
+	}
+	root := phase0.Root{}
+	copy(root[:], data)
+
+	return &root, nil
+}
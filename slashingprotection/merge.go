@@ -0,0 +1,122 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// Merge merges interchanges, for example from multiple validator clients, in to a single interchange.
+// Each validator appears once in the result, with duplicate blocks and attestations removed.
+// All interchanges must be for the same chain.
+func Merge(interchanges []*Interchange) (*Interchange, error) {
+	if len(interchanges) == 0 {
+		return nil, errors.New("no interchanges to merge")
+	}
+
+	res := &Interchange{
+		GenesisValidatorsRoot: interchanges[0].GenesisValidatorsRoot,
+		Data:                  make([]*Validator, 0),
+	}
+	validators := make(map[phase0.BLSPubKey]*Validator)
+	for i, interchange := range interchanges {
+		if interchange.GenesisValidatorsRoot != res.GenesisValidatorsRoot {
+			return nil, fmt.Errorf("interchange %d has genesis validators root %#x; expected %#x", i+1, interchange.GenesisValidatorsRoot, res.GenesisValidatorsRoot)
+		}
+		for _, validator := range interchange.Data {
+			merged, exists := validators[validator.PubKey]
+			if !exists {
+				merged = &Validator{
+					PubKey:             validator.PubKey,
+					SignedBlocks:       make([]*SignedBlock, 0, len(validator.SignedBlocks)),
+					SignedAttestations: make([]*SignedAttestation, 0, len(validator.SignedAttestations)),
+				}
+				validators[validator.PubKey] = merged
+				res.Data = append(res.Data, merged)
+			}
+			merged.SignedBlocks = append(merged.SignedBlocks, validator.SignedBlocks...)
+			merged.SignedAttestations = append(merged.SignedAttestations, validator.SignedAttestations...)
+		}
+	}
+
+	for _, validator := range res.Data {
+		validator.SignedBlocks = dedupBlocks(validator.SignedBlocks)
+		validator.SignedAttestations = dedupAttestations(validator.SignedAttestations)
+	}
+
+	return res, nil
+}
+
+// dedupBlocks sorts blocks by slot and removes duplicates.
+func dedupBlocks(blocks []*SignedBlock) []*SignedBlock {
+	sort.SliceStable(blocks, func(i int, j int) bool {
+		return blocks[i].Slot < blocks[j].Slot
+	})
+
+	res := make([]*SignedBlock, 0, len(blocks))
+	for _, block := range blocks {
+		duplicate := false
+		for j := len(res) - 1; j >= 0 && res[j].Slot == block.Slot; j-- {
+			if sameRoot(res[j].SigningRoot, block.SigningRoot) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			res = append(res, block)
+		}
+	}
+
+	return res
+}
+
+// dedupAttestations sorts attestations by target and source epoch and removes duplicates.
+func dedupAttestations(attestations []*SignedAttestation) []*SignedAttestation {
+	sort.SliceStable(attestations, func(i int, j int) bool {
+		if attestations[i].TargetEpoch != attestations[j].TargetEpoch {
+			return attestations[i].TargetEpoch < attestations[j].TargetEpoch
+		}
+
+		return attestations[i].SourceEpoch < attestations[j].SourceEpoch
+	})
+
+	res := make([]*SignedAttestation, 0, len(attestations))
+	for _, attestation := range attestations {
+		duplicate := false
+		for j := len(res) - 1; j >= 0 && res[j].TargetEpoch == attestation.TargetEpoch; j-- {
+			if res[j].SourceEpoch == attestation.SourceEpoch && sameRoot(res[j].SigningRoot, attestation.SigningRoot) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			res = append(res, attestation)
+		}
+	}
+
+	return res
+}
+
+// sameRoot returns true if two optional signing roots are the same.
+func sameRoot(root1 *phase0.Root, root2 *phase0.Root) bool {
+	if root1 == nil || root2 == nil {
+		return root1 == nil && root2 == nil
+	}
+
+	return *root1 == *root2
+}
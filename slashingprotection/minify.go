@@ -0,0 +1,68 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// Minify creates the minimal interchange that provides the same protection as the supplied interchange.
+// Each validator has at most a single block, at the highest slot signed, and a single attestation,
+// with the highest source and target epochs signed.  Signing roots are not retained.
+func Minify(interchange *Interchange) *Interchange {
+	res := &Interchange{
+		GenesisValidatorsRoot: interchange.GenesisValidatorsRoot,
+		Data:                  make([]*Validator, 0, len(interchange.Data)),
+	}
+
+	validators := make(map[phase0.BLSPubKey]*Validator)
+	for _, validator := range interchange.Data {
+		minified, exists := validators[validator.PubKey]
+		if !exists {
+			minified = &Validator{
+				PubKey:             validator.PubKey,
+				SignedBlocks:       make([]*SignedBlock, 0, 1),
+				SignedAttestations: make([]*SignedAttestation, 0, 1),
+			}
+			validators[validator.PubKey] = minified
+			res.Data = append(res.Data, minified)
+		}
+
+		for _, block := range validator.SignedBlocks {
+			if len(minified.SignedBlocks) == 0 {
+				minified.SignedBlocks = append(minified.SignedBlocks, &SignedBlock{Slot: block.Slot})
+			} else if block.Slot > minified.SignedBlocks[0].Slot {
+				minified.SignedBlocks[0].Slot = block.Slot
+			}
+		}
+
+		for _, attestation := range validator.SignedAttestations {
+			if len(minified.SignedAttestations) == 0 {
+				minified.SignedAttestations = append(minified.SignedAttestations, &SignedAttestation{
+					SourceEpoch: attestation.SourceEpoch,
+					TargetEpoch: attestation.TargetEpoch,
+				})
+				continue
+			}
+			if attestation.SourceEpoch > minified.SignedAttestations[0].SourceEpoch {
+				minified.SignedAttestations[0].SourceEpoch = attestation.SourceEpoch
+			}
+			if attestation.TargetEpoch > minified.SignedAttestations[0].TargetEpoch {
+				minified.SignedAttestations[0].TargetEpoch = attestation.TargetEpoch
+			}
+		}
+	}
+
+	return res
+}
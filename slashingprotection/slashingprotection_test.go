@@ -0,0 +1,203 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashingprotection_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/slashingprotection"
+)
+
+const (
+	genesisValidatorsRoot = "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+	otherRoot             = "0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95"
+	pubKey1               = "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"
+	pubKey2               = "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	root1                 = "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"
+	root2                 = "0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"
+)
+
+func interchange(t *testing.T, genesisValidatorsRoot string, data string) *slashingprotection.Interchange {
+	t.Helper()
+
+	res := &slashingprotection.Interchange{}
+	require.NoError(t, json.Unmarshal([]byte(`{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+genesisValidatorsRoot+`"},"data":[`+data+`]}`), res))
+
+	return res
+}
+
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   string
+	}{
+		{
+			name:  "Empty",
+			input: `{}`,
+			err:   "metadata missing",
+		},
+		{
+			name:  "VersionUnsupported",
+			input: `{"metadata":{"interchange_format_version":"4","genesis_validators_root":"` + genesisValidatorsRoot + `"},"data":[]}`,
+			err:   `unsupported interchange format version "4"`,
+		},
+		{
+			name:  "GenesisValidatorsRootInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"0x01"},"data":[]}`,
+			err:   "genesis validators root must be 32 bytes",
+		},
+		{
+			name:  "PubKeyMissing",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + genesisValidatorsRoot + `"},"data":[{"signed_blocks":[],"signed_attestations":[]}]}`,
+			err:   "data entry 0: pubkey missing",
+		},
+		{
+			name:  "SlotInvalid",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + genesisValidatorsRoot + `"},"data":[{"pubkey":"` + pubKey1 + `","signed_blocks":[{"slot":"-1"}],"signed_attestations":[]}]}`,
+			err:   `data entry 0: slot invalid: strconv.ParseUint: parsing "-1": invalid syntax`,
+		},
+		{
+			name:  "Good",
+			input: `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + genesisValidatorsRoot + `"},"data":[{"pubkey":"` + pubKey1 + `","signed_blocks":[{"slot":"81952","signing_root":"` + root1 + `"},{"slot":"81951"}],"signed_attestations":[{"source_epoch":"2290","target_epoch":"3007","signing_root":"` + root2 + `"},{"source_epoch":"2290","target_epoch":"3008"}]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &slashingprotection.Interchange{}
+			err := json.Unmarshal([]byte(test.input), res)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			output, err := json.Marshal(res)
+			require.NoError(t, err)
+			require.Equal(t, test.input, string(output))
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	interchange1 := interchange(t, genesisValidatorsRoot, `{"pubkey":"`+pubKey1+`","signed_blocks":[{"slot":"10","signing_root":"`+root1+`"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2","signing_root":"`+root1+`"}]}`)
+	interchange2 := interchange(t, genesisValidatorsRoot, `{"pubkey":"`+pubKey1+`","signed_blocks":[{"slot":"10","signing_root":"`+root1+`"},{"slot":"5"}],"signed_attestations":[{"source_epoch":"2","target_epoch":"3"}]},{"pubkey":"`+pubKey2+`","signed_blocks":[],"signed_attestations":[]}`)
+	otherChain := interchange(t, otherRoot, ``)
+
+	tests := []struct {
+		name         string
+		interchanges []*slashingprotection.Interchange
+		res          string
+		err          string
+	}{
+		{
+			name: "Empty",
+			err:  "no interchanges to merge",
+		},
+		{
+			name:         "DifferentChains",
+			interchanges: []*slashingprotection.Interchange{interchange1, otherChain},
+			err:          "interchange 2 has genesis validators root " + otherRoot + "; expected " + genesisValidatorsRoot,
+		},
+		{
+			name:         "Good",
+			interchanges: []*slashingprotection.Interchange{interchange1, interchange2},
+			res:          `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"` + genesisValidatorsRoot + `"},"data":[{"pubkey":"` + pubKey1 + `","signed_blocks":[{"slot":"5"},{"slot":"10","signing_root":"` + root1 + `"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2","signing_root":"` + root1 + `"},{"source_epoch":"2","target_epoch":"3"}]},{"pubkey":"` + pubKey2 + `","signed_blocks":[],"signed_attestations":[]}]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := slashingprotection.Merge(test.interchanges)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			output, err := json.Marshal(res)
+			require.NoError(t, err)
+			require.Equal(t, test.res, string(output))
+		})
+	}
+}
+
+func TestMinify(t *testing.T) {
+	input := interchange(t, genesisValidatorsRoot, `{"pubkey":"`+pubKey1+`","signed_blocks":[{"slot":"10","signing_root":"`+root1+`"},{"slot":"5"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"5"},{"source_epoch":"3","target_epoch":"4"}]},{"pubkey":"`+pubKey2+`","signed_blocks":[],"signed_attestations":[]},{"pubkey":"`+pubKey1+`","signed_blocks":[{"slot":"12"}],"signed_attestations":[]}`)
+
+	output, err := json.Marshal(slashingprotection.Minify(input))
+	require.NoError(t, err)
+	require.Equal(t, `{"metadata":{"interchange_format_version":"5","genesis_validators_root":"`+genesisValidatorsRoot+`"},"data":[{"pubkey":"`+pubKey1+`","signed_blocks":[{"slot":"12"}],"signed_attestations":[{"source_epoch":"3","target_epoch":"5"}]},{"pubkey":"`+pubKey2+`","signed_blocks":[],"signed_attestations":[]}]}`, string(output))
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		issues []string
+	}{
+		{
+			name: "Good",
+			data: `{"pubkey":"` + pubKey1 + `","signed_blocks":[{"slot":"10","signing_root":"` + root1 + `"},{"slot":"10","signing_root":"` + root1 + `"}],"signed_attestations":[{"source_epoch":"1","target_epoch":"2"},{"source_epoch":"2","target_epoch":"3"}]}`,
+		},
+		{
+			name: "DuplicateValidator",
+			data: `{"pubkey":"` + pubKey1 + `","signed_blocks":[],"signed_attestations":[]},{"pubkey":"` + pubKey1 + `","signed_blocks":[],"signed_attestations":[]}`,
+			issues: []string{
+				pubKey1 + ": validator appears multiple times",
+			},
+		},
+		{
+			name: "DoubleProposal",
+			data: `{"pubkey":"` + pubKey1 + `","signed_blocks":[{"slot":"10","signing_root":"` + root1 + `"},{"slot":"10","signing_root":"` + root2 + `"}],"signed_attestations":[]}`,
+			issues: []string{
+				pubKey1 + ": multiple blocks signed for slot 10",
+			},
+		},
+		{
+			name: "SourceAfterTarget",
+			data: `{"pubkey":"` + pubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"3","target_epoch":"2"}]}`,
+			issues: []string{
+				pubKey1 + ": attestation has source epoch 3 after target epoch 2",
+			},
+		},
+		{
+			name: "DoubleVote",
+			data: `{"pubkey":"` + pubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"1","target_epoch":"3","signing_root":"` + root1 + `"},{"source_epoch":"1","target_epoch":"3","signing_root":"` + root2 + `"},{"source_epoch":"2","target_epoch":"3"}]}`,
+			issues: []string{
+				pubKey1 + ": multiple attestations signed for target epoch 3",
+			},
+		},
+		{
+			name: "SurroundVote",
+			data: `{"pubkey":"` + pubKey1 + `","signed_blocks":[],"signed_attestations":[{"source_epoch":"2","target_epoch":"3"},{"source_epoch":"1","target_epoch":"5"},{"source_epoch":"4","target_epoch":"6"}]}`,
+			issues: []string{
+				pubKey1 + ": attestation 1->5 surrounds attestation 2->3",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issues := slashingprotection.Check(interchange(t, genesisValidatorsRoot, test.data))
+			res := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				res = append(res, issue.String())
+			}
+			require.Equal(t, strings.Join(test.issues, "\n"), strings.Join(res, "\n"))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// StoreAccount stores an account.  It will fail if it cannot store the data.
+// Note this will overwrite an existing account with the same ID.
+func (s *Store) StoreAccount(walletID uuid.UUID, accountID uuid.UUID, data []byte) error {
+	// Ensure the wallet exists.
+	if _, err := s.RetrieveWalletByID(walletID); err != nil {
+		return errors.New("unknown wallet")
+	}
+
+	// See if an account with this ID already exists.
+	existingAccount, err := s.RetrieveAccount(walletID, accountID)
+	if err == nil {
+		// It does; they need to have the same ID for us to overwrite it.
+		info := &struct {
+			ID string `json:"uuid"`
+		}{}
+		if err := json.Unmarshal(existingAccount, info); err != nil {
+			return err
+		}
+		if info.ID != accountID.String() {
+			return errors.New("account already exists")
+		}
+	}
+
+	data, err = s.encryptIfRequired(data)
+	if err != nil {
+		return err
+	}
+	if err := s.write(context.Background(), accountKey(walletID, accountID), data); err != nil {
+		return errors.Wrap(err, "failed to store key")
+	}
+
+	return nil
+}
+
+// RetrieveAccount retrieves account-level data.  It will fail if it cannot retrieve the data.
+func (s *Store) RetrieveAccount(walletID uuid.UUID, accountID uuid.UUID) ([]byte, error) {
+	data, err := s.read(context.Background(), accountKey(walletID, accountID))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decryptIfRequired(data)
+}
+
+// RetrieveAccounts retrieves all account-level data for a wallet.
+func (s *Store) RetrieveAccounts(walletID uuid.UUID) <-chan []byte {
+	ch := make(chan []byte, 1024)
+	go func() {
+		defer close(ch)
+		ctx := context.Background()
+		keys, err := s.list(ctx, fmt.Sprintf("%s/", walletID))
+		if err != nil {
+			return
+		}
+		for _, key := range keys {
+			if strings.HasSuffix(key, "/") {
+				// Directory.
+				continue
+			}
+			accountID, err := uuid.Parse(key)
+			if err != nil {
+				// Index or batch object.
+				continue
+			}
+			if accountID == walletID {
+				// Wallet object.
+				continue
+			}
+			data, err := s.RetrieveAccount(walletID, accountID)
+			if err != nil {
+				continue
+			}
+			ch <- data
+		}
+	}()
+
+	return ch
+}
+
+// accountKey is the key of an account.
+func accountKey(walletID uuid.UUID, accountID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s", walletID, accountID)
+}
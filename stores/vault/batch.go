@@ -0,0 +1,50 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// StoreBatch stores wallet batch data.  It will fail if it cannot store the data.
+func (s *Store) StoreBatch(ctx context.Context, walletID uuid.UUID, _ string, data []byte) error {
+	data, err := s.encryptIfRequired(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt batch")
+	}
+	if err := s.write(ctx, batchKey(walletID), data); err != nil {
+		return errors.Wrap(err, "failed to store batch")
+	}
+
+	return nil
+}
+
+// RetrieveBatch retrieves the batch of accounts for a given wallet.
+func (s *Store) RetrieveBatch(ctx context.Context, walletID uuid.UUID) ([]byte, error) {
+	data, err := s.read(ctx, batchKey(walletID))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve batch")
+	}
+
+	return s.decryptIfRequired(data)
+}
+
+// batchKey is the key of a wallet's account batch.
+func batchKey(walletID uuid.UUID) string {
+	return fmt.Sprintf("%s/batch", walletID)
+}
@@ -0,0 +1,184 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// valueField is the field of the KV secret that holds the stored data.
+const valueField = "value"
+
+// errNotFound is returned when the requested item does not exist.
+var errNotFound = errors.New("not found")
+
+type readResponse struct {
+	Data struct {
+		Data map[string]string `json:"data"`
+	} `json:"data"`
+}
+
+type writeRequest struct {
+	Data map[string]string `json:"data"`
+}
+
+type listResponse struct {
+	Data struct {
+		Keys []string `json:"keys"`
+	} `json:"data"`
+}
+
+type appRoleLoginRequest struct {
+	RoleID   string `json:"role_id"`
+	SecretID string `json:"secret_id"`
+}
+
+type loginResponse struct {
+	Auth struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+}
+
+type errorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// loginAppRole obtains a token using AppRole authentication.
+func (s *Store) loginAppRole(ctx context.Context, mount string, roleID string, secretID string) error {
+	res := &loginResponse{}
+	if err := s.call(ctx, http.MethodPost, fmt.Sprintf("auth/%s/login", strings.Trim(mount, "/")), &appRoleLoginRequest{
+		RoleID:   roleID,
+		SecretID: secretID,
+	}, res); err != nil {
+		return errors.Wrap(err, "failed to log in with AppRole")
+	}
+	if res.Auth.ClientToken == "" {
+		return errors.New("AppRole login did not return a token")
+	}
+	s.token = res.Auth.ClientToken
+
+	return nil
+}
+
+// read reads the data at the given key.
+func (s *Store) read(ctx context.Context, key string) ([]byte, error) {
+	res := &readResponse{}
+	if err := s.call(ctx, http.MethodGet, s.dataPath(key), nil, res); err != nil {
+		return nil, err
+	}
+	value, exists := res.Data.Data[valueField]
+	if !exists {
+		return nil, fmt.Errorf("%s has no %s field", key, valueField)
+	}
+	data, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid data at %s", key))
+	}
+
+	return data, nil
+}
+
+// write writes the data to the given key.
+func (s *Store) write(ctx context.Context, key string, data []byte) error {
+	return s.call(ctx, http.MethodPost, s.dataPath(key), &writeRequest{
+		Data: map[string]string{
+			valueField: base64.StdEncoding.EncodeToString(data),
+		},
+	}, nil)
+}
+
+// list lists the keys under the given key.  Keys that have children end with "/".
+func (s *Store) list(ctx context.Context, key string) ([]string, error) {
+	res := &listResponse{}
+	if err := s.call(ctx, "LIST", s.metadataPath(key), nil, res); err != nil {
+		if errors.Is(err, errNotFound) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	return res.Data.Keys, nil
+}
+
+func (s *Store) dataPath(key string) string {
+	return fmt.Sprintf("%s/data/%s/%s", s.mount, s.path, key)
+}
+
+func (s *Store) metadataPath(key string) string {
+	return strings.TrimSuffix(fmt.Sprintf("%s/metadata/%s/%s", s.mount, s.path, key), "/")
+}
+
+// call calls the Vault API.
+func (s *Store) call(ctx context.Context, method string, path string, body any, res any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal request")
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1/%s", s.address, path), reader)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.token != "" {
+		req.Header.Set("X-Vault-Token", s.token)
+	}
+	if s.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.namespace)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "request to vault failed")
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response")
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errNotFound
+	case resp.StatusCode >= http.StatusMultipleChoices:
+		errRes := &errorResponse{}
+		if err := json.Unmarshal(data, errRes); err == nil && len(errRes.Errors) > 0 {
+			return fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(errRes.Errors, "; "))
+		}
+		return fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	if res != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, res); err != nil {
+			return errors.Wrap(err, "invalid response")
+		}
+	}
+
+	return nil
+}
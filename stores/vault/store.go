@@ -0,0 +1,203 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vault provides a wallet store that holds wallets and accounts in a
+// HashiCorp Vault-compatible KV version 2 secrets engine.
+package vault
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// options are the options for the Vault store.
+type options struct {
+	address       string
+	mount         string
+	path          string
+	namespace     string
+	token         string
+	appRoleMount  string
+	appRoleID     string
+	appRoleSecret string
+	passphrase    []byte
+	timeout       time.Duration
+}
+
+// Option gives options to New.
+type Option interface {
+	apply(*options)
+}
+
+type optionFunc func(*options)
+
+func (f optionFunc) apply(o *options) {
+	f(o)
+}
+
+// WithAddress sets the address of the Vault server, for example "https://vault.example.com:8200".
+func WithAddress(address string) Option {
+	return optionFunc(func(o *options) {
+		o.address = address
+	})
+}
+
+// WithMount sets the mount point of the KV version 2 secrets engine.
+// This defaults to "secret", and cannot be overridden by an empty string.
+func WithMount(mount string) Option {
+	return optionFunc(func(o *options) {
+		if mount != "" {
+			o.mount = mount
+		}
+	})
+}
+
+// WithPath sets the path inside the secrets engine in which to place wallets.
+// This defaults to "ethdo", and cannot be overridden by an empty string.
+func WithPath(path string) Option {
+	return optionFunc(func(o *options) {
+		if path != "" {
+			o.path = path
+		}
+	})
+}
+
+// WithNamespace sets the Vault namespace.
+func WithNamespace(namespace string) Option {
+	return optionFunc(func(o *options) {
+		o.namespace = namespace
+	})
+}
+
+// WithToken sets the token with which to authenticate.
+func WithToken(token string) Option {
+	return optionFunc(func(o *options) {
+		o.token = token
+	})
+}
+
+// WithAppRole sets the AppRole role ID and secret ID with which to authenticate.
+func WithAppRole(roleID string, secretID string) Option {
+	return optionFunc(func(o *options) {
+		o.appRoleID = roleID
+		o.appRoleSecret = secretID
+	})
+}
+
+// WithAppRoleMount sets the mount point of the AppRole authentication method.
+// This defaults to "approle", and cannot be overridden by an empty string.
+func WithAppRoleMount(mount string) Option {
+	return optionFunc(func(o *options) {
+		if mount != "" {
+			o.appRoleMount = mount
+		}
+	})
+}
+
+// WithPassphrase sets the passphrase used to encrypt data written to the store.
+func WithPassphrase(passphrase []byte) Option {
+	return optionFunc(func(o *options) {
+		o.passphrase = passphrase
+	})
+}
+
+// WithTimeout sets the timeout for requests to the Vault server.
+func WithTimeout(timeout time.Duration) Option {
+	return optionFunc(func(o *options) {
+		if timeout != 0 {
+			o.timeout = timeout
+		}
+	})
+}
+
+// Store is the store for wallets held in a Vault KV version 2 secrets engine.
+type Store struct {
+	client     *http.Client
+	address    string
+	mount      string
+	path       string
+	namespace  string
+	token      string
+	passphrase []byte
+}
+
+// New creates a new Vault store.
+// This takes the following options:
+//   - address: the address of the Vault server, set with WithAddress()
+//   - mount: the mount point of the KV version 2 secrets engine, defaults to "secret", set with WithMount()
+//   - path: a path inside the secrets engine in which to place wallets, defaults to "ethdo", set with WithPath()
+//   - namespace: the Vault namespace, defaults to none, set with WithNamespace()
+//   - token: a token with which to authenticate, set with WithToken()
+//   - AppRole: a role ID and secret ID with which to authenticate in place of a token, set with WithAppRole()
+//   - passphrase: a key used to encrypt all data written to the store, defaults to blank and no additional encryption
+//   - timeout: the timeout for requests to the Vault server, defaults to 30 seconds, set with WithTimeout()
+func New(opts ...Option) (wtypes.Store, error) {
+	options := options{
+		mount:        "secret",
+		path:         "ethdo",
+		appRoleMount: "approle",
+		timeout:      30 * time.Second,
+	}
+	for _, o := range opts {
+		o.apply(&options)
+	}
+
+	if options.address == "" {
+		return nil, errors.New("no address specified")
+	}
+	address, err := url.Parse(options.address)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid address")
+	}
+	if address.Scheme != "http" && address.Scheme != "https" {
+		return nil, errors.New("address must be http or https")
+	}
+	if options.token == "" && options.appRoleID == "" {
+		return nil, errors.New("no token or AppRole credentials specified")
+	}
+
+	s := &Store{
+		client:     &http.Client{Timeout: options.timeout},
+		address:    strings.TrimSuffix(options.address, "/"),
+		mount:      strings.Trim(options.mount, "/"),
+		path:       strings.Trim(options.path, "/"),
+		namespace:  options.namespace,
+		token:      options.token,
+		passphrase: options.passphrase,
+	}
+
+	if s.token == "" {
+		if err := s.loginAppRole(context.Background(), options.appRoleMount, options.appRoleID, options.appRoleSecret); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Name returns the name of this store.
+func (*Store) Name() string {
+	return "vault"
+}
+
+// Location returns the location of this store.
+func (s *Store) Location() string {
+	return fmt.Sprintf("%s/v1/%s/data/%s", s.address, s.mount, s.path)
+}
@@ -0,0 +1,235 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/stores/vault"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// stubServer is a minimal implementation of a Vault KV version 2 secrets engine.
+type stubServer struct {
+	mu      sync.Mutex
+	token   string
+	secrets map[string]map[string]string
+}
+
+func newStubServer(t *testing.T) (*stubServer, *httptest.Server) {
+	t.Helper()
+
+	stub := &stubServer{
+		token:   "test-token",
+		secrets: make(map[string]map[string]string),
+	}
+	server := httptest.NewServer(http.HandlerFunc(stub.handle))
+	t.Cleanup(server.Close)
+
+	return stub, server
+}
+
+func (s *stubServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	if path == "auth/approle/login" {
+		req := make(map[string]string)
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req["role_id"] != "role" || req["secret_id"] != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errors":["invalid role or secret ID"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"` + s.token + `"}}`))
+		return
+	}
+
+	if r.Header.Get("X-Vault-Token") != s.token {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+
+	switch {
+	case r.Method == "LIST" && strings.HasPrefix(path, "secret/metadata/"):
+		prefix := strings.TrimPrefix(path, "secret/metadata/") + "/"
+		keys := make(map[string]bool)
+		for key := range s.secrets {
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			rest := strings.TrimPrefix(key, prefix)
+			if i := strings.Index(rest, "/"); i != -1 {
+				rest = rest[:i+1]
+			}
+			keys[rest] = true
+		}
+		if len(keys) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		res := make([]string, 0, len(keys))
+		for key := range keys {
+			res = append(res, key)
+		}
+		sort.Strings(res)
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"keys": res}})
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "secret/data/"):
+		data, exists := s.secrets[strings.TrimPrefix(path, "secret/data/")]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"data": data}})
+	case (r.Method == http.MethodPost || r.Method == http.MethodPut) && strings.HasPrefix(path, "secret/data/"):
+		req := struct {
+			Data map[string]string `json:"data"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.secrets[strings.TrimPrefix(path, "secret/data/")] = req.Data
+		_, _ = w.Write([]byte(`{"data":{"version":1}}`))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, server := newStubServer(t)
+
+	tests := []struct {
+		name string
+		opts []vault.Option
+		err  string
+	}{
+		{
+			name: "AddressMissing",
+			opts: []vault.Option{vault.WithToken("test-token")},
+			err:  "no address specified",
+		},
+		{
+			name: "AddressInvalid",
+			opts: []vault.Option{vault.WithAddress("ftp://localhost"), vault.WithToken("test-token")},
+			err:  "address must be http or https",
+		},
+		{
+			name: "CredentialsMissing",
+			opts: []vault.Option{vault.WithAddress(server.URL)},
+			err:  "no token or AppRole credentials specified",
+		},
+		{
+			name: "AppRoleInvalid",
+			opts: []vault.Option{vault.WithAddress(server.URL), vault.WithAppRole("role", "bad")},
+			err:  "failed to log in with AppRole: vault returned status 400: invalid role or secret ID",
+		},
+		{
+			name: "Token",
+			opts: []vault.Option{vault.WithAddress(server.URL), vault.WithToken("test-token")},
+		},
+		{
+			name: "AppRole",
+			opts: []vault.Option{vault.WithAddress(server.URL), vault.WithAppRole("role", "secret")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store, err := vault.New(test.opts...)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "vault", store.Name())
+			require.Equal(t, server.URL+"/v1/secret/data/ethdo", store.(e2wtypes.StoreLocationProvider).Location())
+		})
+	}
+}
+
+func TestWallets(t *testing.T) {
+	require.NoError(t, e2types.InitBLS())
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts []vault.Option
+	}{
+		{
+			name: "Token",
+			opts: []vault.Option{vault.WithToken("test-token")},
+		},
+		{
+			name: "AppRole",
+			opts: []vault.Option{vault.WithAppRole("role", "secret")},
+		},
+		{
+			name: "Passphrase",
+			opts: []vault.Option{vault.WithToken("test-token"), vault.WithPassphrase([]byte("store secret"))},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stub, server := newStubServer(t)
+			store, err := vault.New(append(test.opts, vault.WithAddress(server.URL))...)
+			require.NoError(t, err)
+
+			wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+			require.NoError(t, err)
+			require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+			account, err := wallet.(e2wtypes.WalletAccountCreator).CreateAccount(ctx, "Test account", []byte("pass"))
+			require.NoError(t, err)
+			require.Contains(t, stub.secrets, "ethdo/"+wallet.ID().String()+"/"+account.ID().String())
+
+			// Wallet names clash.
+			_, err = nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+			require.EqualError(t, err, `wallet "Test wallet" already exists`)
+
+			// Reopen the wallet and find the account.
+			wallet, err = nd.OpenWallet(ctx, "Test wallet", store, keystorev4.New())
+			require.NoError(t, err)
+			accounts := 0
+			for walletAccount := range wallet.Accounts(ctx) {
+				require.Equal(t, account.ID(), walletAccount.ID())
+				accounts++
+			}
+			require.Equal(t, 1, accounts)
+			_, err = wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, "Test account")
+			require.NoError(t, err)
+
+			wallets := 0
+			for range store.RetrieveWallets() {
+				wallets++
+			}
+			require.Equal(t, 1, wallets)
+
+			_, err = store.RetrieveWallet("Unknown")
+			require.EqualError(t, err, "wallet not found")
+		})
+	}
+}
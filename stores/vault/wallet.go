@@ -0,0 +1,99 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// StoreWallet stores wallet-level data.  It will fail if it cannot store the data.
+// Note that this will overwrite any existing data; it is up to higher-level functions to check for the presence of a wallet with
+// the wallet name and handle clashes accordingly.
+func (s *Store) StoreWallet(id uuid.UUID, _ string, data []byte) error {
+	data, err := s.encryptIfRequired(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt wallet")
+	}
+	if err := s.write(context.Background(), walletHeaderKey(id), data); err != nil {
+		return errors.Wrap(err, "failed to store wallet")
+	}
+
+	return nil
+}
+
+// RetrieveWallet retrieves wallet-level data.  It will fail if it cannot retrieve the data.
+func (s *Store) RetrieveWallet(walletName string) ([]byte, error) {
+	for data := range s.RetrieveWallets() {
+		info := &struct {
+			Name string `json:"name"`
+		}{}
+		err := json.Unmarshal(data, info)
+		if err == nil && info.Name == walletName {
+			return data, nil
+		}
+	}
+
+	return nil, errors.New("wallet not found")
+}
+
+// RetrieveWalletByID retrieves wallet-level data.  It will fail if it cannot retrieve the data.
+func (s *Store) RetrieveWalletByID(walletID uuid.UUID) ([]byte, error) {
+	data, err := s.read(context.Background(), walletHeaderKey(walletID))
+	if err != nil {
+		return nil, errors.New("wallet not found")
+	}
+
+	return s.decryptIfRequired(data)
+}
+
+// RetrieveWallets retrieves wallet-level data for all wallets.
+func (s *Store) RetrieveWallets() <-chan []byte {
+	ch := make(chan []byte, 1024)
+	go func() {
+		defer close(ch)
+		ctx := context.Background()
+		keys, err := s.list(ctx, "")
+		if err != nil {
+			return
+		}
+		for _, key := range keys {
+			// Wallets are held in their own directory, named for their ID.
+			if !strings.HasSuffix(key, "/") {
+				continue
+			}
+			walletID, err := uuid.Parse(strings.TrimSuffix(key, "/"))
+			if err != nil {
+				continue
+			}
+			data, err := s.RetrieveWalletByID(walletID)
+			if err != nil {
+				continue
+			}
+			ch <- data
+		}
+	}()
+
+	return ch
+}
+
+// walletHeaderKey is the key of the wallet header.
+func walletHeaderKey(walletID uuid.UUID) string {
+	return fmt.Sprintf("%s/%s", walletID, walletID)
+}
@@ -110,6 +110,26 @@ func (m *SlotsPerEpochProvider) SlotsPerEpoch(_ context.Context) (uint64, error)
 	return m.slotsPerEpoch, nil
 }
 
+// BeaconCommitteesProvider is a mock for eth2client.BeaconCommitteesProvider.
+type BeaconCommitteesProvider struct {
+	committees []*apiv1.BeaconCommittee
+}
+
+// NewBeaconCommitteesProvider returns a mock beacon committees provider with the provided values.
+func NewBeaconCommitteesProvider(committees []*apiv1.BeaconCommittee) eth2client.BeaconCommitteesProvider {
+	return &BeaconCommitteesProvider{
+		committees: committees,
+	}
+}
+
+// BeaconCommittees is a mock.
+func (m *BeaconCommitteesProvider) BeaconCommittees(_ context.Context, _ *api.BeaconCommitteesOpts) (*api.Response[[]*apiv1.BeaconCommittee], error) {
+	return &api.Response[[]*apiv1.BeaconCommittee]{
+		Data:     m.committees,
+		Metadata: make(map[string]any),
+	}, nil
+}
+
 // AttestationsSubmitter is a mock for eth2client.AttestationsSubmitter.
 type AttestationsSubmitter struct{}
 
@@ -119,7 +139,7 @@ func NewAttestationSubmitter() eth2client.AttestationsSubmitter {
 }
 
 // SubmitAttestations is a mock.
-func (m *AttestationsSubmitter) SubmitAttestations(_ context.Context, _ []*phase0.Attestation) error {
+func (m *AttestationsSubmitter) SubmitAttestations(_ context.Context, _ *api.SubmitAttestationsOpts) error {
 	return nil
 }
 
@@ -145,7 +165,7 @@ func NewAggregateAttestationsSubmitter() eth2client.AggregateAttestationsSubmitt
 }
 
 // SubmitAggregateAttestations is a mock.
-func (m *AggregateAttestationsSubmitter) SubmitAggregateAttestations(_ context.Context, _ []*phase0.SignedAggregateAndProof) error {
+func (m *AggregateAttestationsSubmitter) SubmitAggregateAttestations(_ context.Context, _ *api.SubmitAggregateAttestationsOpts) error {
 	return nil
 }
 
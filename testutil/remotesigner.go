@@ -0,0 +1,62 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testutil
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+)
+
+// NewRemoteSigner creates a stub Web3Signer-compatible remote signer that holds the supplied keys.
+// The stub signs the supplied signing root without checking it against the request; it should only be used for tests.
+func NewRemoteSigner(keys ...e2types.PrivateKey) *httptest.Server {
+	signers := make(map[string]e2types.PrivateKey, len(keys))
+	for _, key := range keys {
+		signers[fmt.Sprintf("%#x", key.PublicKey().Marshal())] = key
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/api/v1/eth2/sign/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		key, exists := signers[strings.TrimPrefix(r.URL.Path, "/api/v1/eth2/sign/")]
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		req := &struct {
+			SigningRoot string `json:"signingRoot"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		signingRoot, err := hex.DecodeString(strings.TrimPrefix(req.SigningRoot, "0x"))
+		if err != nil || len(signingRoot) != 32 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"signature":"%#x"}`, key.Sign(signingRoot).Marshal())
+	}))
+}
@@ -16,20 +16,88 @@ package util
 import (
 	"bytes"
 	"context"
+	"fmt"
 
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/wealdtech/ethdo/services/chaintime"
 )
 
+// AttestationCommitteeBits returns the aggregation bits of the attestation for each committee that it covers.
+// Prior to Electra an attestation covers the single committee in its data.  From Electra an attestation can
+// cover multiple committees, flagged in its committee bits, with the aggregation bits of each committee
+// concatenated in committee index order.
+func AttestationCommitteeBits(ctx context.Context,
+	committeesCache *BeaconCommitteesCache,
+	attestation *spec.VersionedAttestation,
+) (
+	map[phase0.CommitteeIndex]bitfield.Bitlist,
+	error,
+) {
+	data, err := attestation.Data()
+	if err != nil {
+		return nil, err
+	}
+	aggregationBits, err := attestation.AggregationBits()
+	if err != nil {
+		return nil, err
+	}
+
+	if attestation.Version < spec.DataVersionElectra {
+		return map[phase0.CommitteeIndex]bitfield.Bitlist{data.Index: aggregationBits}, nil
+	}
+
+	committeeBits, err := attestation.CommitteeBits()
+	if err != nil {
+		return nil, err
+	}
+	committees, err := committeesCache.Fetch(ctx, data.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[phase0.CommitteeIndex]bitfield.Bitlist)
+	offset := uint64(0)
+	for _, index := range committeeBits.BitIndices() {
+		committeeIndex := phase0.CommitteeIndex(index)
+		committee, exists := committees[committeeIndex]
+		if !exists {
+			return nil, fmt.Errorf("no committee %d at slot %d", committeeIndex, data.Slot)
+		}
+		committeeSize := uint64(len(committee))
+		if offset+committeeSize > aggregationBits.Len() {
+			return nil, fmt.Errorf("aggregation bits too short for committees at slot %d", data.Slot)
+		}
+		bits := bitfield.NewBitlist(committeeSize)
+		for i := range committeeSize {
+			if aggregationBits.BitAt(offset + i) {
+				bits.SetBitAt(i, true)
+			}
+		}
+		res[committeeIndex] = bits
+		offset += committeeSize
+	}
+	if offset != aggregationBits.Len() {
+		return nil, fmt.Errorf("aggregation bits do not match committees at slot %d", data.Slot)
+	}
+
+	return res, nil
+}
+
 // AttestationHead returns the head for which the attestation should have voted.
 func AttestationHead(ctx context.Context,
 	headersCache *BeaconBlockHeaderCache,
-	attestation *phase0.Attestation,
+	attestation *spec.VersionedAttestation,
 ) (
 	phase0.Root,
 	error,
 ) {
-	slot := attestation.Data.Slot
+	data, err := attestation.Data()
+	if err != nil {
+		return phase0.Root{}, err
+	}
+	slot := data.Slot
 	for {
 		header, err := headersCache.Fetch(ctx, slot)
 		if err != nil {
@@ -53,12 +121,16 @@ func AttestationHead(ctx context.Context,
 // AttestationHeadCorrect returns true if the given attestation had the correct head.
 func AttestationHeadCorrect(ctx context.Context,
 	headersCache *BeaconBlockHeaderCache,
-	attestation *phase0.Attestation,
+	attestation *spec.VersionedAttestation,
 ) (
 	bool,
 	error,
 ) {
-	slot := attestation.Data.Slot
+	data, err := attestation.Data()
+	if err != nil {
+		return false, err
+	}
+	slot := data.Slot
 	for {
 		header, err := headersCache.Fetch(ctx, slot)
 		if err != nil {
@@ -74,7 +146,7 @@ func AttestationHeadCorrect(ctx context.Context,
 			slot--
 			continue
 		}
-		return bytes.Equal(header.Root[:], attestation.Data.BeaconBlockRoot[:]), nil
+		return bytes.Equal(header.Root[:], data.BeaconBlockRoot[:]), nil
 	}
 }
 
@@ -82,13 +154,17 @@ func AttestationHeadCorrect(ctx context.Context,
 func AttestationTarget(ctx context.Context,
 	headersCache *BeaconBlockHeaderCache,
 	chainTime chaintime.Service,
-	attestation *phase0.Attestation,
+	attestation *spec.VersionedAttestation,
 ) (
 	phase0.Root,
 	error,
 ) {
+	data, err := attestation.Data()
+	if err != nil {
+		return phase0.Root{}, err
+	}
 	// Start with first slot of the target epoch.
-	slot := chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch)
+	slot := chainTime.FirstSlotOfEpoch(data.Target.Epoch)
 	for {
 		header, err := headersCache.Fetch(ctx, slot)
 		if err != nil {
@@ -113,13 +189,17 @@ func AttestationTarget(ctx context.Context,
 func AttestationTargetCorrect(ctx context.Context,
 	headersCache *BeaconBlockHeaderCache,
 	chainTime chaintime.Service,
-	attestation *phase0.Attestation,
+	attestation *spec.VersionedAttestation,
 ) (
 	bool,
 	error,
 ) {
+	data, err := attestation.Data()
+	if err != nil {
+		return false, err
+	}
 	// Start with first slot of the target epoch.
-	slot := chainTime.FirstSlotOfEpoch(attestation.Data.Target.Epoch)
+	slot := chainTime.FirstSlotOfEpoch(data.Target.Epoch)
 	for {
 		header, err := headersCache.Fetch(ctx, slot)
 		if err != nil {
@@ -135,6 +215,6 @@ func AttestationTargetCorrect(ctx context.Context,
 			slot--
 			continue
 		}
-		return bytes.Equal(header.Root[:], attestation.Data.Target.Root[:]), nil
+		return bytes.Equal(header.Root[:], data.Target.Root[:]), nil
 	}
 }
@@ -0,0 +1,156 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"testing"
+
+	apiv1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testing/mock"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func bitlist(size uint64, bits ...uint64) bitfield.Bitlist {
+	res := bitfield.NewBitlist(size)
+	for _, bit := range bits {
+		res.SetBitAt(bit, true)
+	}
+
+	return res
+}
+
+func TestAttestationCommitteeBits(t *testing.T) {
+	ctx := context.Background()
+
+	committeesCache := util.NewBeaconCommitteesCache(mock.NewBeaconCommitteesProvider([]*apiv1.BeaconCommittee{
+		{Slot: 100, Index: 0, Validators: []phase0.ValidatorIndex{1, 2, 3}},
+		{Slot: 100, Index: 1, Validators: []phase0.ValidatorIndex{4, 5}},
+		{Slot: 100, Index: 2, Validators: []phase0.ValidatorIndex{6, 7, 8, 9}},
+		{Slot: 101, Index: 0, Validators: []phase0.ValidatorIndex{10, 11}},
+	}))
+
+	electraCommitteeBits := func(indices ...uint64) bitfield.Bitvector64 {
+		res := bitfield.NewBitvector64()
+		for _, index := range indices {
+			res.SetBitAt(index, true)
+		}
+
+		return res
+	}
+
+	tests := []struct {
+		name        string
+		attestation *spec.VersionedAttestation
+		res         map[phase0.CommitteeIndex]bitfield.Bitlist
+		err         string
+	}{
+		{
+			name: "Deneb",
+			attestation: &spec.VersionedAttestation{
+				Version: spec.DataVersionDeneb,
+				Deneb: &phase0.Attestation{
+					AggregationBits: bitlist(2, 1),
+					Data:            &phase0.AttestationData{Slot: 100, Index: 1},
+				},
+			},
+			res: map[phase0.CommitteeIndex]bitfield.Bitlist{
+				1: bitlist(2, 1),
+			},
+		},
+		{
+			name: "ElectraSingleCommittee",
+			attestation: &spec.VersionedAttestation{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.Attestation{
+					AggregationBits: bitlist(4, 0, 3),
+					CommitteeBits:   electraCommitteeBits(2),
+					Data:            &phase0.AttestationData{Slot: 100},
+				},
+			},
+			res: map[phase0.CommitteeIndex]bitfield.Bitlist{
+				2: bitlist(4, 0, 3),
+			},
+		},
+		{
+			name: "ElectraMultipleCommittees",
+			attestation: &spec.VersionedAttestation{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.Attestation{
+					// Committee 0 (3 validators), then committee 2 (4 validators).
+					AggregationBits: bitlist(7, 1, 3, 6),
+					CommitteeBits:   electraCommitteeBits(0, 2),
+					Data:            &phase0.AttestationData{Slot: 100},
+				},
+			},
+			res: map[phase0.CommitteeIndex]bitfield.Bitlist{
+				0: bitlist(3, 1),
+				2: bitlist(4, 0, 3),
+			},
+		},
+		{
+			name: "ElectraUnknownCommittee",
+			attestation: &spec.VersionedAttestation{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.Attestation{
+					AggregationBits: bitlist(2),
+					CommitteeBits:   electraCommitteeBits(1),
+					Data:            &phase0.AttestationData{Slot: 101},
+				},
+			},
+			err: "no committee 1 at slot 101",
+		},
+		{
+			name: "ElectraBitsTooShort",
+			attestation: &spec.VersionedAttestation{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.Attestation{
+					AggregationBits: bitlist(4),
+					CommitteeBits:   electraCommitteeBits(0, 1),
+					Data:            &phase0.AttestationData{Slot: 100},
+				},
+			},
+			err: "aggregation bits too short for committees at slot 100",
+		},
+		{
+			name: "ElectraBitsTooLong",
+			attestation: &spec.VersionedAttestation{
+				Version: spec.DataVersionElectra,
+				Electra: &electra.Attestation{
+					AggregationBits: bitlist(6),
+					CommitteeBits:   electraCommitteeBits(0, 1),
+					Data:            &phase0.AttestationData{Slot: 100},
+				},
+			},
+			err: "aggregation bits do not match committees at slot 100",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.AttestationCommitteeBits(ctx, committeesCache, test.attestation)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.res, res)
+			}
+		})
+	}
+}
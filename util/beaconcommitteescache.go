@@ -0,0 +1,70 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/api"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// BeaconCommitteesCache is a cache of beacon committees.
+type BeaconCommitteesCache struct {
+	beaconCommitteesProvider eth2client.BeaconCommitteesProvider
+	entries                  map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex
+}
+
+// NewBeaconCommitteesCache makes a new beacon committees cache.
+func NewBeaconCommitteesCache(provider eth2client.BeaconCommitteesProvider) *BeaconCommitteesCache {
+	return &BeaconCommitteesCache{
+		beaconCommitteesProvider: provider,
+		entries:                  make(map[phase0.Slot]map[phase0.CommitteeIndex][]phase0.ValidatorIndex),
+	}
+}
+
+// Fetch the beacon committees for the given slot.
+func (b *BeaconCommitteesCache) Fetch(ctx context.Context,
+	slot phase0.Slot,
+) (
+	map[phase0.CommitteeIndex][]phase0.ValidatorIndex,
+	error,
+) {
+	committees, exists := b.entries[slot]
+	if !exists {
+		// Committees are returned for the entire epoch, so cache them all.
+		response, err := b.beaconCommitteesProvider.BeaconCommittees(ctx, &api.BeaconCommitteesOpts{
+			State: fmt.Sprintf("%d", slot),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, beaconCommittee := range response.Data {
+			if _, exists := b.entries[beaconCommittee.Slot]; !exists {
+				b.entries[beaconCommittee.Slot] = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+			}
+			b.entries[beaconCommittee.Slot][beaconCommittee.Index] = beaconCommittee.Validators
+		}
+
+		committees, exists = b.entries[slot]
+		if !exists {
+			committees = make(map[phase0.CommitteeIndex][]phase0.ValidatorIndex)
+			b.entries[slot] = committees
+		}
+	}
+
+	return committees, nil
+}
@@ -1,4 +1,4 @@
-// Copyright © 2020, 2023 Weald Technology Trading
+// Copyright © 2020 - 2024 Weald Technology Trading
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
@@ -16,13 +16,17 @@ package util
 import (
 	"context"
 	"fmt"
+	"net"
+	nethttp "net/http"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/http"
+	"github.com/attestantio/go-eth2-client/multi"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 )
@@ -37,11 +41,28 @@ var defaultBeaconNodeAddresses = []string{
 // fallbackBeaconNode is used if no other connection is supplied.
 var fallbackBeaconNode = "http://mainnet-consensus.attestant.io/"
 
+// beaconNodeConfigs are the HTTP configurations of connected beacon nodes, keyed by
+// the address reported by their client, for requests that the client does not support.
+var beaconNodeConfigs sync.Map
+
+// beaconNodeConfig is the HTTP configuration of a connected beacon node.
+type beaconNodeConfig struct {
+	address string
+	timeout time.Duration
+	client  *nethttp.Client
+}
+
+// ConnectOpts are the options for connecting to beacon nodes.
+// Address can contain multiple comma-separated addresses, in which case
+// requests will fail over between the nodes.
+// LogFallback logs fallbacks to stderr, including beacon nodes that cannot be reached.
+// Quorum, if set, is the number of beacon nodes to which connections must succeed.
 type ConnectOpts struct {
 	Address       string
 	Timeout       time.Duration
 	AllowInsecure bool
 	LogFallback   bool
+	Quorum        int
 }
 
 // ConnectToBeaconNode connects to a beacon node at the given address.
@@ -54,9 +75,29 @@ func ConnectToBeaconNode(ctx context.Context, opts *ConnectOpts) (eth2client.Ser
 		return nil, errors.New("no timeout specified")
 	}
 
-	if opts.Address != "" {
+	addresses := ParseAddresses(opts.Address)
+	switch len(addresses) {
+	case 0:
+		// No explicit address; fall through to the defaults.
+	case 1:
 		// We have an explicit address; use it.
-		return connectToBeaconNode(ctx, opts.Address, opts.Timeout, opts.AllowInsecure)
+		return connectToBeaconNode(ctx, addresses[0], opts.Timeout, opts.AllowInsecure)
+	default:
+		// We have multiple addresses; connect to them all and fail over between them.
+		clients, err := connectToBeaconNodes(ctx, addresses, opts)
+		if err != nil {
+			return nil, err
+		}
+		client, err := multi.New(ctx,
+			multi.WithLogLevel(zerolog.Disabled),
+			multi.WithTimeout(opts.Timeout),
+			multi.WithClients(clients),
+		)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create multi-node client")
+		}
+
+		return client, nil
 	}
 
 	// Try the defaults.
@@ -79,6 +120,66 @@ func ConnectToBeaconNode(ctx context.Context, opts *ConnectOpts) (eth2client.Ser
 	return nil, errors.New("failed to connect to any beacon node")
 }
 
+// ConnectToBeaconNodes connects to each of the beacon nodes at the given addresses
+// individually, for use when results from separate nodes need to be compared.
+func ConnectToBeaconNodes(ctx context.Context, opts *ConnectOpts) ([]eth2client.Service, error) {
+	if opts == nil {
+		return nil, errors.New("no options specified")
+	}
+
+	if opts.Timeout == 0 {
+		return nil, errors.New("no timeout specified")
+	}
+
+	addresses := ParseAddresses(opts.Address)
+	if len(addresses) == 0 {
+		client, err := ConnectToBeaconNode(ctx, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return []eth2client.Service{client}, nil
+	}
+
+	return connectToBeaconNodes(ctx, addresses, opts)
+}
+
+// ParseAddresses parses a comma-separated list of beacon node addresses.
+func ParseAddresses(input string) []string {
+	addresses := make([]string, 0)
+	for _, address := range strings.Split(input, ",") {
+		address = strings.TrimSpace(address)
+		if address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+
+	return addresses
+}
+
+func connectToBeaconNodes(ctx context.Context, addresses []string, opts *ConnectOpts) ([]eth2client.Service, error) {
+	// Nodes that cannot be reached are skipped, as long as enough are available.
+	clients := make([]eth2client.Service, 0, len(addresses))
+	for _, address := range addresses {
+		client, err := connectToBeaconNode(ctx, address, opts.Timeout, opts.AllowInsecure)
+		if err != nil {
+			if opts.LogFallback {
+				fmt.Fprintf(os.Stderr, "Failed to connect to beacon node %s: %v\n", address, err)
+			}
+			continue
+		}
+		clients = append(clients, client)
+	}
+	if len(clients) == 0 {
+		return nil, errors.New("failed to connect to any beacon node")
+	}
+	if opts.Quorum > len(clients) {
+		return nil, fmt.Errorf("quorum of %d requested but only %d of %d beacon nodes available", opts.Quorum, len(clients), len(addresses))
+	}
+
+	return clients, nil
+}
+
 func connectToBeaconNode(ctx context.Context, address string, timeout time.Duration, allowInsecure bool) (eth2client.Service, error) {
 	if !strings.HasPrefix(address, "http") {
 		address = fmt.Sprintf("http://%s", address)
@@ -106,5 +207,22 @@ func connectToBeaconNode(ctx context.Context, address string, timeout time.Durat
 		return nil, errors.Wrap(err, "failed to connect to beacon node")
 	}
 
+	// The client masks sensitive parts of its address, so keep the original for direct requests.
+	beaconNodeConfigs.Store(eth2Client.Address(), &beaconNodeConfig{
+		address: address,
+		timeout: timeout,
+		client: &nethttp.Client{
+			Transport: &nethttp.Transport{
+				Proxy: nethttp.ProxyFromEnvironment,
+				DialContext: (&net.Dialer{
+					Timeout:   timeout,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				MaxIdleConnsPerHost: 16,
+				IdleConnTimeout:     600 * time.Second,
+			},
+		},
+	})
+
 	return eth2Client, nil
 }
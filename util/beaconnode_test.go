@@ -0,0 +1,110 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// newBeaconNode returns a server that responds to the requests used to check a beacon node connection.
+func newBeaconNode(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/eth/v1/node/syncing":
+			fmt.Fprint(w, `{"data":{"head_slot":"1","sync_distance":"0","is_syncing":false,"is_optimistic":false,"el_offline":false}}`)
+		case "/eth/v1/node/version":
+			fmt.Fprint(w, `{"data":{"version":"test/v1.0.0"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestConnectToBeaconNodes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	good1 := newBeaconNode(t).URL
+	good2 := newBeaconNode(t).URL
+	// Nothing listens on port 1.
+	bad := "http://127.0.0.1:1"
+
+	tests := []struct {
+		name    string
+		address string
+		quorum  int
+		clients int
+		err     string
+	}{
+		{
+			name:    "AllGood",
+			address: good1 + "," + good2,
+			quorum:  2,
+			clients: 2,
+		},
+		{
+			name:    "SomeBad",
+			address: good1 + "," + bad + "," + good2,
+			clients: 2,
+		},
+		{
+			name:    "SomeBadQuorumMet",
+			address: good1 + "," + bad + "," + good2,
+			quorum:  2,
+			clients: 2,
+		},
+		{
+			name:    "SomeBadQuorumNotMet",
+			address: good1 + "," + bad,
+			quorum:  2,
+			err:     "quorum of 2 requested but only 1 of 2 beacon nodes available",
+		},
+		{
+			name:    "AllBad",
+			address: bad + "," + bad,
+			err:     "failed to connect to any beacon node",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clients, err := util.ConnectToBeaconNodes(ctx, &util.ConnectOpts{
+				Address:       test.address,
+				Timeout:       time.Second,
+				AllowInsecure: true,
+				Quorum:        test.quorum,
+			})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, clients, test.clients)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/pkg/errors"
+)
+
+// BeaconAPIError is an error returned by a beacon node's REST API.
+type BeaconAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error returns the error as a string.
+func (e *BeaconAPIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("beacon node returned status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("beacon node returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// AttestationReward is the reward, or penalty if negative, in Gwei for a validator's attestation in an epoch.
+type AttestationReward struct {
+	Validator      phase0.ValidatorIndex
+	Head           int64
+	Target         int64
+	Source         int64
+	InclusionDelay int64
+	Inactivity     int64
+}
+
+// BlockReward is the reward in Gwei given to the proposer of a block.
+type BlockReward struct {
+	Proposer          phase0.ValidatorIndex
+	Total             int64
+	Attestations      int64
+	SyncAggregate     int64
+	ProposerSlashings int64
+	AttesterSlashings int64
+}
+
+// SyncCommitteeReward is the reward, or penalty if negative, in Gwei for a validator's sync committee message in a block.
+type SyncCommitteeReward struct {
+	Validator phase0.ValidatorIndex
+	Reward    int64
+}
+
+type beaconAPIErrorJSON struct {
+	Message string `json:"message"`
+}
+
+type attestationRewardJSON struct {
+	ValidatorIndex string `json:"validator_index"`
+	Head           string `json:"head"`
+	Target         string `json:"target"`
+	Source         string `json:"source"`
+	InclusionDelay string `json:"inclusion_delay"`
+	Inactivity     string `json:"inactivity"`
+}
+
+type attestationRewardsJSON struct {
+	Data struct {
+		TotalRewards []*attestationRewardJSON `json:"total_rewards"`
+	} `json:"data"`
+}
+
+type blockRewardJSON struct {
+	ProposerIndex     string `json:"proposer_index"`
+	Total             string `json:"total"`
+	Attestations      string `json:"attestations"`
+	SyncAggregate     string `json:"sync_aggregate"`
+	ProposerSlashings string `json:"proposer_slashings"`
+	AttesterSlashings string `json:"attester_slashings"`
+}
+
+type blockRewardsJSON struct {
+	Data *blockRewardJSON `json:"data"`
+}
+
+type syncCommitteeRewardJSON struct {
+	ValidatorIndex string `json:"validator_index"`
+	Reward         string `json:"reward"`
+}
+
+type syncCommitteeRewardsJSON struct {
+	Data []*syncCommitteeRewardJSON `json:"data"`
+}
+
+// ObtainAttestationRewards obtains the attestation rewards for the given validators in the given epoch
+// from the given beacon node.
+func ObtainAttestationRewards(ctx context.Context,
+	eth2Client eth2client.Service,
+	epoch phase0.Epoch,
+	validators []phase0.ValidatorIndex,
+) (
+	[]*AttestationReward,
+	error,
+) {
+	resp := &attestationRewardsJSON{}
+	if err := beaconAPIPost(ctx, eth2Client, fmt.Sprintf("/eth/v1/beacon/rewards/attestations/%d", epoch), indicesJSON(validators), resp); err != nil {
+		return nil, err
+	}
+
+	res := make([]*AttestationReward, 0, len(resp.Data.TotalRewards))
+	for _, data := range resp.Data.TotalRewards {
+		reward := &AttestationReward{}
+		var err error
+		if reward.Validator, err = parseValidatorIndex(data.ValidatorIndex); err != nil {
+			return nil, err
+		}
+		if reward.Head, err = parseGwei(data.Head); err != nil {
+			return nil, err
+		}
+		if reward.Target, err = parseGwei(data.Target); err != nil {
+			return nil, err
+		}
+		if reward.Source, err = parseGwei(data.Source); err != nil {
+			return nil, err
+		}
+		if reward.InclusionDelay, err = parseGwei(data.InclusionDelay); err != nil {
+			return nil, err
+		}
+		if reward.Inactivity, err = parseGwei(data.Inactivity); err != nil {
+			return nil, err
+		}
+		res = append(res, reward)
+	}
+
+	return res, nil
+}
+
+// ObtainBlockRewards obtains the proposer rewards for the given block from the given beacon node.
+func ObtainBlockRewards(ctx context.Context,
+	eth2Client eth2client.Service,
+	blockID string,
+) (
+	*BlockReward,
+	error,
+) {
+	resp := &blockRewardsJSON{}
+	if err := beaconAPIRequest(ctx, eth2Client, http.MethodGet, fmt.Sprintf("/eth/v1/beacon/rewards/blocks/%s", blockID), nil, resp); err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("no block rewards returned")
+	}
+
+	reward := &BlockReward{}
+	var err error
+	if reward.Proposer, err = parseValidatorIndex(resp.Data.ProposerIndex); err != nil {
+		return nil, err
+	}
+	if reward.Total, err = parseGwei(resp.Data.Total); err != nil {
+		return nil, err
+	}
+	if reward.Attestations, err = parseGwei(resp.Data.Attestations); err != nil {
+		return nil, err
+	}
+	if reward.SyncAggregate, err = parseGwei(resp.Data.SyncAggregate); err != nil {
+		return nil, err
+	}
+	if reward.ProposerSlashings, err = parseGwei(resp.Data.ProposerSlashings); err != nil {
+		return nil, err
+	}
+	if reward.AttesterSlashings, err = parseGwei(resp.Data.AttesterSlashings); err != nil {
+		return nil, err
+	}
+
+	return reward, nil
+}
+
+// ObtainSyncCommitteeRewards obtains the sync committee rewards for the given validators in the given block
+// from the given beacon node.
+func ObtainSyncCommitteeRewards(ctx context.Context,
+	eth2Client eth2client.Service,
+	blockID string,
+	validators []phase0.ValidatorIndex,
+) (
+	[]*SyncCommitteeReward,
+	error,
+) {
+	resp := &syncCommitteeRewardsJSON{}
+	if err := beaconAPIPost(ctx, eth2Client, fmt.Sprintf("/eth/v1/beacon/rewards/sync_committee/%s", blockID), indicesJSON(validators), resp); err != nil {
+		return nil, err
+	}
+
+	res := make([]*SyncCommitteeReward, 0, len(resp.Data))
+	for _, data := range resp.Data {
+		reward := &SyncCommitteeReward{}
+		var err error
+		if reward.Validator, err = parseValidatorIndex(data.ValidatorIndex); err != nil {
+			return nil, err
+		}
+		if reward.Reward, err = parseGwei(data.Reward); err != nil {
+			return nil, err
+		}
+		res = append(res, reward)
+	}
+
+	return res, nil
+}
+
+func beaconAPIPost(ctx context.Context,
+	eth2Client eth2client.Service,
+	path string,
+	body []string,
+	result any,
+) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	return beaconAPIRequest(ctx, eth2Client, http.MethodPost, path, data, result)
+}
+
+// beaconAPIRequest makes a request directly to the beacon node, using the same HTTP
+// configuration as its client.
+func beaconAPIRequest(ctx context.Context,
+	eth2Client eth2client.Service,
+	method string,
+	path string,
+	body []byte,
+	result any,
+) error {
+	value, exists := beaconNodeConfigs.Load(eth2Client.Address())
+	if !exists {
+		return fmt.Errorf("no configuration for beacon node %s", eth2Client.Address())
+	}
+	config, isConfig := value.(*beaconNodeConfig)
+	if !isConfig {
+		return fmt.Errorf("invalid configuration for beacon node %s", eth2Client.Address())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, config.timeout)
+	defer cancel()
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(config.address, "/")+path, reqBody)
+	if err != nil {
+		return errors.Wrap(err, "failed to create HTTP request")
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := config.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to call beacon node")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &BeaconAPIError{
+			StatusCode: resp.StatusCode,
+		}
+		errData := &beaconAPIErrorJSON{}
+		if err := json.NewDecoder(resp.Body).Decode(errData); err == nil {
+			apiErr.Message = errData.Message
+		}
+
+		return apiErr
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Wrap(err, "invalid response from beacon node")
+	}
+
+	return nil
+}
+
+func indicesJSON(validators []phase0.ValidatorIndex) []string {
+	res := make([]string, len(validators))
+	for i := range validators {
+		res[i] = fmt.Sprintf("%d", validators[i])
+	}
+
+	return res
+}
+
+func parseValidatorIndex(input string) (phase0.ValidatorIndex, error) {
+	index, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid validator index %q returned by beacon node", input)
+	}
+
+	return phase0.ValidatorIndex(index), nil
+}
+
+func parseGwei(input string) (int64, error) {
+	if input == "" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseInt(input, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q returned by beacon node", input)
+	}
+
+	return amount, nil
+}
@@ -0,0 +1,170 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// connectRewardsNode connects to a beacon node that passes rewards requests to the given handler.
+func connectRewardsNode(t *testing.T, prefix string, handler http.HandlerFunc) eth2client.Service {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case prefix + "/eth/v1/node/syncing":
+			fmt.Fprint(w, `{"data":{"head_slot":"1","sync_distance":"0","is_syncing":false,"is_optimistic":false,"el_offline":false}}`)
+		case prefix + "/eth/v1/node/version":
+			fmt.Fprint(w, `{"data":{"version":"test/v1.0.0"}}`)
+		default:
+			if !strings.HasPrefix(r.URL.Path, prefix+"/") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			r.URL.Path = strings.TrimPrefix(r.URL.Path, prefix)
+			handler(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	eth2Client, err := util.ConnectToBeaconNode(context.Background(), &util.ConnectOpts{
+		Address:       server.URL + prefix,
+		Timeout:       time.Second,
+		AllowInsecure: true,
+	})
+	require.NoError(t, err)
+
+	return eth2Client
+}
+
+func TestObtainAttestationRewards(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		status   int
+		expected []*util.AttestationReward
+		err      string
+	}{
+		{
+			name:     "Good",
+			response: `{"execution_optimistic":false,"finalized":true,"data":{"ideal_rewards":[],"total_rewards":[{"validator_index":"1","head":"2000","target":"4000","source":"3000","inactivity":"0"},{"validator_index":"2","head":"0","target":"-4000","source":"-3000","inactivity":"-10"}]}}`,
+			status:   http.StatusOK,
+			expected: []*util.AttestationReward{
+				{Validator: 1, Head: 2000, Target: 4000, Source: 3000},
+				{Validator: 2, Target: -4000, Source: -3000, Inactivity: -10},
+			},
+		},
+		{
+			name:     "NotFound",
+			response: `{"code":404,"message":"State not found"}`,
+			status:   http.StatusNotFound,
+			err:      "beacon node returned status 404: State not found",
+		},
+		{
+			name:     "BadAmount",
+			response: `{"data":{"total_rewards":[{"validator_index":"1","head":"bad"}]}}`,
+			status:   http.StatusOK,
+			err:      `invalid amount "bad" returned by beacon node`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			eth2Client := connectRewardsNode(t, "", func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, http.MethodPost, r.Method)
+				require.Equal(t, "/eth/v1/beacon/rewards/attestations/5", r.URL.Path)
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				require.Equal(t, `["1","2"]`, string(body))
+				w.WriteHeader(test.status)
+				fmt.Fprint(w, test.response)
+			})
+
+			rewards, err := util.ObtainAttestationRewards(context.Background(), eth2Client, 5, []phase0.ValidatorIndex{1, 2})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, rewards)
+			}
+		})
+	}
+}
+
+func TestObtainBlockRewards(t *testing.T) {
+	eth2Client := connectRewardsNode(t, "", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/rewards/blocks/100":
+			fmt.Fprint(w, `{"data":{"proposer_index":"7","total":"60","attestations":"40","sync_aggregate":"10","proposer_slashings":"5","attester_slashings":"5"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	reward, err := util.ObtainBlockRewards(context.Background(), eth2Client, "100")
+	require.NoError(t, err)
+	require.Equal(t, &util.BlockReward{
+		Proposer:          7,
+		Total:             60,
+		Attestations:      40,
+		SyncAggregate:     10,
+		ProposerSlashings: 5,
+		AttesterSlashings: 5,
+	}, reward)
+
+	_, err = util.ObtainBlockRewards(context.Background(), eth2Client, "101")
+	var apiErr *util.BeaconAPIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+func TestObtainSyncCommitteeRewards(t *testing.T) {
+	eth2Client := connectRewardsNode(t, "", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"data":[{"validator_index":"3","reward":"25"},{"validator_index":"4","reward":"-25"}]}`)
+	})
+
+	rewards, err := util.ObtainSyncCommitteeRewards(context.Background(), eth2Client, "100", []phase0.ValidatorIndex{3, 4})
+	require.NoError(t, err)
+	require.Equal(t, []*util.SyncCommitteeReward{
+		{Validator: 3, Reward: 25},
+		{Validator: 4, Reward: -25},
+	}, rewards)
+}
+
+func TestObtainRewardsConnectionPath(t *testing.T) {
+	// The path of the connection address is masked by the client, but must still be used for requests.
+	var requested string
+	eth2Client := connectRewardsNode(t, "/secret", func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.Path
+		fmt.Fprint(w, `{"data":[]}`)
+	})
+	require.NotContains(t, eth2Client.Address(), "secret")
+
+	_, err := util.ObtainSyncCommitteeRewards(context.Background(), eth2Client, "100", []phase0.ValidatorIndex{3})
+	require.NoError(t, err)
+	require.Equal(t, "/eth/v1/beacon/rewards/sync_committee/100", requested)
+}
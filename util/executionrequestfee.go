@@ -0,0 +1,113 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type executionRequestFeeCall struct {
+	To   string `json:"to"`
+	Data string `json:"data"`
+}
+
+type jsonRPCRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	Result string        `json:"result"`
+	Error  *jsonRPCError `json:"error"`
+}
+
+type jsonRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ObtainExecutionRequestFee obtains the current fee for an execution layer request
+// (for example an EIP-7002 withdrawal request or EIP-7251 consolidation request)
+// by calling the request contract with no data via the execution node at the given address.
+func ObtainExecutionRequestFee(ctx context.Context,
+	address string,
+	timeout time.Duration,
+	contract []byte,
+) (
+	*big.Int,
+	error,
+) {
+	if !strings.HasPrefix(address, "http") {
+		address = fmt.Sprintf("http://%s", address)
+	}
+
+	body, err := json.Marshal(&jsonRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  "eth_call",
+		Params: []any{
+			&executionRequestFeeCall{
+				To:   fmt.Sprintf("%#x", contract),
+				Data: "0x",
+			},
+			"latest",
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, address, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create HTTP request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to call execution node")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("execution node returned status %d", resp.StatusCode)
+	}
+
+	var rpcResp jsonRPCResponse
+	if err := json.NewDecoder(resp.Body).Decode(&rpcResp); err != nil {
+		return nil, errors.Wrap(err, "invalid response from execution node")
+	}
+	if rpcResp.Error != nil {
+		return nil, fmt.Errorf("execution node returned error: %s", rpcResp.Error.Message)
+	}
+
+	fee, success := new(big.Int).SetString(strings.TrimPrefix(rpcResp.Result, "0x"), 16)
+	if !success {
+		return nil, fmt.Errorf("invalid fee %q returned by execution node", rpcResp.Result)
+	}
+
+	return fee, nil
+}
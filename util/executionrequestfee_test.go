@@ -0,0 +1,80 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestObtainExecutionRequestFee(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		status   int
+		expected *big.Int
+		err      string
+	}{
+		{
+			name:     "Good",
+			response: `{"jsonrpc":"2.0","id":1,"result":"0x0000000000000000000000000000000000000000000000000000000000000003"}`,
+			status:   http.StatusOK,
+			expected: big.NewInt(3),
+		},
+		{
+			name:     "RPCError",
+			response: `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`,
+			status:   http.StatusOK,
+			err:      "execution node returned error: execution reverted",
+		},
+		{
+			name:     "BadStatus",
+			response: ``,
+			status:   http.StatusInternalServerError,
+			err:      "execution node returned status 500",
+		},
+		{
+			name:     "BadResult",
+			response: `{"jsonrpc":"2.0","id":1,"result":"0xzz"}`,
+			status:   http.StatusOK,
+			err:      `invalid fee "0xzz" returned by execution node`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(test.status)
+				fmt.Fprint(w, test.response)
+			}))
+			defer server.Close()
+
+			fee, err := util.ObtainExecutionRequestFee(context.Background(), server.URL, time.Second, []byte{0x01})
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, fee)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// Consensus and execution client values come from
+// https://github.com/ethereum/execution-apis/blob/main/src/engine/identification.md
+var consensusClients = map[string]string{
+	"GR": "grandine",
+	"LH": "lighthouse",
+	"LS": "lodestar",
+	"NB": "nimbus",
+	"PM": "prysm",
+	"TK": "teku",
+}
+
+var consensusClientRegex = regexp.MustCompile(`(GR|LH|LS|NB|PM|TK)([0-9a-f]*)`)
+
+var executionClients = map[string]string{
+	"BU": "besu",
+	"EG": "erigon",
+	"EJ": "ethereumJS",
+	"GE": "go-ethereum",
+	"NM": "nethermind",
+	"RH": "reth",
+}
+
+var executionClientRegex = regexp.MustCompile(`(BU|EG|EJ|GE|NM|RH)([0-9a-f]*)`)
+
+// GraffitiIdentification is the client identification information present in graffiti.
+type GraffitiIdentification struct {
+	// Graffiti is the graffiti without the client identification.
+	Graffiti []byte
+	// ConsensusClient is the name of the consensus client, if present.
+	ConsensusClient string
+	// ConsensusHash is the version hash of the consensus client, if present.
+	ConsensusHash string
+	// ExecutionClient is the name of the execution client, if present.
+	ExecutionClient string
+	// ExecutionHash is the version hash of the execution client, if present.
+	ExecutionHash string
+}
+
+// ParseGraffitiIdentification parses the client identification information from graffiti.
+// The client identification will always be the last entry in the graffiti, with a space beforehand.
+// This returns nil if the graffiti does not contain client identification information.
+func ParseGraffitiIdentification(graffiti []byte) *GraffitiIdentification {
+	// Remove any trailing null characters.
+	graffiti = bytes.TrimRight(graffiti, "\u0000")
+
+	parts := bytes.Split(graffiti, []byte{' '})
+	consensusData := consensusClientRegex.Find(parts[len(parts)-1])
+	executionData := executionClientRegex.Find(parts[len(parts)-1])
+	if len(consensusData) == 0 && len(executionData) == 0 {
+		return nil
+	}
+
+	res := &GraffitiIdentification{
+		Graffiti: bytes.Join(parts[0:len(parts)-1], []byte(" ")),
+	}
+	if len(consensusData) > 0 {
+		res.ConsensusClient = consensusClients[string(consensusData[0:2])]
+		res.ConsensusHash = string(consensusData[2:])
+	}
+	if len(executionData) > 0 {
+		res.ExecutionClient = executionClients[string(executionData[0:2])]
+		res.ExecutionHash = string(executionData[2:])
+	}
+
+	return res
+}
@@ -16,6 +16,7 @@ package util
 import (
 	"context"
 	"fmt"
+	"os"
 	"regexp"
 	"sort"
 	"strconv"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
+	"github.com/wealdtech/ethdo/stores/vault"
 	e2types "github.com/wealdtech/go-eth2-types/v2"
 	e2wallet "github.com/wealdtech/go-eth2-wallet"
 	dirk "github.com/wealdtech/go-eth2-wallet-dirk"
@@ -58,6 +60,30 @@ func SetupStore() error {
 		if err != nil {
 			return errors.Wrap(err, "failed to access Amazon S3 wallet store")
 		}
+	case "vault":
+		if GetBaseDir() != "" {
+			return errors.New("basedir does not apply to the vault store")
+		}
+		address := viper.GetString("stores.vault.address")
+		if address == "" {
+			address = os.Getenv("VAULT_ADDR")
+		}
+		token := viper.GetString("stores.vault.token")
+		if token == "" {
+			token = os.Getenv("VAULT_TOKEN")
+		}
+		store, err = vault.New(vault.WithPassphrase([]byte(GetStorePassphrase("vault"))),
+			vault.WithAddress(address),
+			vault.WithMount(viper.GetString("stores.vault.mount")),
+			vault.WithPath(viper.GetString("stores.vault.path")),
+			vault.WithNamespace(viper.GetString("stores.vault.namespace")),
+			vault.WithToken(token),
+			vault.WithAppRole(viper.GetString("stores.vault.approle.role-id"), viper.GetString("stores.vault.approle.secret-id")),
+			vault.WithAppRoleMount(viper.GetString("stores.vault.approle.mount")),
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to access Vault wallet store")
+		}
 	case "filesystem":
 		opts := make([]filesystem.Option, 0)
 		if GetStorePassphrase("filesystem") != "" {
@@ -0,0 +1,263 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"github.com/wealdtech/ethdo/shamir"
+	"golang.org/x/text/unicode/norm"
+)
+
+// mnemonicShareVersion is the version of the mnemonic share encoding.
+const mnemonicShareVersion = 1
+
+// mnemonicShareHeaderLen is the length of the header of a mnemonic share:
+// version and language, set identifier (2 bytes), threshold and index.
+const mnemonicShareHeaderLen = 5
+
+// mnemonicShareChecksumLen is the length of the checksum of a mnemonic share.
+const mnemonicShareChecksumLen = 2
+
+// MnemonicShare is a share of a mnemonic, created by SplitMnemonic.
+type MnemonicShare struct {
+	// ID identifies the set of shares to which this share belongs.
+	ID uint16
+	// Threshold is the number of shares required to recover the mnemonic.
+	Threshold uint8
+	// Index is the index of this share.
+	Index uint8
+	// Words are the words of the share.
+	Words string
+
+	language uint8
+	data     []byte
+}
+
+// SplitMnemonic splits the entropy of a mnemonic in to shares using Shamir secret sharing,
+// any threshold of which can recover the mnemonic.
+// Each share is encoded as a list of words from the English BIP-39 word list, and contains
+// its index and a checksum so that transcription errors can be detected.
+// Note that the shares do not contain any passphrase used with the mnemonic.
+func SplitMnemonic(mnemonic string, participants int, threshold int) ([]*MnemonicShare, error) {
+	mnemonic = string(norm.NFKD.Bytes([]byte(strings.Join(strings.Fields(mnemonic), " "))))
+	switch len(strings.Fields(mnemonic)) {
+	case 12, 15, 18, 21, 24:
+		// Good.
+	default:
+		return nil, errors.New("mnemonic must be 12, 15, 18, 21 or 24 words; passphrases cannot be shared")
+	}
+
+	var entropy []byte
+	language := -1
+	for i, wl := range mnemonicWordLists {
+		bip39.SetWordList(wl)
+		var err error
+		entropy, err = bip39.EntropyFromMnemonic(expandMnemonic(mnemonic))
+		if err == nil {
+			language = i
+			break
+		}
+	}
+	bip39.SetWordList(wordlists.English)
+	if language == -1 {
+		return nil, errors.New("mnemonic is invalid")
+	}
+
+	parts, err := shamir.Split(entropy, participants, threshold)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to split mnemonic")
+	}
+
+	id := make([]byte, 2)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Wrap(err, "failed to generate identifier")
+	}
+
+	shares := make([]*MnemonicShare, len(parts))
+	for i, part := range parts {
+		// Shamir parts are the share data followed by a single byte index.
+		data := make([]byte, 0, mnemonicShareHeaderLen+len(part)-1+mnemonicShareChecksumLen)
+		data = append(data, byte(mnemonicShareVersion<<4|language))
+		data = append(data, id...)
+		data = append(data, byte(threshold), part[len(part)-1])
+		data = append(data, part[:len(part)-1]...)
+		checksum := sha256.Sum256(data)
+		data = append(data, checksum[:mnemonicShareChecksumLen]...)
+
+		shares[i], err = parseMnemonicShareData(data)
+		if err != nil {
+			return nil, err
+		}
+		shares[i].Words = encodeShareWords(data)
+	}
+
+	return shares, nil
+}
+
+// RecoverMnemonic recovers a mnemonic from a threshold of shares created by SplitMnemonic.
+func RecoverMnemonic(input []string) (string, error) {
+	shares := make([]*MnemonicShare, 0, len(input))
+	indices := make(map[uint8]bool)
+	for i := range input {
+		share, err := ParseMnemonicShare(input[i])
+		if err != nil {
+			return "", errors.Wrap(err, fmt.Sprintf("share %d", i+1))
+		}
+		if len(shares) > 0 {
+			if share.ID != shares[0].ID {
+				return "", fmt.Errorf("share %d is from a different set of shares", i+1)
+			}
+			if share.Threshold != shares[0].Threshold ||
+				share.language != shares[0].language ||
+				len(share.data) != len(shares[0].data) {
+				return "", fmt.Errorf("share %d is inconsistent with other shares", i+1)
+			}
+		}
+		if indices[share.Index] {
+			return "", fmt.Errorf("share %d is a duplicate", i+1)
+		}
+		indices[share.Index] = true
+		shares = append(shares, share)
+	}
+	if len(shares) == 0 {
+		return "", errors.New("no shares supplied")
+	}
+	if len(shares) < int(shares[0].Threshold) {
+		return "", fmt.Errorf("%d shares supplied; %d required", len(shares), shares[0].Threshold)
+	}
+
+	parts := make([][]byte, len(shares))
+	for i, share := range shares {
+		parts[i] = append(append([]byte{}, share.data...), share.Index)
+	}
+	entropy, err := shamir.Combine(parts)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to combine shares")
+	}
+
+	bip39.SetWordList(mnemonicWordLists[shares[0].language])
+	defer bip39.SetWordList(wordlists.English)
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate mnemonic")
+	}
+
+	return mnemonic, nil
+}
+
+// ParseMnemonicShare parses the words of a mnemonic share, verifying its checksum.
+// Words may be abbreviated to their first four letters.
+func ParseMnemonicShare(input string) (*MnemonicShare, error) {
+	words := strings.Fields(strings.ToLower(input))
+	if len(words) == 0 {
+		return nil, errors.New("share is empty")
+	}
+
+	// Decode the words to bits, 11 bits per word.
+	data := make([]byte, len(words)*11/8)
+	bit := 0
+	for _, word := range words {
+		index, exists := shareWordIndices[word]
+		if !exists {
+			return nil, fmt.Errorf("unknown word %q", word)
+		}
+		for i := 10; i >= 0; i-- {
+			if index&(1<<i) != 0 {
+				if bit/8 >= len(data) {
+					return nil, errors.New("share has invalid padding")
+				}
+				data[bit/8] |= 0x80 >> (bit % 8)
+			}
+			bit++
+		}
+	}
+
+	if len(data) < mnemonicShareHeaderLen+mnemonicShareChecksumLen+1 {
+		return nil, errors.New("share is too short")
+	}
+	checksumStart := len(data) - mnemonicShareChecksumLen
+	checksum := sha256.Sum256(data[:checksumStart])
+	if !bytes.Equal(checksum[:mnemonicShareChecksumLen], data[checksumStart:]) {
+		return nil, errors.New("share checksum is invalid; check for transcription errors")
+	}
+
+	share, err := parseMnemonicShareData(data)
+	if err != nil {
+		return nil, err
+	}
+	share.Words = encodeShareWords(data)
+
+	return share, nil
+}
+
+// parseMnemonicShareData parses the binary form of a mnemonic share.
+func parseMnemonicShareData(data []byte) (*MnemonicShare, error) {
+	if data[0]>>4 != mnemonicShareVersion {
+		return nil, fmt.Errorf("share version %d not supported", data[0]>>4)
+	}
+	language := data[0] & 0x0f
+	if int(language) >= len(mnemonicWordLists) {
+		return nil, errors.New("share has unknown language")
+	}
+	entropyLen := len(data) - mnemonicShareHeaderLen - mnemonicShareChecksumLen
+	if entropyLen < 16 || entropyLen > 32 || entropyLen%4 != 0 {
+		return nil, errors.New("share has invalid length")
+	}
+
+	return &MnemonicShare{
+		ID:        uint16(data[1])<<8 | uint16(data[2]),
+		Threshold: data[3],
+		Index:     data[4],
+		language:  language,
+		data:      data[mnemonicShareHeaderLen : mnemonicShareHeaderLen+entropyLen],
+	}, nil
+}
+
+// encodeShareWords encodes the binary form of a mnemonic share as words, 11 bits per word.
+func encodeShareWords(data []byte) string {
+	bits := len(data) * 8
+	words := make([]string, 0, (bits+10)/11)
+	for start := 0; start < bits; start += 11 {
+		index := 0
+		for bit := start; bit < start+11; bit++ {
+			index <<= 1
+			if bit < bits && data[bit/8]&(0x80>>(bit%8)) != 0 {
+				index |= 1
+			}
+		}
+		words = append(words, wordlists.English[index])
+	}
+
+	return strings.Join(words, " ")
+}
+
+// shareWordIndices maps words of the English BIP-39 word list, and their
+// four-letter abbreviations, to their index.
+var shareWordIndices = func() map[string]int {
+	res := make(map[string]int, len(wordlists.English)*2)
+	for i, word := range wordlists.English {
+		res[word] = i
+		res[firstFour(word)] = i
+	}
+
+	return res
+}()
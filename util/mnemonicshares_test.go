@@ -0,0 +1,226 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+func TestSplitMnemonic(t *testing.T) {
+	tests := []struct {
+		name         string
+		mnemonic     string
+		participants int
+		threshold    int
+		words        int
+		recovered    string
+		err          string
+	}{
+		{
+			name:         "Empty",
+			participants: 3,
+			threshold:    2,
+			err:          "mnemonic must be 12, 15, 18, 21 or 24 words; passphrases cannot be shared",
+		},
+		{
+			name:         "Passphrase",
+			mnemonic:     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art passphrase",
+			participants: 3,
+			threshold:    2,
+			err:          "mnemonic must be 12, 15, 18, 21 or 24 words; passphrases cannot be shared",
+		},
+		{
+			name:         "ChecksumInvalid",
+			mnemonic:     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			participants: 3,
+			threshold:    2,
+			err:          "mnemonic is invalid",
+		},
+		{
+			name:         "ThresholdTooLow",
+			mnemonic:     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			participants: 3,
+			threshold:    1,
+			err:          "failed to split mnemonic: threshold must be at least 2",
+		},
+		{
+			name:         "ThresholdTooHigh",
+			mnemonic:     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			participants: 3,
+			threshold:    4,
+			err:          "failed to split mnemonic: parts cannot be less than threshold",
+		},
+		{
+			name:         "Words12",
+			mnemonic:     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			participants: 3,
+			threshold:    2,
+			words:        17,
+			recovered:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		},
+		{
+			name:         "Words24",
+			mnemonic:     "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+			participants: 5,
+			threshold:    3,
+			words:        29,
+			recovered:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art",
+		},
+		{
+			name:         "Abbreviated",
+			mnemonic:     "aban aban aban aban aban aban aban aban aban aban aban abou",
+			participants: 3,
+			threshold:    2,
+			words:        17,
+			recovered:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		},
+		{
+			name:         "Spanish",
+			mnemonic:     "ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco abierto",
+			participants: 3,
+			threshold:    2,
+			words:        17,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shares, err := util.SplitMnemonic(test.mnemonic, test.participants, test.threshold)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, shares, test.participants)
+			for _, share := range shares {
+				require.Len(t, strings.Fields(share.Words), test.words)
+				require.Equal(t, uint8(test.threshold), share.Threshold)
+				require.Equal(t, shares[0].ID, share.ID)
+			}
+
+			// Any threshold of shares recovers the mnemonic.
+			for start := 0; start+test.threshold <= len(shares); start++ {
+				input := make([]string, 0, test.threshold)
+				for _, share := range shares[start : start+test.threshold] {
+					input = append(input, share.Words)
+				}
+				mnemonic, err := util.RecoverMnemonic(input)
+				require.NoError(t, err)
+				if test.recovered != "" {
+					require.Equal(t, test.recovered, mnemonic)
+				}
+				seed1, err := util.SeedFromMnemonic(test.mnemonic)
+				require.NoError(t, err)
+				seed2, err := util.SeedFromMnemonic(mnemonic)
+				require.NoError(t, err)
+				require.Equal(t, seed1, seed2)
+			}
+		})
+	}
+}
+
+func TestRecoverMnemonic(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+	shares, err := util.SplitMnemonic(mnemonic, 3, 2)
+	require.NoError(t, err)
+	otherShares, err := util.SplitMnemonic(mnemonic, 3, 2)
+	require.NoError(t, err)
+	for otherShares[0].ID == shares[0].ID {
+		// Ensure the sets are distinct.
+		otherShares, err = util.SplitMnemonic(mnemonic, 3, 2)
+		require.NoError(t, err)
+	}
+
+	// Alter a single word of a share.
+	words := strings.Fields(shares[1].Words)
+	if words[10] == "zoo" {
+		words[10] = "abandon"
+	} else {
+		words[10] = "zoo"
+	}
+	altered := strings.Join(words, " ")
+
+	// Abbreviate the words of a share.
+	words = strings.Fields(shares[1].Words)
+	for i := range words {
+		if len(words[i]) > 4 {
+			words[i] = words[i][:4]
+		}
+	}
+	abbreviated := strings.Join(words, " ")
+
+	tests := []struct {
+		name   string
+		shares []string
+		err    string
+	}{
+		{
+			name: "Empty",
+			err:  "no shares supplied",
+		},
+		{
+			name:   "Insufficient",
+			shares: []string{shares[0].Words},
+			err:    "1 shares supplied; 2 required",
+		},
+		{
+			name:   "UnknownWord",
+			shares: []string{shares[0].Words, shares[1].Words + " bogus"},
+			err:    `share 2: unknown word "bogus"`,
+		},
+		{
+			name:   "Altered",
+			shares: []string{shares[0].Words, altered},
+			err:    "share 2: share checksum is invalid; check for transcription errors",
+		},
+		{
+			name:   "Duplicate",
+			shares: []string{shares[0].Words, shares[0].Words},
+			err:    "share 2 is a duplicate",
+		},
+		{
+			name:   "DifferentSet",
+			shares: []string{shares[0].Words, otherShares[1].Words},
+			err:    "share 2 is from a different set of shares",
+		},
+		{
+			name:   "Abbreviated",
+			shares: []string{shares[0].Words, abbreviated},
+		},
+		{
+			name:   "UpperCase",
+			shares: []string{strings.ToUpper(shares[2].Words), shares[1].Words},
+		},
+		{
+			name:   "AllShares",
+			shares: []string{shares[2].Words, shares[0].Words, shares[1].Words},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := util.RecoverMnemonic(test.shares)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, mnemonic, res)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+)
+
+// QuorumDisagreement is a response from a beacon node that does not match the quorum.
+type QuorumDisagreement struct {
+	// Address is the address of the beacon node.
+	Address string
+	// Summary is the summary of the response from the beacon node.
+	Summary string
+	// Err is the error returned by the beacon node, if any.
+	Err error
+}
+
+// String provides a human-readable description of the disagreement.
+func (d *QuorumDisagreement) String() string {
+	if d.Err != nil {
+		return fmt.Sprintf("%s: error: %v", d.Address, d.Err)
+	}
+
+	return fmt.Sprintf("%s: %s", d.Address, d.Summary)
+}
+
+// QuorumFetch fetches data from each of the supplied clients in parallel, and returns
+// the data that at least quorum of the clients agree upon.  Agreement is determined by
+// comparing the output of summary for each response.  Details of any clients that did
+// not agree with the majority are also returned.
+func QuorumFetch[T any](ctx context.Context,
+	clients []eth2client.Service,
+	quorum int,
+	fetch func(context.Context, eth2client.Service) (T, error),
+	summary func(T) string,
+) (
+	T,
+	[]*QuorumDisagreement,
+	error,
+) {
+	var res T
+	if quorum < 1 {
+		return res, nil, fmt.Errorf("invalid quorum %d", quorum)
+	}
+	if quorum > len(clients) {
+		return res, nil, fmt.Errorf("quorum of %d requested but only %d beacon nodes available", quorum, len(clients))
+	}
+
+	type response struct {
+		data    T
+		summary string
+		err     error
+	}
+	responses := make([]*response, len(clients))
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data, err := fetch(ctx, clients[i])
+			responses[i] = &response{
+				data: data,
+				err:  err,
+			}
+			if err == nil {
+				responses[i].summary = summary(data)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Find the most common response, preferring earlier clients in the case of a tie.
+	counts := make(map[string]int)
+	majority := -1
+	for i, response := range responses {
+		if response.err != nil {
+			continue
+		}
+		counts[response.summary]++
+		if majority == -1 || counts[response.summary] > counts[responses[majority].summary] {
+			majority = i
+		}
+	}
+	if majority == -1 {
+		return res, nil, fmt.Errorf("no beacon node returned data: %w", responses[0].err)
+	}
+
+	disagreements := make([]*QuorumDisagreement, 0)
+	for i, response := range responses {
+		if response.err == nil && response.summary == responses[majority].summary {
+			continue
+		}
+		disagreements = append(disagreements, &QuorumDisagreement{
+			Address: clients[i].Address(),
+			Summary: response.summary,
+			Err:     response.err,
+		})
+	}
+
+	if counts[responses[majority].summary] < quorum {
+		return res, disagreements, fmt.Errorf("quorum not reached: %d of %d beacon nodes agree, %d required", counts[responses[majority].summary], len(clients), quorum)
+	}
+
+	return responses[majority].data, disagreements, nil
+}
@@ -0,0 +1,164 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	eth2client "github.com/attestantio/go-eth2-client"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/util"
+)
+
+// A mock Ethereum 2 client service that returns a fixed value.
+type valueETH2Client struct {
+	address string
+	value   uint64
+	err     error
+}
+
+// Name returns the name of the client implementation.
+func (c *valueETH2Client) Name() string {
+	return "value mock"
+}
+
+// Address returns the address of the client.
+func (c *valueETH2Client) Address() string {
+	return c.address
+}
+
+// IsActive returns true if the client is active.
+func (c *valueETH2Client) IsActive() bool {
+	return true
+}
+
+// IsSynced returns true if the client is synced.
+func (c *valueETH2Client) IsSynced() bool {
+	return true
+}
+
+func fetchValue(_ context.Context, client eth2client.Service) (uint64, error) {
+	mock := client.(*valueETH2Client)
+	return mock.value, mock.err
+}
+
+func summariseValue(value uint64) string {
+	return fmt.Sprintf("value %d", value)
+}
+
+func TestQuorumFetch(t *testing.T) {
+	tests := []struct {
+		name          string
+		clients       []eth2client.Service
+		quorum        int
+		expected      uint64
+		disagreements []string
+		err           string
+	}{
+		{
+			name: "QuorumZero",
+			clients: []eth2client.Service{
+				&valueETH2Client{address: "a", value: 1},
+			},
+			quorum: 0,
+			err:    "invalid quorum 0",
+		},
+		{
+			name: "QuorumTooHigh",
+			clients: []eth2client.Service{
+				&valueETH2Client{address: "a", value: 1},
+			},
+			quorum: 2,
+			err:    "quorum of 2 requested but only 1 beacon nodes available",
+		},
+		{
+			name: "AllAgree",
+			clients: []eth2client.Service{
+				&valueETH2Client{address: "a", value: 1},
+				&valueETH2Client{address: "b", value: 1},
+				&valueETH2Client{address: "c", value: 1},
+			},
+			quorum:        3,
+			expected:      1,
+			disagreements: []string{},
+		},
+		{
+			name: "OneDisagrees",
+			clients: []eth2client.Service{
+				&valueETH2Client{address: "a", value: 2},
+				&valueETH2Client{address: "b", value: 1},
+				&valueETH2Client{address: "c", value: 1},
+			},
+			quorum:        2,
+			expected:      1,
+			disagreements: []string{"a: value 2"},
+		},
+		{
+			name: "OneErrors",
+			clients: []eth2client.Service{
+				&valueETH2Client{address: "a", value: 1},
+				&valueETH2Client{address: "b", err: errors.New("unavailable")},
+				&valueETH2Client{address: "c", value: 1},
+			},
+			quorum:        2,
+			expected:      1,
+			disagreements: []string{"b: error: unavailable"},
+		},
+		{
+			name: "NotReached",
+			clients: []eth2client.Service{
+				&valueETH2Client{address: "a", value: 1},
+				&valueETH2Client{address: "b", value: 2},
+				&valueETH2Client{address: "c", value: 3},
+			},
+			quorum: 2,
+			err:    "quorum not reached: 1 of 3 beacon nodes agree, 2 required",
+		},
+		{
+			name: "AllError",
+			clients: []eth2client.Service{
+				&valueETH2Client{address: "a", err: errors.New("unavailable")},
+				&valueETH2Client{address: "b", err: errors.New("unavailable")},
+			},
+			quorum: 1,
+			err:    "no beacon node returned data: unavailable",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, disagreements, err := util.QuorumFetch(context.Background(), test.clients, test.quorum, fetchValue, summariseValue)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, test.expected, res)
+				descriptions := make([]string, 0, len(disagreements))
+				for _, disagreement := range disagreements {
+					descriptions = append(descriptions, disagreement.String())
+				}
+				require.Equal(t, test.disagreements, descriptions)
+			}
+		})
+	}
+}
+
+func TestParseAddresses(t *testing.T) {
+	require.Equal(t, []string{}, util.ParseAddresses(""))
+	require.Equal(t, []string{"localhost:5052"}, util.ParseAddresses("localhost:5052"))
+	require.Equal(t, []string{"localhost:5052", "localhost:5051"}, util.ParseAddresses("localhost:5052, localhost:5051,"))
+}
@@ -0,0 +1,209 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// RekeyedAccount is an account that has been re-encrypted by RekeyAccounts.
+type RekeyedAccount struct {
+	ID   uuid.UUID
+	Name string
+	// Backup is the path of the keystore holding the account as it was prior to re-encryption.
+	Backup string
+}
+
+// rekeyItem holds the original and re-encrypted data for an account.
+type rekeyItem struct {
+	id       uuid.UUID
+	name     string
+	original []byte
+	updated  []byte
+	keystore []byte
+}
+
+// RekeyAccounts re-encrypts the secret keys of the given accounts of a wallet with a new passphrase,
+// using the supplied encryptor.
+//
+// All accounts are decrypted before any are changed, and EIP-2335 keystores of the accounts as they
+// were are written to a new directory inside backupDir.  If storing a re-encrypted account fails then
+// the accounts already stored are restored, so either all or none of the accounts are re-encrypted.
+func RekeyAccounts(_ context.Context,
+	wallet e2wtypes.Wallet,
+	accountIDs []uuid.UUID,
+	passphrases []string,
+	newPassphrase string,
+	encryptor e2wtypes.Encryptor,
+	backupDir string,
+) (
+	[]*RekeyedAccount,
+	error,
+) {
+	storeProvider, isStoreProvider := wallet.(e2wtypes.StoreProvider)
+	if !isStoreProvider {
+		return nil, errors.New("wallet does not provide access to its store")
+	}
+	store := storeProvider.Store()
+	if len(accountIDs) == 0 {
+		return nil, errors.New("no accounts to re-encrypt")
+	}
+	if newPassphrase == "" {
+		return nil, errors.New("new passphrase is required")
+	}
+
+	items := make([]*rekeyItem, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		data, err := store.RetrieveAccount(wallet.ID(), accountID)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to retrieve account %s", accountID))
+		}
+		item, err := rekeyAccountData(accountID, data, passphrases, newPassphrase, encryptor)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	// Back up the accounts before changing anything.
+	backupDir = filepath.Join(backupDir, fmt.Sprintf("%s-%s", wallet.ID(), time.Now().UTC().Format("20060102T150405Z")))
+	if err := os.MkdirAll(backupDir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "failed to create backup directory")
+	}
+	res := make([]*RekeyedAccount, 0, len(items))
+	for _, item := range items {
+		backup := filepath.Join(backupDir, fmt.Sprintf("keystore-%s.json", item.id))
+		if err := os.WriteFile(backup, item.keystore, 0o600); err != nil {
+			return nil, errors.Wrap(err, "failed to write backup keystore")
+		}
+		res = append(res, &RekeyedAccount{
+			ID:     item.id,
+			Name:   item.name,
+			Backup: backup,
+		})
+	}
+
+	for i, item := range items {
+		if err := store.StoreAccount(wallet.ID(), item.id, item.updated); err != nil {
+			// Restore the accounts that have been altered, including this one in case of a partial write.
+			for _, restore := range items[:i+1] {
+				if restoreErr := store.StoreAccount(wallet.ID(), restore.id, restore.original); restoreErr != nil {
+					return nil, errors.Wrap(restoreErr, fmt.Sprintf("failed to restore account %q after failing to store re-encrypted account; restore it from %s", restore.name, backupDir))
+				}
+			}
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to store re-encrypted account %q; no accounts have been changed", item.name))
+		}
+	}
+
+	return res, nil
+}
+
+// rekeyAccountData re-encrypts the secret key held in the serialized account data.
+func rekeyAccountData(accountID uuid.UUID,
+	data []byte,
+	passphrases []string,
+	newPassphrase string,
+	encryptor e2wtypes.Encryptor,
+) (
+	*rekeyItem,
+	error,
+) {
+	account := make(map[string]any)
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to parse account %s", accountID))
+	}
+	name, ok := account["name"].(string)
+	if !ok {
+		name = accountID.String()
+	}
+	crypto, ok := account["crypto"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("account %q does not have an encrypted key", name)
+	}
+
+	// Any keystore V4 encryptor can decrypt, as parameters are held in the crypto itself.
+	decryptor := keystorev4.New()
+	var secret []byte
+	for _, passphrase := range passphrases {
+		var err error
+		secret, err = decryptor.Decrypt(crypto, passphrase)
+		if err == nil {
+			break
+		}
+	}
+	if secret == nil {
+		return nil, fmt.Errorf("unable to decrypt account %q with supplied passphrases", name)
+	}
+
+	updatedCrypto, err := encryptor.Encrypt(secret, newPassphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to encrypt account %q", name))
+	}
+
+	// Keystore of the account as it was.
+	keystore := map[string]any{
+		"crypto":      crypto,
+		"pubkey":      account["pubkey"],
+		"path":        "",
+		"uuid":        accountID.String(),
+		"version":     4,
+		"description": name,
+	}
+	if path, exists := account["path"]; exists {
+		keystore["path"] = path
+	}
+	keystoreData, err := json.Marshal(keystore)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create backup keystore")
+	}
+
+	account["crypto"] = updatedCrypto
+	if _, exists := account["encryptor"]; exists {
+		account["encryptor"] = encryptor.String()
+	}
+	updated, err := json.Marshal(account)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to serialize account %q", name))
+	}
+
+	return &rekeyItem{
+		id:       accountID,
+		name:     name,
+		original: data,
+		updated:  updated,
+		keystore: keystoreData,
+	}, nil
+}
+
+// KeystoreEncryptor returns a keystore V4 encryptor using the given key derivation function.
+func KeystoreEncryptor(kdf string) (e2wtypes.Encryptor, error) {
+	switch kdf {
+	case "", "pbkdf2":
+		return keystorev4.New(), nil
+	case "scrypt":
+		return keystorev4.New(keystorev4.WithCipher("scrypt")), nil
+	default:
+		return nil, errors.New("kdf must be one of scrypt or pbkdf2")
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+	"github.com/wealdtech/ethdo/util"
+	e2types "github.com/wealdtech/go-eth2-types/v2"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+	nd "github.com/wealdtech/go-eth2-wallet-nd/v2"
+	scratch "github.com/wealdtech/go-eth2-wallet-store-scratch"
+	e2wtypes "github.com/wealdtech/go-eth2-wallet-types/v2"
+)
+
+// failingStore is a store that fails to store accounts after a given number of calls.
+type failingStore struct {
+	e2wtypes.Store
+	remaining int
+}
+
+func (s *failingStore) StoreAccount(walletID uuid.UUID, accountID uuid.UUID, data []byte) error {
+	if s.remaining == 0 {
+		// Fail this call, but allow later calls to restore accounts.
+		s.remaining = -1
+		return errors.New("store failure")
+	}
+	s.remaining--
+
+	return s.Store.StoreAccount(walletID, accountID, data)
+}
+
+func TestRekeyAccounts(t *testing.T) {
+	ctx := context.Background()
+	require.NoError(t, e2types.InitBLS())
+
+	accounts := []struct {
+		name       string
+		key        []byte
+		passphrase string
+	}{
+		{
+			name:       "Account 1",
+			key:        testutil.HexToBytes("0x25295f0d1d592a90b333e26e85149708208e9f8e8bc18f6c77bd62f8ad7a6866"),
+			passphrase: "pass1",
+		},
+		{
+			name:       "Account 2",
+			key:        testutil.HexToBytes("0x51d0b65185db6989ab0b560d6deed19c7ead0e24b9b6372cbecb1f26bdfad000"),
+			passphrase: "pass2",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		passphrases   []string
+		newPassphrase string
+		failAfter     int
+		err           string
+	}{
+		{
+			name:        "NewPassphraseMissing",
+			passphrases: []string{"pass1", "pass2"},
+			failAfter:   -1,
+			err:         "new passphrase is required",
+		},
+		{
+			name:          "PassphraseIncorrect",
+			passphrases:   []string{"pass1"},
+			newPassphrase: "new",
+			failAfter:     -1,
+			err:           `unable to decrypt account "Account 2" with supplied passphrases`,
+		},
+		{
+			name:          "StoreFails",
+			passphrases:   []string{"pass1", "pass2"},
+			newPassphrase: "new",
+			failAfter:     1,
+			err:           `failed to store re-encrypted account "Account 2"; no accounts have been changed: store failure`,
+		},
+		{
+			name:          "Good",
+			passphrases:   []string{"pass1", "pass2"},
+			newPassphrase: "new",
+			failAfter:     -1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &failingStore{
+				Store:     scratch.New(),
+				remaining: -1,
+			}
+			wallet, err := nd.CreateWallet(ctx, "Test wallet", store, keystorev4.New())
+			require.NoError(t, err)
+			require.NoError(t, wallet.(e2wtypes.WalletLocker).Unlock(ctx, nil))
+			accountIDs := make([]uuid.UUID, len(accounts))
+			for i := range accounts {
+				account, err := wallet.(e2wtypes.WalletAccountImporter).ImportAccount(ctx, accounts[i].name, accounts[i].key, []byte(accounts[i].passphrase))
+				require.NoError(t, err)
+				accountIDs[i] = account.ID()
+			}
+
+			store.remaining = test.failAfter
+			backupDir := t.TempDir()
+			rekeyed, err := util.RekeyAccounts(ctx, wallet, accountIDs, test.passphrases, test.newPassphrase, keystorev4.New(), backupDir)
+
+			// Reopen the wallet to avoid cached accounts.
+			wallet, openErr := nd.OpenWallet(ctx, "Test wallet", store, keystorev4.New())
+			require.NoError(t, openErr)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				// Accounts must retain their original passphrases.
+				for i := range accounts {
+					account, err := wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, accounts[i].name)
+					require.NoError(t, err)
+					require.NoError(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte(accounts[i].passphrase)))
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, rekeyed, len(accounts))
+
+			for i := range accounts {
+				require.Equal(t, accountIDs[i], rekeyed[i].ID)
+				require.Equal(t, accounts[i].name, rekeyed[i].Name)
+
+				account, err := wallet.(e2wtypes.WalletAccountByNameProvider).AccountByName(ctx, accounts[i].name)
+				require.NoError(t, err)
+				require.Error(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte(accounts[i].passphrase)))
+				require.NoError(t, account.(e2wtypes.AccountLocker).Unlock(ctx, []byte(test.newPassphrase)))
+
+				// The backup keystore must be usable with the original passphrase.
+				keystore, err := os.ReadFile(rekeyed[i].Backup)
+				require.NoError(t, err)
+				backup, err := util.ParseAccount(ctx, string(keystore), []string{accounts[i].passphrase}, true)
+				require.NoError(t, err)
+				privateKey, err := backup.(e2wtypes.AccountPrivateKeyProvider).PrivateKey(ctx)
+				require.NoError(t, err)
+				require.Equal(t, accounts[i].key, privateKey.Marshal())
+			}
+		})
+	}
+}
+
+func TestKeystoreEncryptor(t *testing.T) {
+	tests := []struct {
+		name string
+		kdf  string
+		err  string
+	}{
+		{
+			name: "Default",
+		},
+		{
+			name: "PBKDF2",
+			kdf:  "pbkdf2",
+		},
+		{
+			name: "Scrypt",
+			kdf:  "scrypt",
+		},
+		{
+			name: "Invalid",
+			kdf:  "argon2",
+			err:  "kdf must be one of scrypt or pbkdf2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			encryptor, err := util.KeystoreEncryptor(test.kdf)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "keystorev4", encryptor.String())
+		})
+	}
+}
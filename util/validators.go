@@ -15,9 +15,12 @@ package util
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
+	"unicode"
 
 	eth2client "github.com/attestantio/go-eth2-client"
 	"github.com/attestantio/go-eth2-client/api"
@@ -27,44 +30,111 @@ import (
 )
 
 // ParseValidators parses input to obtain the list of validators.
+// Each item of input can be an index, a range of indices such as "100-199", a public key, or
+// the path to a file containing any of these separated by commas or whitespace.
 func ParseValidators(ctx context.Context, validatorsProvider eth2client.ValidatorsProvider, validatorsStr []string, stateID string) ([]*apiv1.Validator, error) {
-	validators := make([]*apiv1.Validator, 0, len(validatorsStr))
+	indices, pubKeys, err := parseValidatorsStr(validatorsStr, true)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*apiv1.Validator, 0, len(indices)+len(pubKeys))
+	seen := make(map[phase0.ValidatorIndex]bool)
+	if len(indices) > 0 || len(pubKeys) == 0 {
+		response, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: stateID, Indices: indices})
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain validators %v", indices))
+		}
+		for _, validator := range response.Data {
+			seen[validator.Index] = true
+			validators = append(validators, validator)
+		}
+	}
+	if len(pubKeys) > 0 {
+		response, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: stateID, PubKeys: pubKeys})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to obtain validators by public key")
+		}
+		for _, validator := range response.Data {
+			if !seen[validator.Index] {
+				seen[validator.Index] = true
+				validators = append(validators, validator)
+			}
+		}
+	}
+
+	return validators, nil
+}
+
+// parseValidatorsStr parses input to obtain validator indices and public keys.
+func parseValidatorsStr(validatorsStr []string, allowFiles bool) ([]phase0.ValidatorIndex, []phase0.BLSPubKey, error) {
 	indices := make([]phase0.ValidatorIndex, 0)
+	pubKeys := make([]phase0.BLSPubKey, 0)
 	for i := range validatorsStr {
-		if strings.Contains(validatorsStr[i], "-") {
+		validatorStr := strings.TrimSpace(validatorsStr[i])
+		if validatorStr == "" {
+			continue
+		}
+
+		if strings.HasPrefix(validatorStr, "0x") {
+			// Public key.
+			data, err := hex.DecodeString(strings.TrimPrefix(validatorStr, "0x"))
+			if err != nil || len(data) != phase0.PublicKeyLength {
+				return nil, nil, fmt.Errorf("invalid public key %s", validatorStr)
+			}
+			pubKey := phase0.BLSPubKey{}
+			copy(pubKey[:], data)
+			pubKeys = append(pubKeys, pubKey)
+			continue
+		}
+
+		index, err := strconv.ParseUint(validatorStr, 10, 64)
+		if err == nil {
+			indices = append(indices, phase0.ValidatorIndex(index))
+			continue
+		}
+
+		if info, statErr := os.Stat(validatorStr); allowFiles && statErr == nil && !info.IsDir() {
+			// File containing validators.
+			data, err := os.ReadFile(validatorStr)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, fmt.Sprintf("failed to read validators from %s", validatorStr))
+			}
+			fileIndices, filePubKeys, err := parseValidatorsStr(strings.FieldsFunc(string(data), func(r rune) bool {
+				return r == ',' || unicode.IsSpace(r)
+			}), false)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, fmt.Sprintf("invalid validators in %s", validatorStr))
+			}
+			indices = append(indices, fileIndices...)
+			pubKeys = append(pubKeys, filePubKeys...)
+			continue
+		}
+
+		if strings.Contains(validatorStr, "-") {
 			// Range.
-			bits := strings.Split(validatorsStr[i], "-")
+			bits := strings.Split(validatorStr, "-")
 			if len(bits) != 2 {
-				return nil, fmt.Errorf("invalid range %s", validatorsStr[i])
+				return nil, nil, fmt.Errorf("invalid range %s", validatorStr)
 			}
 			low, err := strconv.ParseUint(bits[0], 10, 64)
 			if err != nil {
-				return nil, errors.Wrap(err, "invalid range start")
+				return nil, nil, errors.Wrap(err, "invalid range start")
 			}
 			high, err := strconv.ParseUint(bits[1], 10, 64)
 			if err != nil {
-				return nil, errors.Wrap(err, "invalid range end")
+				return nil, nil, errors.Wrap(err, "invalid range end")
 			}
 			for index := low; index <= high; index++ {
 				indices = append(indices, phase0.ValidatorIndex(index))
 			}
-		} else {
-			index, err := strconv.ParseUint(validatorsStr[i], 10, 64)
-			if err != nil {
-				return nil, errors.Wrapf(err, "failed to parse validator %s", validatorsStr[i])
-			}
-			indices = append(indices, phase0.ValidatorIndex(index))
+			continue
 		}
-	}
 
-	response, err := validatorsProvider.Validators(ctx, &api.ValidatorsOpts{State: stateID, Indices: indices})
-	if err != nil {
-		return nil, errors.Wrap(err, fmt.Sprintf("failed to obtain validators %v", indices))
-	}
-	for _, validator := range response.Data {
-		validators = append(validators, validator)
+		return nil, nil, errors.Wrapf(err, "failed to parse validator %s", validatorStr)
 	}
-	return validators, nil
+
+	return indices, pubKeys, nil
 }
 
 // ParseValidator parses input to obtain the validator.
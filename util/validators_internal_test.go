@@ -0,0 +1,101 @@
+// Copyright © 2024 Weald Technology Trading.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/ethdo/testutil"
+)
+
+func TestParseValidatorsStr(t *testing.T) {
+	pubKeyStr := "0xa99a76ed7796f7be22d5b7e85deeb7c5677e88e511e0b337618f8c4eb61349b4bf2d153f649f7b53359fe8b94a38e44c"
+	pubKey := phase0.BLSPubKey{}
+	copy(pubKey[:], testutil.HexToBytes(pubKeyStr))
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "validators.txt")
+	require.NoError(t, os.WriteFile(file, []byte("1, 2\n5-6\n"+pubKeyStr+"\n"), 0o600))
+	badFile := filepath.Join(dir, "bad-validators.txt")
+	require.NoError(t, os.WriteFile(badFile, []byte("1\nbad\n"), 0o600))
+
+	tests := []struct {
+		name          string
+		validatorsStr []string
+		indices       []phase0.ValidatorIndex
+		pubKeys       []phase0.BLSPubKey
+		err           string
+	}{
+		{
+			name:    "Empty",
+			indices: []phase0.ValidatorIndex{},
+			pubKeys: []phase0.BLSPubKey{},
+		},
+		{
+			name:          "Indices",
+			validatorsStr: []string{"1", " 2 ", "10-12"},
+			indices:       []phase0.ValidatorIndex{1, 2, 10, 11, 12},
+			pubKeys:       []phase0.BLSPubKey{},
+		},
+		{
+			name:          "PubKey",
+			validatorsStr: []string{pubKeyStr, "3"},
+			indices:       []phase0.ValidatorIndex{3},
+			pubKeys:       []phase0.BLSPubKey{pubKey},
+		},
+		{
+			name:          "PubKeyInvalid",
+			validatorsStr: []string{"0x1234"},
+			err:           "invalid public key 0x1234",
+		},
+		{
+			name:          "RangeInvalid",
+			validatorsStr: []string{"1-2-3"},
+			err:           "invalid range 1-2-3",
+		},
+		{
+			name:          "File",
+			validatorsStr: []string{file, "7"},
+			indices:       []phase0.ValidatorIndex{1, 2, 5, 6, 7},
+			pubKeys:       []phase0.BLSPubKey{pubKey},
+		},
+		{
+			name:          "FileInvalid",
+			validatorsStr: []string{badFile},
+			err:           "invalid validators in " + badFile + `: failed to parse validator bad: strconv.ParseUint: parsing "bad": invalid syntax`,
+		},
+		{
+			name:          "Unknown",
+			validatorsStr: []string{"bad"},
+			err:           `failed to parse validator bad: strconv.ParseUint: parsing "bad": invalid syntax`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			indices, pubKeys, err := parseValidatorsStr(test.validatorsStr, true)
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.indices, indices)
+			require.Equal(t, test.pubKeys, pubKeys)
+		})
+	}
+}